@@ -0,0 +1,8 @@
+package clientstore
+
+// Config 클라이언트 저장소 설정
+type Config struct {
+	// Secret 저장소에 저장되는 client_secret_jwt 클라이언트 시크릿 원문을 암호화 할 때 사용할 비밀키
+	// 서명키 저장소의 비밀키와 별도로 설정하여 서로 영향을 주지 않고 교체 할 수 있다.
+	Secret string `json:"secret"`
+}
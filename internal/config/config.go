@@ -2,9 +2,15 @@ package config
 
 import (
 	"encoding/json"
+	"oauth-server-go/internal/config/clientstore"
 	"oauth-server-go/internal/config/db"
+	"oauth-server-go/internal/config/dpop"
+	"oauth-server-go/internal/config/jwtbearer"
+	"oauth-server-go/internal/config/keystore"
 	"oauth-server-go/internal/config/log"
+	"oauth-server-go/internal/config/mtls"
 	"oauth-server-go/internal/config/redis"
+	"oauth-server-go/internal/config/registration"
 	"oauth-server-go/internal/config/session"
 	"os"
 	"path/filepath"
@@ -12,11 +18,28 @@ import (
 
 // Config 어플리케이션에서 사용되는 설정
 type Config struct {
-	Port    string         `json:"port"`
+	Port string `json:"port"`
+
+	// Issuer 인가 서버의 식별자(iss)로 사용될 URL
+	// JWT 형식의 토큰을 발급할 때 iss 클레임으로 사용된다.
+	Issuer string `json:"issuer"`
+
 	DB      db.Config      `json:"db"`
 	Redis   redis.Config   `json:"redis"`
 	Session session.Config `json:"session"`
 	Logger  log.Config     `json:"logger"`
+
+	KeyStore keystore.Config `json:"keystore"`
+
+	ClientStore clientstore.Config `json:"client_store"`
+
+	DPoP dpop.Config `json:"dpop"`
+
+	TLS mtls.Config `json:"tls"`
+
+	JWTBearer jwtbearer.Config `json:"jwt_bearer"`
+
+	Registration registration.Config `json:"registration"`
 }
 
 // Read /config 폴더의 config.<profile>.json 파일을 읽어 어플리케이션 설정 인스턴스를 생성한다.
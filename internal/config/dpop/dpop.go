@@ -0,0 +1,29 @@
+package dpop
+
+import "time"
+
+const defaultNonceLifetime = 5 * time.Minute
+
+// Config DPoP [RFC 9449] 증명 검증 설정
+//
+// [RFC 9449]: https://datatracker.ietf.org/doc/html/rfc9449
+type Config struct {
+	// RequireNonce DPoP 증명에 서버가 발급한 nonce 포함을 강제할지 여부
+	RequireNonce bool `json:"require_nonce"`
+
+	// NonceSecret 서버 nonce 서명에 사용할 비밀키
+	// 여러 서버 인스턴스가 같은 nonce 를 검증 할 수 있도록 모든 인스턴스에 같은 값을 설정해야 한다.
+	// 설정하지 않을 경우 어플리케이션 기동시 랜덤한 값을 생성한다.
+	NonceSecret string `json:"nonce_secret"`
+
+	// NonceLifetimeSec 서버 nonce 의 유효기간. 초단위로 설정되며 설정하지 않을 경우 5분으로 설정된다.
+	NonceLifetimeSec int `json:"nonce_lifetime_sec"`
+}
+
+// NonceLifetime 서버 nonce 의 유효기간을 반환한다.
+func (c *Config) NonceLifetime() time.Duration {
+	if c.NonceLifetimeSec <= 0 {
+		return defaultNonceLifetime
+	}
+	return time.Duration(c.NonceLifetimeSec) * time.Second
+}
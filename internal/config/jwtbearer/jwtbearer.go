@@ -0,0 +1,39 @@
+package jwtbearer
+
+import "github.com/go-jose/go-jose/v4"
+
+// Config [RFC 7523] JWT 인가 승인 방식 설정
+// 신뢰하는 발급자가 설정되지 않은 경우 JWT 인가 승인 방식을 지원하지 않는다.
+//
+// [RFC 7523]: https://datatracker.ietf.org/doc/html/rfc7523#section-2.1
+type Config struct {
+	// TrustedIssuers JWT 어설션을 발급 할 수 있는 신뢰하는 발급자 목록
+	TrustedIssuers []TrustedIssuer `json:"trusted_issuers"`
+}
+
+// TrustedIssuer 신뢰하는 발급자 설정
+type TrustedIssuer struct {
+	// Issuer 어설션의 iss 클레임과 비교할 발급자 식별자
+	Issuer string `json:"issuer"`
+
+	// JWKSURI 발급자의 공개키 목록을 조회할 수 있는 URI. JWKS 가 설정된 경우 사용하지 않는다.
+	JWKSURI string `json:"jwks_uri"`
+
+	// JWKS 발급자의 공개키 목록
+	JWKS *jose.JSONWebKeySet `json:"jwks"`
+
+	// SubjectMappings 어설션의 주체(sub)를 자원 소유자 아이디로 변환하는 규칙 목록. 순서대로 적용된다.
+	SubjectMappings []SubjectMapping `json:"subject_mappings"`
+
+	// Scopes 발급자의 어설션으로 부여 할 수 있는 스코프 목록
+	Scopes []string `json:"scopes"`
+}
+
+// SubjectMapping 주체 변환 규칙 설정
+type SubjectMapping struct {
+	// Pattern 주체 전체와 일치해야 하는 정규 표현식
+	Pattern string `json:"pattern"`
+
+	// Username 자원 소유자 아이디 템플릿. Pattern 의 캡처 그룹을 $1, ${name} 형식으로 참조 할 수 있다.
+	Username string `json:"username"`
+}
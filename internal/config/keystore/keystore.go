@@ -0,0 +1,64 @@
+package keystore
+
+import "time"
+
+const (
+	// StorageDB 서명키를 데이터베이스에 저장한다.
+	StorageDB = "db"
+
+	// StorageFile 서명키를 디스크에 암호화 된 파일로 저장한다.
+	StorageFile = "file"
+)
+
+const (
+	defaultRotationPeriod = 30 * 24 * time.Hour
+	defaultCheckInterval  = time.Hour
+)
+
+// Config 토큰 서명키 저장소 및 교체 설정
+type Config struct {
+	// Storage 서명키 저장소 종류("db", "file") 설정하지 않을 경우 데이터베이스를 사용한다.
+	Storage string `json:"storage"`
+
+	// Dir 파일 저장소를 사용할 경우 서명키 파일들을 저장할 디렉토리
+	Dir string `json:"dir"`
+
+	// Secret 저장소에 저장되는 개인키를 암호화 할 때 사용할 비밀키
+	Secret string `json:"secret"`
+
+	// Algorithms 서명에 사용할 알고리즘 목록(RS256, PS256, ES256)
+	// 알고리즘 별로 키가 관리되며 첫번째 알고리즘이 기본 서명 알고리즘으로 사용된다. 설정하지 않을 경우 RS256을 사용한다.
+	Algorithms []string `json:"algorithms"`
+
+	// RotationPeriodSec 서명키가 활성화 된 후 교체 될 때까지의 기간. 초단위로 설정되며 설정하지 않을 경우 30일로 설정된다.
+	RotationPeriodSec int `json:"rotation_period_sec"`
+
+	// RetentionSec 퇴역한 서명키를 공개키 목록(JWKS)에 유지할 기간. 초단위로 설정된다.
+	// 서명된 토큰의 최대 유효기간과 교체 확인 주기(CheckIntervalSec)를 더한 기간보다 짧게 설정된 경우 그 기간으로 설정된다.
+	RetentionSec int `json:"retention_sec"`
+
+	// CheckIntervalSec 서명키 교체 여부를 확인할 주기. 초단위로 설정되며 설정하지 않을 경우 1시간으로 설정된다.
+	// 여러 서버 인스턴스가 같은 저장소를 사용할 경우 다른 인스턴스에서 교체한 키도 이 주기로 반영된다.
+	CheckIntervalSec int `json:"check_interval_sec"`
+}
+
+// RotationPeriod 서명키 교체 주기를 반환한다.
+func (c *Config) RotationPeriod() time.Duration {
+	if c.RotationPeriodSec <= 0 {
+		return defaultRotationPeriod
+	}
+	return time.Duration(c.RotationPeriodSec) * time.Second
+}
+
+// Retention 퇴역한 서명키의 유지 기간을 반환한다. 인자로 받은 최소 기간보다 짧을 경우 최소 기간을 반환한다.
+func (c *Config) Retention(min time.Duration) time.Duration {
+	return max(time.Duration(c.RetentionSec)*time.Second, min)
+}
+
+// CheckInterval 서명키 교체 여부 확인 주기를 반환한다.
+func (c *Config) CheckInterval() time.Duration {
+	if c.CheckIntervalSec <= 0 {
+		return defaultCheckInterval
+	}
+	return time.Duration(c.CheckIntervalSec) * time.Second
+}
@@ -0,0 +1,56 @@
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// Config 서버 TLS 및 상호 TLS [RFC 8705] 클라이언트 인증 설정
+// 인증서와 개인키가 설정되지 않은 경우 서버는 TLS 없이 동작하며 상호 TLS 클라이언트 인증을 사용할 수 없다.
+//
+// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705
+type Config struct {
+	// CertFile 서버 인증서 파일(PEM) 경로
+	CertFile string `json:"cert_file"`
+
+	// KeyFile 서버 인증서의 개인키 파일(PEM) 경로
+	KeyFile string `json:"key_file"`
+
+	// ClientCAFile tls_client_auth 인증시 클라이언트 인증서를 검증할 신뢰하는 인증 기관 인증서 파일(PEM) 경로
+	// 설정하지 않을 경우 tls_client_auth 인증을 사용할 수 없다.
+	ClientCAFile string `json:"client_ca_file"`
+}
+
+// Enabled 서버가 TLS로 동작하도록 설정되었는지 여부를 반환한다.
+func (c *Config) Enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// ServerConfig 서버의 TLS 설정을 반환한다.
+// 자체 서명 인증서로 인증하는 클라이언트도 있으므로 TLS 핸드쉐이크에서는 클라이언트 인증서를 요청만 하고 검증하지 않는다.
+// 클라이언트 인증서의 검증은 클라이언트 인증 과정에서 클라이언트의 인증 방식에 따라 진행된다.
+func (c *Config) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.RequestClientCert,
+	}
+}
+
+// ClientCAs 신뢰하는 인증 기관 인증서 목록을 반환한다. 설정되지 않은 경우 nil 을 반환한다.
+func (c *Config) ClientCAs() (*x509.CertPool, error) {
+	if c.ClientCAFile == "" {
+		return nil, nil
+	}
+	pem, err := os.ReadFile(c.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in %s", c.ClientCAFile)
+	}
+	return pool, nil
+}
@@ -0,0 +1,15 @@
+package registration
+
+// Config [RFC 7591] 동적 클라이언트 등록 설정
+//
+// 초기 엑세스 토큰 없이 요청한 익명 등록은 안전한 인가 방식과 AnonymousScopes 에 설정된 스코프만 등록 할 수 있다.
+//
+// [RFC 7591]: https://datatracker.ietf.org/doc/html/rfc7591#section-3
+type Config struct {
+	// InitialAccessToken 제한 없이 클라이언트를 등록 할 수 있는 초기 엑세스 토큰
+	// 설정하지 않을 경우 익명 등록만 허용한다.
+	InitialAccessToken string `json:"initial_access_token"`
+
+	// AnonymousScopes 익명 등록으로 등록 할 수 있는 스코프 목록
+	AnonymousScopes []string `json:"anonymous_scopes"`
+}
@@ -0,0 +1,271 @@
+package authorization
+
+import (
+	"fmt"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/scope"
+	"oauth-server-go/pkg/array"
+	"oauth-server-go/pkg/period"
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// backchannelExpires 백채널 인증 요청의 기본 만료 시간 (10분)
+	backchannelExpires = time.Minute * 10
+
+	// backchannelMaxExpires 클라이언트가 requested_expiry 로 요청 할 수 있는 최대 만료 시간 (30분)
+	backchannelMaxExpires = time.Minute * 30
+
+	// BackchannelPollingInterval poll 방식의 클라이언트가 토큰 엔드포인트를 폴링할 최소 간격 (5초)
+	BackchannelPollingInterval = time.Second * 5
+
+	// backchannelPollingSlowDown 폴링 간격을 지키지 않았을 때 늘어나는 폴링 간격 (5초)
+	backchannelPollingSlowDown = time.Second * 5
+
+	// backchannelBindingMessageMaxLength 인증 기기에 표시되는 바인딩 메시지의 최대 길이
+	backchannelBindingMessageMaxLength = 64
+)
+
+// BackchannelStatus 백채널 인증 요청의 상태
+type BackchannelStatus string
+
+const (
+	// BackchannelStatusPending 자원 소유자의 승인을 기다리는 상태
+	BackchannelStatusPending BackchannelStatus = "pending"
+
+	// BackchannelStatusApproved 자원 소유자가 승인한 상태
+	BackchannelStatusApproved BackchannelStatus = "approved"
+
+	// BackchannelStatusDenied 자원 소유자가 거부한 상태
+	BackchannelStatusDenied BackchannelStatus = "denied"
+)
+
+// BackchannelRequest [OpenID CIBA] 백채널 인증 요청 파라미터
+//
+// 자원 소유자를 식별하는 힌트(login_hint, login_hint_token, id_token_hint)는 하나만 입력 해야 하며 현재는 login_hint 만 지원한다.
+//
+// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.7.1
+type BackchannelRequest struct {
+	Scopes         string `form:"scope"`
+	LoginHint      string `form:"login_hint"`
+	LoginHintToken string `form:"login_hint_token"`
+	IDTokenHint    string `form:"id_token_hint"`
+
+	// BindingMessage 클라이언트와 인증 기기 양쪽에 표시되어 자원 소유자가 같은 요청인지 확인 할 수 있게 하는 짧은 메시지
+	BindingMessage string `form:"binding_message"`
+
+	// ClientNotificationToken ping 방식에서 인가 서버가 클라이언트의 알림 엔드포인트를 호출할 때 사용하는 Bearer 토큰
+	ClientNotificationToken string `form:"client_notification_token"`
+
+	// RequestedExpiry 클라이언트가 요청한 인증 요청 식별자(auth_req_id)의 만료 시간(초)
+	RequestedExpiry uint `form:"requested_expiry"`
+}
+
+// BackchannelAuthentication [OpenID CIBA] 백채널 인증 요청
+//
+// 클라이언트는 브라우저 리다이렉트 없이 자원 소유자를 식별하는 힌트로 인증을 요청하며,
+// 인가 서버는 자원 소유자의 인증 기기로 요청을 알리고 자원 소유자는 인증 기기에서 요청을 승인한다.
+// 클라이언트는 발급 받은 인증 요청 식별자(auth_req_id)로 토큰 엔드포인트를 폴링하거나(poll) 인가 서버의 알림을 받은 후(ping) 토큰을 발급 받는다.
+//
+// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html
+type BackchannelAuthentication struct {
+	// authReqID 클라이언트가 토큰 발급에 사용하는 인증 요청 식별자
+	authReqID string
+
+	// client 인증을 요청한 클라이언트
+	client *client.Client
+
+	// username 힌트로 식별된 자원 소유자의 식별자
+	username string
+
+	// scopes 클라이언트가 요청한 스코프 승인 후에는 자원 소유자가 승인한 스코프로 변경된다.
+	scopes []string
+
+	// bindingMessage 인증 기기에 표시할 바인딩 메시지
+	bindingMessage string
+
+	// notificationToken ping 방식에서 클라이언트 알림 엔드포인트 호출에 사용할 Bearer 토큰
+	notificationToken string
+
+	status BackchannelStatus
+
+	// interval 토큰 엔드포인트 폴링 간격
+	interval time.Duration
+
+	// lastPolledAt 마지막으로 토큰 엔드포인트를 폴링한 시간
+	lastPolledAt time.Time
+
+	// authTime, acr, amr 요청을 승인한 자원 소유자가 인증 기기에서 로그인한 시간과 인증 컨텍스트 클래스, 인증 방식
+	authTime time.Time
+	acr      string
+	amr      []string
+
+	period.Range
+}
+
+// NewBackchannelAuthentication 힌트로 식별된 자원 소유자에 대한 새 백채널 인증 요청을 생성한다.
+//
+// 요청한 스코프는 openid 를 포함해야 하며 모두 클라이언트에 등록된 스코프여야 한다.
+// 바인딩 메시지는 64자 이내여야 하며, ping 방식의 클라이언트는 client_notification_token 이 필수이다.
+// 만료 시간은 requested_expiry 가 있는 경우 최대 30분까지 요청한 값을, 없는 경우 10분을 사용한다.
+func NewBackchannelAuthentication(c *client.Client, r *BackchannelRequest, username string, g GenerateCode) (*BackchannelAuthentication, error) {
+	scopes := scope.Split(r.Scopes)
+	if !slices.Contains(scopes, scope.OpenID) {
+		return nil, fmt.Errorf("%w: openid scope is required", oautherr.ErrInvalidScope)
+	}
+	if !array.ContainsAll(c.Scopes(), scopes) {
+		return nil, oautherr.ErrInvalidScope
+	}
+	if utf8.RuneCountInString(r.BindingMessage) > backchannelBindingMessageMaxLength {
+		return nil, fmt.Errorf("%w: binding_message must be %d characters or less", oautherr.ErrInvalidBindingMessage, backchannelBindingMessageMaxLength)
+	}
+	if c.BackchannelTokenDeliveryMode() == client.BackchannelTokenDeliveryPing && r.ClientNotificationToken == "" {
+		return nil, fmt.Errorf("%w: client_notification_token", oautherr.ErrMissingParameter)
+	}
+
+	expires := backchannelExpires
+	if r.RequestedExpiry > 0 {
+		expires = min(time.Duration(r.RequestedExpiry)*time.Second, backchannelMaxExpires)
+	}
+	return &BackchannelAuthentication{
+		authReqID:         g(),
+		client:            c,
+		username:          username,
+		scopes:            scopes,
+		bindingMessage:    r.BindingMessage,
+		notificationToken: r.ClientNotificationToken,
+		status:            BackchannelStatusPending,
+		interval:          BackchannelPollingInterval,
+		Range:             period.New(expires),
+	}, nil
+}
+
+// NewBackchannelAuthenticationWithState 저장소에 저장된 값으로 백채널 인증 요청을 생성한다.
+func NewBackchannelAuthenticationWithState(authReqID string, c *client.Client, username string, scopes []string, bindingMessage, notificationToken string,
+	status BackchannelStatus, interval time.Duration, lastPolledAt, authTime time.Time, acr string, amr []string, r period.Range) *BackchannelAuthentication {
+	return &BackchannelAuthentication{
+		authReqID:         authReqID,
+		client:            c,
+		username:          username,
+		scopes:            scopes,
+		bindingMessage:    bindingMessage,
+		notificationToken: notificationToken,
+		status:            status,
+		interval:          interval,
+		lastPolledAt:      lastPolledAt,
+		authTime:          authTime,
+		acr:               acr,
+		amr:               amr,
+		Range:             r,
+	}
+}
+
+func (b *BackchannelAuthentication) AuthReqID() string {
+	return b.authReqID
+}
+
+func (b *BackchannelAuthentication) Client() *client.Client {
+	return b.client
+}
+
+func (b *BackchannelAuthentication) Username() string {
+	return b.username
+}
+
+func (b *BackchannelAuthentication) Scopes() []string {
+	return b.scopes
+}
+
+func (b *BackchannelAuthentication) BindingMessage() string {
+	return b.bindingMessage
+}
+
+func (b *BackchannelAuthentication) NotificationToken() string {
+	return b.notificationToken
+}
+
+func (b *BackchannelAuthentication) Status() BackchannelStatus {
+	return b.status
+}
+
+func (b *BackchannelAuthentication) Interval() time.Duration {
+	return b.interval
+}
+
+func (b *BackchannelAuthentication) LastPolledAt() time.Time {
+	return b.lastPolledAt
+}
+
+func (b *BackchannelAuthentication) AuthTime() time.Time {
+	return b.authTime
+}
+
+func (b *BackchannelAuthentication) ACR() string {
+	return b.acr
+}
+
+func (b *BackchannelAuthentication) AMR() []string {
+	return b.amr
+}
+
+// Approve 자원 소유자가 백채널 인증 요청을 승인한다.
+// 요청의 자원 소유자만 승인 할 수 있으며 승인한 스코프가 없는 경우 거부한 것으로 처리한다.
+// authTime, acr, amr 은 승인한 자원 소유자가 실제로 로그인한 시간과 인증 컨텍스트 클래스, 인증 방식으로 ID 토큰과 엑세스 토큰의 auth_time, acr, amr 로 사용된다.
+func (b *BackchannelAuthentication) Approve(username string, scopes []string, authTime time.Time, acr string, amr []string) error {
+	if b.status != BackchannelStatusPending || !b.Available() {
+		return fmt.Errorf("%w: backchannel authentication is not pending", oautherr.ErrExpiredResource)
+	}
+	if username == "" || username != b.username {
+		return fmt.Errorf("%w: user is not allowed to approve the request", oautherr.ErrAccessDenied)
+	}
+	if len(scopes) == 0 {
+		b.status = BackchannelStatusDenied
+		return nil
+	}
+	if !array.ContainsAll(b.scopes, scopes) {
+		return oautherr.ErrInvalidScope
+	}
+	if !slices.Contains(scopes, scope.OpenID) {
+		scopes = append([]string{scope.OpenID}, scopes...)
+	}
+	b.scopes = scopes
+	b.status = BackchannelStatusApproved
+	b.authTime = authTime
+	b.acr = acr
+	b.amr = amr
+	return nil
+}
+
+// Poll 클라이언트의 토큰 엔드포인트 폴링을 처리한다.
+// 자원 소유자가 승인한 경우에만 nil 을 반환하며 그 외에는 [OpenID CIBA] 에 정의된 에러를 반환한다.
+//
+//   - 만료된 경우: [oautherr.ErrExpiredToken]
+//   - poll 방식에서 폴링 간격보다 빠르게 폴링한 경우: [oautherr.ErrSlowDown] 이후 폴링 간격이 늘어난다.
+//   - 자원 소유자가 거부한 경우: [oautherr.ErrAccessDenied]
+//   - 자원 소유자의 승인을 기다리는 경우: [oautherr.ErrAuthorizationPending]
+//
+// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.11
+func (b *BackchannelAuthentication) Poll(now time.Time) error {
+	if !b.Available() {
+		return fmt.Errorf("%w: auth_req_id is expired", oautherr.ErrExpiredToken)
+	}
+
+	last := b.lastPolledAt
+	b.lastPolledAt = now
+	if b.client.BackchannelTokenDeliveryMode() == client.BackchannelTokenDeliveryPoll && !last.IsZero() && now.Sub(last) < b.interval {
+		b.interval += backchannelPollingSlowDown
+		return fmt.Errorf("%w: polling interval is %d seconds", oautherr.ErrSlowDown, b.interval/time.Second)
+	}
+
+	switch b.status {
+	case BackchannelStatusApproved:
+		return nil
+	case BackchannelStatusDenied:
+		return fmt.Errorf("%w: resource owner denied access", oautherr.ErrAccessDenied)
+	default:
+		return oautherr.ErrAuthorizationPending
+	}
+}
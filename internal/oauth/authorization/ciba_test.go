@@ -0,0 +1,151 @@
+package authorization
+
+import (
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/pkg/period"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestBackchannelClient(mode client.BackchannelTokenDeliveryMode) *client.Client {
+	c := client.New("test_client", "secret", "test", client.TypeConfidential)
+	c.AddScope("openid")
+	c.AddScope("read")
+	c.AddScope("write")
+	c.SetBackchannelTokenDeliveryMode(mode)
+	return c
+}
+
+func newTestBackchannelAuthentication(mode client.BackchannelTokenDeliveryMode, status BackchannelStatus, r period.Range) *BackchannelAuthentication {
+	c := newTestBackchannelClient(mode)
+	return NewBackchannelAuthenticationWithState("auth_req_id", c, "user", []string{"openid", "read", "write"}, "", "",
+		status, BackchannelPollingInterval, time.Time{}, time.Time{}, "", nil, r)
+}
+
+func TestNewBackchannelAuthentication(t *testing.T) {
+	generate := func() string { return "auth_req_id" }
+
+	t.Run("승인 대기 상태의 요청 생성", func(t *testing.T) {
+		r := &BackchannelRequest{Scopes: "openid read", LoginHint: "user", BindingMessage: "W4SCT"}
+
+		b, err := NewBackchannelAuthentication(newTestBackchannelClient(client.BackchannelTokenDeliveryPoll), r, "user", generate)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "auth_req_id", b.AuthReqID())
+		assert.Equal(t, BackchannelStatusPending, b.Status())
+		assert.Equal(t, []string{"openid", "read"}, b.Scopes())
+		assert.Equal(t, "W4SCT", b.BindingMessage())
+	})
+
+	t.Run("requested_expiry 는 최대 만료 시간으로 제한", func(t *testing.T) {
+		r := &BackchannelRequest{Scopes: "openid", RequestedExpiry: 60 * 60}
+
+		b, err := NewBackchannelAuthentication(newTestBackchannelClient(client.BackchannelTokenDeliveryPoll), r, "user", generate)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, uint(backchannelMaxExpires/time.Second), b.ExpiresIn(), 1)
+	})
+
+	t.Run("openid 스코프가 없는 경우 ErrInvalidScope", func(t *testing.T) {
+		r := &BackchannelRequest{Scopes: "read"}
+
+		_, err := NewBackchannelAuthentication(newTestBackchannelClient(client.BackchannelTokenDeliveryPoll), r, "user", generate)
+		assert.ErrorIs(t, err, oautherr.ErrInvalidScope)
+	})
+
+	t.Run("클라이언트에 등록되지 않은 스코프 요청시 ErrInvalidScope", func(t *testing.T) {
+		r := &BackchannelRequest{Scopes: "openid admin"}
+
+		_, err := NewBackchannelAuthentication(newTestBackchannelClient(client.BackchannelTokenDeliveryPoll), r, "user", generate)
+		assert.ErrorIs(t, err, oautherr.ErrInvalidScope)
+	})
+
+	t.Run("바인딩 메시지가 너무 긴 경우 ErrInvalidBindingMessage", func(t *testing.T) {
+		r := &BackchannelRequest{Scopes: "openid", BindingMessage: strings.Repeat("가", backchannelBindingMessageMaxLength+1)}
+
+		_, err := NewBackchannelAuthentication(newTestBackchannelClient(client.BackchannelTokenDeliveryPoll), r, "user", generate)
+		assert.ErrorIs(t, err, oautherr.ErrInvalidBindingMessage)
+	})
+
+	t.Run("ping 방식에서 client_notification_token 누락시 ErrMissingParameter", func(t *testing.T) {
+		r := &BackchannelRequest{Scopes: "openid"}
+
+		_, err := NewBackchannelAuthentication(newTestBackchannelClient(client.BackchannelTokenDeliveryPing), r, "user", generate)
+		assert.ErrorIs(t, err, oautherr.ErrMissingParameter)
+	})
+}
+
+func TestBackchannelAuthentication_Approve(t *testing.T) {
+	now := time.Now().Add(-time.Hour)
+
+	t.Run("승인한 스코프로 변경", func(t *testing.T) {
+		b := newTestBackchannelAuthentication(client.BackchannelTokenDeliveryPoll, BackchannelStatusPending, period.New(time.Minute))
+
+		assert.NoError(t, b.Approve("user", []string{"read"}, now, "acr", []string{"pwd"}))
+		assert.Equal(t, BackchannelStatusApproved, b.Status())
+		assert.Equal(t, []string{"openid", "read"}, b.Scopes())
+		assert.Equal(t, now, b.AuthTime())
+		assert.Equal(t, "acr", b.ACR())
+		assert.Equal(t, []string{"pwd"}, b.AMR())
+	})
+
+	t.Run("승인한 스코프가 없는 경우 거부", func(t *testing.T) {
+		b := newTestBackchannelAuthentication(client.BackchannelTokenDeliveryPoll, BackchannelStatusPending, period.New(time.Minute))
+
+		assert.NoError(t, b.Approve("user", nil, now, "", nil))
+		assert.Equal(t, BackchannelStatusDenied, b.Status())
+	})
+
+	t.Run("다른 자원 소유자가 승인시 ErrAccessDenied", func(t *testing.T) {
+		b := newTestBackchannelAuthentication(client.BackchannelTokenDeliveryPoll, BackchannelStatusPending, period.New(time.Minute))
+
+		assert.ErrorIs(t, b.Approve("other", []string{"read"}, now, "", nil), oautherr.ErrAccessDenied)
+	})
+
+	t.Run("이미 승인된 요청은 ErrExpiredResource", func(t *testing.T) {
+		b := newTestBackchannelAuthentication(client.BackchannelTokenDeliveryPoll, BackchannelStatusApproved, period.New(time.Minute))
+
+		assert.ErrorIs(t, b.Approve("user", []string{"read"}, now, "", nil), oautherr.ErrExpiredResource)
+	})
+}
+
+func TestBackchannelAuthentication_Poll(t *testing.T) {
+	now := time.Now()
+	available := period.New(time.Minute)
+
+	t.Run("만료된 요청은 ErrExpiredToken", func(t *testing.T) {
+		b := newTestBackchannelAuthentication(client.BackchannelTokenDeliveryPoll, BackchannelStatusPending, period.NewWithStartEnd(now.Add(-time.Hour), now.Add(-time.Minute)))
+
+		assert.ErrorIs(t, b.Poll(now), oautherr.ErrExpiredToken)
+	})
+
+	t.Run("승인 대기중인 요청은 ErrAuthorizationPending", func(t *testing.T) {
+		b := newTestBackchannelAuthentication(client.BackchannelTokenDeliveryPoll, BackchannelStatusPending, available)
+
+		assert.ErrorIs(t, b.Poll(now), oautherr.ErrAuthorizationPending)
+	})
+
+	t.Run("poll 방식에서 폴링 간격보다 빠르게 폴링한 경우 ErrSlowDown", func(t *testing.T) {
+		b := newTestBackchannelAuthentication(client.BackchannelTokenDeliveryPoll, BackchannelStatusApproved, available)
+
+		_ = b.Poll(now)
+		assert.ErrorIs(t, b.Poll(now.Add(time.Second)), oautherr.ErrSlowDown)
+		assert.Equal(t, BackchannelPollingInterval+backchannelPollingSlowDown, b.Interval())
+	})
+
+	t.Run("ping 방식은 폴링 간격을 검사하지 않음", func(t *testing.T) {
+		b := newTestBackchannelAuthentication(client.BackchannelTokenDeliveryPing, BackchannelStatusApproved, available)
+
+		_ = b.Poll(now)
+		assert.NoError(t, b.Poll(now.Add(time.Second)))
+	})
+
+	t.Run("거부된 요청은 ErrAccessDenied", func(t *testing.T) {
+		b := newTestBackchannelAuthentication(client.BackchannelTokenDeliveryPoll, BackchannelStatusDenied, available)
+
+		assert.ErrorIs(t, b.Poll(now), oautherr.ErrAccessDenied)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/detail"
 	oautherr "oauth-server-go/internal/oauth/errors"
 	"oauth-server-go/internal/oauth/scope"
 	"oauth-server-go/pkg/array"
@@ -55,6 +56,26 @@ type Code struct {
 	codeChallenge       Challenge
 	codeChallengeMethod ChallengeMethod
 
+	// nonce 인가 요청시 받은 [OpenID Connect] nonce 값으로 ID 토큰 발급시 사용된다.
+	//
+	// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#IDToken
+	nonce string
+
+	// authTime 자원 소유자가 인증을 완료한 시간
+	authTime time.Time
+
+	// acr 자원 소유자 인증의 인증 컨텍스트 클래스 참조값
+	acr string
+
+	// amr 자원 소유자가 인증에 사용한 인증 방식 참조값 목록
+	amr []string
+
+	// resources 인가 요청시 받은 자원 지시자 목록. 토큰 발급시 토큰의 대상(aud)은 이 목록 이내로 제한된다.
+	resources []string
+
+	// authorizationDetails 인가 요청시 받은 인가 상세 정보. 토큰 발급시 토큰의 인가 상세 정보는 이 목록 이내로 제한된다.
+	authorizationDetails detail.Details
+
 	period.Range
 }
 
@@ -90,6 +111,30 @@ func (c *Code) CodeChallengeMethod() ChallengeMethod {
 	return c.codeChallengeMethod
 }
 
+func (c *Code) Nonce() string {
+	return c.nonce
+}
+
+func (c *Code) AuthTime() time.Time {
+	return c.authTime
+}
+
+func (c *Code) ACR() string {
+	return c.acr
+}
+
+func (c *Code) AMR() []string {
+	return c.amr
+}
+
+func (c *Code) Resources() []string {
+	return c.resources
+}
+
+func (c *Code) AuthorizationDetails() detail.Details {
+	return c.authorizationDetails
+}
+
 func NewCode(c *client.Client, g GenerateCode) *Code {
 	code := &Code{
 		value:  g(),
@@ -122,6 +167,16 @@ func (c *Code) CopyFrom(request *Request) error {
 	c.scopes = scopes
 	c.state = request.State
 	c.redirect = request.Redirect
+	c.nonce = request.Nonce
+	c.authTime = request.AuthTime
+	c.acr = request.ACR
+	c.amr = request.AMR
+	c.resources = request.Resource
+	details, err := detail.Parse(request.AuthorizationDetails)
+	if err != nil {
+		return err
+	}
+	c.authorizationDetails = details
 	c.codeChallenge = request.CodeChallenge
 	c.codeChallengeMethod = request.CodeChallengeMethod
 	if c.codeChallenge != "" && c.codeChallengeMethod == "" {
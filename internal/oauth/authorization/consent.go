@@ -0,0 +1,88 @@
+package authorization
+
+import (
+	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/pkg/period"
+	"slices"
+)
+
+// Consent 자원 소유자가 클라이언트에게 동의한 스코프
+//
+// 자원 소유자가 인가 승인 페이지에서 승인한 스코프를 클라이언트에 설정된 기간([client.Client.ConsentTTL]) 동안 기억하여,
+// 이미 동의한 스코프로 다시 인가 요청을 하는 경우 인가 승인 페이지를 보여주지 않고
+// 새로 요청한 스코프가 있는 경우 새로 요청한 스코프에 대해서만 동의를 받는다.
+type Consent struct {
+	username string
+	client   *client.Client
+
+	// scopes 자원 소유자가 동의한 스코프 목록
+	scopes []string
+
+	period.Range
+}
+
+// NewConsent 자원 소유자가 동의한 스코프로 새 동의를 생성한다. 유효 기간은 클라이언트에 설정된 기간으로 설정된다.
+func NewConsent(username string, c *client.Client, scopes []string) *Consent {
+	return &Consent{
+		username: username,
+		client:   c,
+		scopes:   scopes,
+		Range:    period.New(c.ConsentTTL()),
+	}
+}
+
+// NewConsentWithState 저장소에 저장된 값으로 동의를 생성한다.
+func NewConsentWithState(username string, c *client.Client, scopes []string, r period.Range) *Consent {
+	return &Consent{
+		username: username,
+		client:   c,
+		scopes:   scopes,
+		Range:    r,
+	}
+}
+
+func (c *Consent) Username() string {
+	return c.username
+}
+
+func (c *Consent) Client() *client.Client {
+	return c.client
+}
+
+func (c *Consent) Scopes() []string {
+	return c.scopes
+}
+
+// Consented 요청한 스코프 중 자원 소유자가 이미 동의한 스코프를 반환한다. 동의가 만료된 경우 nil 을 반환한다.
+func (c *Consent) Consented(requested []string) []string {
+	if !c.Available() {
+		return nil
+	}
+	var consented []string
+	for _, s := range requested {
+		if slices.Contains(c.scopes, s) {
+			consented = append(consented, s)
+		}
+	}
+	return consented
+}
+
+// Merge 자원 소유자가 새로 동의한 스코프를 기존에 동의한 스코프와 합쳐 새 동의를 생성한다.
+// 기존 동의의 최초 동의 시각은 유지하며 만료 시각만 연장된다.
+// 기존 동의가 만료된 경우 새로 동의한 스코프만 가지며, 유효 기간은 새로 시작된다.
+func (c *Consent) Merge(approved []string) *Consent {
+	var scopes []string
+	if c.Available() {
+		scopes = append(scopes, c.scopes...)
+	}
+	for _, s := range approved {
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	merged := NewConsent(c.username, c.client, scopes)
+	if c.Available() {
+		merged.Range = period.NewWithStartEnd(c.Start(), merged.End())
+	}
+	return merged
+}
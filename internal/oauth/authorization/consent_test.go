@@ -0,0 +1,65 @@
+package authorization
+
+import (
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/pkg/period"
+	"testing"
+	"time"
+)
+
+func TestNewConsent(t *testing.T) {
+	c := client.New("test_client", "", "test", client.TypeConfidential)
+	c.SetConsentTTL(time.Hour)
+
+	consent := NewConsent("user", c, []string{"read"})
+
+	assert.True(t, consent.Available())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), consent.End(), time.Second)
+}
+
+func TestConsent_Consented(t *testing.T) {
+	now := time.Now()
+	c := client.New("test_client", "", "test", client.TypeConfidential)
+
+	t.Run("요청한 스코프 중 동의한 스코프만 반환", func(t *testing.T) {
+		consent := NewConsentWithState("user", c, []string{"read", "write"}, period.New(time.Hour))
+
+		assert.Equal(t, []string{"read"}, consent.Consented([]string{"read", "delete"}))
+	})
+
+	t.Run("동의한 스코프가 없는 경우 nil", func(t *testing.T) {
+		consent := NewConsentWithState("user", c, []string{"read"}, period.New(time.Hour))
+
+		assert.Nil(t, consent.Consented([]string{"write"}))
+	})
+
+	t.Run("만료된 동의는 nil", func(t *testing.T) {
+		consent := NewConsentWithState("user", c, []string{"read"}, period.NewWithStartEnd(now.Add(-time.Hour), now.Add(-time.Minute)))
+
+		assert.Nil(t, consent.Consented([]string{"read"}))
+	})
+}
+
+func TestConsent_Merge(t *testing.T) {
+	now := time.Now()
+	c := client.New("test_client", "", "test", client.TypeConfidential)
+
+	t.Run("기존에 동의한 스코프에 새로 동의한 스코프를 추가", func(t *testing.T) {
+		consent := NewConsentWithState("user", c, []string{"read", "write"}, period.NewWithStartEnd(now.Add(-time.Hour), now.Add(time.Hour)))
+
+		merged := consent.Merge([]string{"write", "delete"})
+		assert.Equal(t, "user", merged.Username())
+		assert.Equal(t, []string{"read", "write", "delete"}, merged.Scopes())
+		assert.Equal(t, consent.Start(), merged.Start())
+		assert.True(t, merged.End().After(consent.End()))
+	})
+
+	t.Run("만료된 동의는 새로 동의한 스코프만 가짐", func(t *testing.T) {
+		consent := NewConsentWithState("user", c, []string{"read"}, period.NewWithStartEnd(now.Add(-time.Hour), now.Add(-time.Minute)))
+
+		merged := consent.Merge([]string{"write"})
+		assert.Equal(t, []string{"write"}, merged.Scopes())
+		assert.True(t, merged.Available())
+	})
+}
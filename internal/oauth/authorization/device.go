@@ -0,0 +1,248 @@
+package authorization
+
+import (
+	"fmt"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/pkg/array"
+	"oauth-server-go/pkg/period"
+	"strings"
+	"time"
+)
+
+const (
+	// deviceCodeExpiresMinute 디바이스 코드의 만료 시간 (10분)
+	deviceCodeExpiresMinute = time.Minute * 10
+
+	// DevicePollingInterval 클라이언트가 토큰 엔드포인트를 폴링할 최소 간격 (5초)
+	DevicePollingInterval = time.Second * 5
+
+	// devicePollingSlowDown 폴링 간격을 지키지 않았을 때 [RFC 8628] 에 따라 늘어나는 폴링 간격 (5초)
+	//
+	// [RFC 8628]: https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+	devicePollingSlowDown = time.Second * 5
+)
+
+// DeviceStatus 디바이스 인가 요청의 상태
+type DeviceStatus string
+
+const (
+	// DeviceStatusPending 자원 소유자의 승인을 기다리는 상태
+	DeviceStatusPending DeviceStatus = "pending"
+
+	// DeviceStatusApproved 자원 소유자가 승인한 상태
+	DeviceStatusApproved DeviceStatus = "approved"
+
+	// DeviceStatusDenied 자원 소유자가 거부한 상태
+	DeviceStatusDenied DeviceStatus = "denied"
+)
+
+// DeviceCode [RFC 8628] 디바이스 인가 요청
+//
+// 브라우저를 사용 할 수 없거나 입력이 제한된 디바이스에서 사용된다.
+// 디바이스는 발급 받은 디바이스 코드(device_code)로 토큰 엔드포인트를 폴링하며,
+// 자원 소유자는 다른 기기에서 사용자 코드(user_code)를 입력하여 인가를 승인한다.
+//
+// [RFC 8628]: https://datatracker.ietf.org/doc/html/rfc8628
+type DeviceCode struct {
+	// deviceCode 디바이스가 토큰 엔드포인트 폴링에 사용하는 코드
+	deviceCode string
+
+	// userCode 자원 소유자가 인증 페이지에서 입력하는 코드
+	userCode string
+
+	// client 인가를 요청한 클라이언트
+	client *client.Client
+
+	// scopes 디바이스가 요청한 스코프 승인 후에는 자원 소유자가 승인한 스코프로 변경된다.
+	scopes []string
+
+	// username 인가를 승인한 자원 소유자의 식별자
+	username string
+
+	status DeviceStatus
+
+	// interval 토큰 엔드포인트 폴링 간격
+	interval time.Duration
+
+	// lastPolledAt 마지막으로 토큰 엔드포인트를 폴링한 시간
+	lastPolledAt time.Time
+
+	// authTime, acr, amr 인가를 승인한 자원 소유자가 로그인한 시간과 인증 컨텍스트 클래스, 인증 방식
+	authTime time.Time
+	acr      string
+	amr      []string
+
+	period.Range
+}
+
+// NewDeviceCode 새 디바이스 인가 요청을 생성한다.
+// 요청한 스코프는 모두 클라이언트에 등록된 스코프여야 한다.
+func NewDeviceCode(c *client.Client, scopes []string, deviceCode, userCode GenerateCode) (*DeviceCode, error) {
+	if !array.ContainsAll(c.Scopes(), scopes) {
+		return nil, oautherr.ErrInvalidScope
+	}
+	return &DeviceCode{
+		deviceCode: deviceCode(),
+		userCode:   userCode(),
+		client:     c,
+		scopes:     scopes,
+		status:     DeviceStatusPending,
+		interval:   DevicePollingInterval,
+		Range:      period.New(deviceCodeExpiresMinute),
+	}, nil
+}
+
+// NewDeviceCodeWithState 저장소에 저장된 값으로 디바이스 인가 요청을 생성한다.
+func NewDeviceCodeWithState(deviceCode, userCode string, c *client.Client, scopes []string, username string, status DeviceStatus, interval time.Duration, lastPolledAt time.Time,
+	authTime time.Time, acr string, amr []string, r period.Range) *DeviceCode {
+	return &DeviceCode{
+		deviceCode:   deviceCode,
+		userCode:     userCode,
+		client:       c,
+		scopes:       scopes,
+		username:     username,
+		status:       status,
+		interval:     interval,
+		lastPolledAt: lastPolledAt,
+		authTime:     authTime,
+		acr:          acr,
+		amr:          amr,
+		Range:        r,
+	}
+}
+
+func (d *DeviceCode) DeviceCode() string {
+	return d.deviceCode
+}
+
+func (d *DeviceCode) UserCode() string {
+	return d.userCode
+}
+
+func (d *DeviceCode) Client() *client.Client {
+	return d.client
+}
+
+func (d *DeviceCode) Scopes() []string {
+	return d.scopes
+}
+
+func (d *DeviceCode) Username() string {
+	return d.username
+}
+
+func (d *DeviceCode) Status() DeviceStatus {
+	return d.status
+}
+
+func (d *DeviceCode) Interval() time.Duration {
+	return d.interval
+}
+
+func (d *DeviceCode) LastPolledAt() time.Time {
+	return d.lastPolledAt
+}
+
+func (d *DeviceCode) AuthTime() time.Time {
+	return d.authTime
+}
+
+func (d *DeviceCode) ACR() string {
+	return d.acr
+}
+
+func (d *DeviceCode) AMR() []string {
+	return d.amr
+}
+
+// Approve 자원 소유자가 디바이스 인가 요청을 승인한다.
+// 승인한 스코프가 없는 경우 거부한 것으로 처리한다.
+// authTime, acr, amr 은 승인한 자원 소유자의 로그인 정보로 발급되는 엑세스 토큰의 인증 정보로 사용된다.
+func (d *DeviceCode) Approve(username string, scopes []string, authTime time.Time, acr string, amr []string) error {
+	if d.status != DeviceStatusPending || !d.Available() {
+		return fmt.Errorf("%w: device code is not pending", oautherr.ErrExpiredResource)
+	}
+	if username == "" {
+		return fmt.Errorf("%w: username", oautherr.ErrMissingParameter)
+	}
+	if len(scopes) == 0 {
+		d.status = DeviceStatusDenied
+		return nil
+	}
+	if !array.ContainsAll(d.scopes, scopes) {
+		return oautherr.ErrInvalidScope
+	}
+	d.username = username
+	d.scopes = scopes
+	d.status = DeviceStatusApproved
+	d.authTime = authTime
+	d.acr = acr
+	d.amr = amr
+	return nil
+}
+
+// Poll 디바이스의 토큰 엔드포인트 폴링을 처리한다.
+// 자원 소유자가 승인한 경우에만 nil 을 반환하며 그 외에는 [RFC 8628] 에 정의된 에러를 반환한다.
+//
+//   - 만료된 경우: [oautherr.ErrExpiredToken]
+//   - 폴링 간격보다 빠르게 폴링한 경우: [oautherr.ErrSlowDown] 이후 폴링 간격이 늘어난다.
+//   - 자원 소유자가 거부한 경우: [oautherr.ErrAccessDenied]
+//   - 자원 소유자의 승인을 기다리는 경우: [oautherr.ErrAuthorizationPending]
+//
+// [RFC 8628]: https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+func (d *DeviceCode) Poll(now time.Time) error {
+	if !d.Available() {
+		return fmt.Errorf("%w: device code is expired", oautherr.ErrExpiredToken)
+	}
+
+	last := d.lastPolledAt
+	d.lastPolledAt = now
+	if !last.IsZero() && now.Sub(last) < d.interval {
+		d.interval += devicePollingSlowDown
+		return fmt.Errorf("%w: polling interval is %d seconds", oautherr.ErrSlowDown, d.interval/time.Second)
+	}
+
+	switch d.status {
+	case DeviceStatusApproved:
+		return nil
+	case DeviceStatusDenied:
+		return fmt.Errorf("%w: resource owner denied access", oautherr.ErrAccessDenied)
+	default:
+		return oautherr.ErrAuthorizationPending
+	}
+}
+
+// userCodeCharset 사용자 코드에 사용되는 문자 [RFC 8628] 에서 권장하는 모음이 없는 20개 문자를 사용한다.
+//
+// [RFC 8628]: https://datatracker.ietf.org/doc/html/rfc8628#section-6.1
+const userCodeCharset = "BCDFGHJKLMNPQRSTVWXZ"
+
+// userCodeLength 구분자(-)를 제외한 사용자 코드의 길이
+const userCodeLength = 8
+
+// FormatUserCode 자원 소유자가 입력한 사용자 코드를 저장된 형식(XXXX-XXXX)으로 변환한다.
+// 대소문자를 구분하지 않으며 구분자(-)와 공백 등 코드에 사용되지 않는 문자는 무시한다.
+func FormatUserCode(input string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(input) {
+		if strings.ContainsRune(userCodeCharset, r) {
+			b.WriteRune(r)
+		}
+	}
+	code := b.String()
+	if len(code) != userCodeLength {
+		return code
+	}
+	return code[:userCodeLength/2] + "-" + code[userCodeLength/2:]
+}
+
+// GenerateUserCode 랜덤 인덱스 함수를 이용하여 새 사용자 코드를 XXXX-XXXX 형식으로 생성한다.
+// randIndex 는 [0, n) 범위의 랜덤한 정수를 반환해야 한다.
+func GenerateUserCode(randIndex func(n int) int) string {
+	code := make([]byte, userCodeLength)
+	for i := range code {
+		code[i] = userCodeCharset[randIndex(len(userCodeCharset))]
+	}
+	return FormatUserCode(string(code))
+}
@@ -0,0 +1,97 @@
+package authorization
+
+import (
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/pkg/period"
+	"testing"
+	"time"
+)
+
+func newTestDeviceCode(status DeviceStatus, r period.Range) *DeviceCode {
+	c := client.New("test_client", "", "test", client.TypePublic)
+	return NewDeviceCodeWithState("device", "BCDF-GHJK", c, []string{"read", "write"}, "", status, DevicePollingInterval, time.Time{}, time.Time{}, "", nil, r)
+}
+
+func TestDeviceCode_Poll(t *testing.T) {
+	now := time.Now()
+	available := period.New(time.Minute)
+
+	t.Run("만료된 디바이스 코드는 ErrExpiredToken", func(t *testing.T) {
+		d := newTestDeviceCode(DeviceStatusPending, period.NewWithStartEnd(now.Add(-time.Hour), now.Add(-time.Minute)))
+
+		assert.ErrorIs(t, d.Poll(now), oautherr.ErrExpiredToken)
+	})
+
+	t.Run("승인 대기중인 디바이스 코드는 ErrAuthorizationPending", func(t *testing.T) {
+		d := newTestDeviceCode(DeviceStatusPending, available)
+
+		assert.ErrorIs(t, d.Poll(now), oautherr.ErrAuthorizationPending)
+		assert.Equal(t, now, d.LastPolledAt())
+	})
+
+	t.Run("폴링 간격보다 빠르게 폴링한 경우 ErrSlowDown 및 폴링 간격 증가", func(t *testing.T) {
+		d := newTestDeviceCode(DeviceStatusApproved, available)
+
+		_ = d.Poll(now)
+		err := d.Poll(now.Add(time.Second))
+
+		assert.ErrorIs(t, err, oautherr.ErrSlowDown)
+		assert.Equal(t, DevicePollingInterval+5*time.Second, d.Interval())
+	})
+
+	t.Run("폴링 간격을 지킨 경우 승인된 디바이스 코드는 에러 없음", func(t *testing.T) {
+		d := newTestDeviceCode(DeviceStatusApproved, available)
+
+		_ = d.Poll(now)
+		assert.NoError(t, d.Poll(now.Add(DevicePollingInterval)))
+	})
+
+	t.Run("거부된 디바이스 코드는 ErrAccessDenied", func(t *testing.T) {
+		d := newTestDeviceCode(DeviceStatusDenied, available)
+
+		assert.ErrorIs(t, d.Poll(now), oautherr.ErrAccessDenied)
+	})
+}
+
+func TestDeviceCode_Approve(t *testing.T) {
+	now := time.Now().Add(-time.Hour)
+
+	t.Run("승인한 스코프와 자원 소유자의 인증 정보로 변경", func(t *testing.T) {
+		d := newTestDeviceCode(DeviceStatusPending, period.New(time.Minute))
+
+		assert.NoError(t, d.Approve("user", []string{"read"}, now, "acr", []string{"pwd"}))
+		assert.Equal(t, DeviceStatusApproved, d.Status())
+		assert.Equal(t, []string{"read"}, d.Scopes())
+		assert.Equal(t, "user", d.Username())
+		assert.Equal(t, now, d.AuthTime())
+		assert.Equal(t, "acr", d.ACR())
+		assert.Equal(t, []string{"pwd"}, d.AMR())
+	})
+
+	t.Run("승인한 스코프가 없는 경우 거부", func(t *testing.T) {
+		d := newTestDeviceCode(DeviceStatusPending, period.New(time.Minute))
+
+		assert.NoError(t, d.Approve("user", nil, now, "", nil))
+		assert.Equal(t, DeviceStatusDenied, d.Status())
+	})
+
+	t.Run("요청하지 않은 스코프 승인시 ErrInvalidScope", func(t *testing.T) {
+		d := newTestDeviceCode(DeviceStatusPending, period.New(time.Minute))
+
+		assert.ErrorIs(t, d.Approve("user", []string{"admin"}, now, "", nil), oautherr.ErrInvalidScope)
+	})
+
+	t.Run("이미 승인된 디바이스 코드는 ErrExpiredResource", func(t *testing.T) {
+		d := newTestDeviceCode(DeviceStatusApproved, period.New(time.Minute))
+
+		assert.ErrorIs(t, d.Approve("user", []string{"read"}, now, "", nil), oautherr.ErrExpiredResource)
+	})
+}
+
+func TestFormatUserCode(t *testing.T) {
+	assert.Equal(t, "BCDF-GHJK", FormatUserCode("bcdf-ghjk"))
+	assert.Equal(t, "BCDF-GHJK", FormatUserCode(" BCDF GHJK "))
+	assert.Equal(t, "BCD", FormatUserCode("bcd"))
+}
@@ -0,0 +1,164 @@
+package authorization
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"strings"
+	"time"
+)
+
+// requestObjectLeeway 요청 객체의 시간 관련 클레임(exp, nbf, iat) 검증시 허용하는 시간 오차
+const requestObjectLeeway = time.Second * 30
+
+// AlgorithmNone 서명되지 않은 요청 객체의 JOSE 헤더 alg 값
+const AlgorithmNone = "none"
+
+// RequestObjectClaims [RFC 9101] 인가 요청 객체의 클레임
+// 인가 요청 파라미터를 그대로 클레임으로 가진다.
+//
+// [RFC 9101]: https://datatracker.ietf.org/doc/html/rfc9101#section-4
+type RequestObjectClaims struct {
+	jwt.Claims
+
+	Client              string          `json:"client_id,omitempty"`
+	State               string          `json:"state,omitempty"`
+	Redirect            string          `json:"redirect_uri,omitempty"`
+	Scopes              string          `json:"scope,omitempty"`
+	ResponseType        ResponseType    `json:"response_type,omitempty"`
+	ResponseMode        ResponseMode    `json:"response_mode,omitempty"`
+	CodeChallenge       Challenge       `json:"code_challenge,omitempty"`
+	CodeChallengeMethod ChallengeMethod `json:"code_challenge_method,omitempty"`
+	Nonce               string          `json:"nonce,omitempty"`
+	Prompt              string          `json:"prompt,omitempty"`
+	MaxAge              *int64          `json:"max_age,omitempty"`
+	LoginHint           string          `json:"login_hint,omitempty"`
+	ACRValues           string          `json:"acr_values,omitempty"`
+
+	// Resource 자원 지시자 단일 문자열과 배열 모두 받을 수 있도록 [jwt.Audience] 를 사용한다.
+	Resource jwt.Audience `json:"resource,omitempty"`
+
+	// AuthorizationDetails 요청 객체에서는 문자열이 아닌 JSON 배열 그대로 받는다.
+	AuthorizationDetails json.RawMessage `json:"authorization_details,omitempty"`
+}
+
+// Request 요청 객체의 클레임으로 인가 요청을 생성한다.
+func (c *RequestObjectClaims) Request() *Request {
+	return &Request{
+		Client:              c.Client,
+		State:               c.State,
+		Redirect:            c.Redirect,
+		Scopes:              c.Scopes,
+		ResponseType:        c.ResponseType,
+		ResponseMode:        c.ResponseMode,
+		CodeChallenge:       c.CodeChallenge,
+		CodeChallengeMethod: c.CodeChallengeMethod,
+		Nonce:               c.Nonce,
+		Prompt:              c.Prompt,
+		MaxAge:              c.MaxAge,
+		LoginHint:           c.LoginHint,
+		ACRValues:           c.ACRValues,
+		Resource:            c.Resource,
+
+		AuthorizationDetails: string(c.AuthorizationDetails),
+	}
+}
+
+// RequestObjectVerifier [RFC 9101] 인가 요청 객체를 검증하고 인가 요청으로 변환한다.
+//
+// [RFC 9101]: https://datatracker.ietf.org/doc/html/rfc9101
+type RequestObjectVerifier struct {
+	// Audience 서명된 요청 객체의 aud 클레임에 포함되어야 하는 인가 서버 식별자
+	Audience string
+
+	// FetchJWKS 클라이언트의 jwks_uri 에서 공개키 목록을 조회하는 함수
+	FetchJWKS client.FetchJWKS
+}
+
+// Verify 요청 객체를 검증하고 요청 객체의 클레임으로 만든 인가 요청을 반환한다.
+//
+// [RFC 9101] 에 따라 요청 객체가 사용된 경우 인가 요청 파라미터는 요청 객체의 클레임만 사용하며,
+// 요청 객체 밖의 파라미터는 요청 객체와 같은지 확인해야 하는 client_id 를 제외하고 모두 무시한다.
+// 요청 객체에 client_id 클레임이 없는 경우 인가 요청의 client_id 를 사용한다.
+//
+// 서명된 요청 객체는 클라이언트의 공개키로 서명을 검증하며 iss 클레임은 client_id 와, aud 클레임은 인가 서버 식별자와 같아야 한다.
+// 서명되지 않은(alg=none) 요청 객체는 클라이언트가 서명된 요청 객체만 받도록 설정된 경우 거부한다.
+// 검증에 실패한 경우 [oautherr.ErrInvalidRequestObject] 를 반환한다.
+//
+// [RFC 9101]: https://datatracker.ietf.org/doc/html/rfc9101#section-6.3
+func (v *RequestObjectVerifier) Verify(ctx context.Context, c *client.Client, raw string) (*Request, error) {
+	alg, err := requestObjectAlgorithm(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims RequestObjectClaims
+	expected := jwt.Expected{Time: time.Now()}
+	if alg == AlgorithmNone {
+		if c.RequireSignedRequestObject() {
+			return nil, fmt.Errorf("%w: client(%s) requires signed request object", oautherr.ErrInvalidRequestObject, c.Id())
+		}
+		if err = decodeUnsignedRequestObject(raw, &claims); err != nil {
+			return nil, err
+		}
+	} else {
+		if err = c.VerifyJWT(ctx, v.FetchJWKS, raw, &claims); err != nil {
+			return nil, fmt.Errorf("%w: %v", oautherr.ErrInvalidRequestObject, err)
+		}
+		expected.Issuer = c.Id()
+		expected.AnyAudience = jwt.Audience{v.Audience}
+	}
+	if err = claims.ValidateWithLeeway(expected, requestObjectLeeway); err != nil {
+		return nil, fmt.Errorf("%w: %v", oautherr.ErrInvalidRequestObject, err)
+	}
+
+	if claims.Client == "" {
+		claims.Client = c.Id()
+	}
+	if claims.Client != c.Id() {
+		return nil, fmt.Errorf("%w: client_id(%s) is not matched with request object", oautherr.ErrInvalidRequestObject, c.Id())
+	}
+	return claims.Request(), nil
+}
+
+// requestObjectAlgorithm 요청 객체의 JOSE 헤더에서 서명 알고리즘(alg)을 반환한다.
+func requestObjectAlgorithm(raw string) (string, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("%w: request object must be compact serialized jwt", oautherr.ErrInvalidRequestObject)
+	}
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", fmt.Errorf("%w: request object header is malformed", oautherr.ErrInvalidRequestObject)
+	}
+	var h struct {
+		Algorithm string `json:"alg"`
+	}
+	if err = json.Unmarshal(header, &h); err != nil || h.Algorithm == "" {
+		return "", fmt.Errorf("%w: request object header is malformed", oautherr.ErrInvalidRequestObject)
+	}
+	return h.Algorithm, nil
+}
+
+// decodeUnsignedRequestObject 서명되지 않은 요청 객체의 클레임을 디코딩 한다.
+// 서명되지 않은 요청 객체는 서명 부분이 비어 있어야 한다.
+func decodeUnsignedRequestObject(raw string, claims *RequestObjectClaims) error {
+	parts := strings.Split(raw, ".")
+	if parts[2] != "" {
+		return fmt.Errorf("%w: unsigned request object must not have signature", oautherr.ErrInvalidRequestObject)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("%w: request object payload is malformed", oautherr.ErrInvalidRequestObject)
+	}
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return fmt.Errorf("%w: request object payload is malformed", oautherr.ErrInvalidRequestObject)
+	}
+	return nil
+}
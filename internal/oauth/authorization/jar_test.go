@@ -0,0 +1,180 @@
+package authorization
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"testing"
+	"time"
+)
+
+const testIssuer = "https://auth.example.com"
+
+func signRequestObject(t *testing.T, private *ecdsa.PrivateKey, kid string, claims RequestObjectClaims) string {
+	opts := (&jose.SignerOptions{}).WithHeader(jose.HeaderKey("kid"), kid).WithType("oauth-authz-req+jwt")
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: private}, opts)
+	assert.NoError(t, err)
+
+	raw, err := jwt.Signed(signer).Claims(claims).Serialize()
+	assert.NoError(t, err)
+	return raw
+}
+
+func unsignedRequestObject(claims RequestObjectClaims) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	payload, _ := json.Marshal(claims)
+	return header + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+}
+
+func TestRequestObjectVerifier_Verify(t *testing.T) {
+	private, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	jwks := &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: private.Public(), KeyID: "client-key", Algorithm: "ES256", Use: "sig"}}}
+
+	newClient := func() *client.Client {
+		c := client.New("test_client", "", "test", client.TypeConfidential)
+		c.SetJWKS(jwks)
+		return c
+	}
+	validClaims := func() RequestObjectClaims {
+		return RequestObjectClaims{
+			Claims: jwt.Claims{
+				Issuer:   "test_client",
+				Audience: jwt.Audience{testIssuer},
+				Expiry:   jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+			Client:       "test_client",
+			ResponseType: ResponseTypeCode,
+			Scopes:       "read write",
+			State:        "state",
+			Redirect:     "https://client.example.com/callback",
+		}
+	}
+	verifier := &RequestObjectVerifier{Audience: testIssuer}
+
+	t.Run("서명된 요청 객체의 클레임으로 인가 요청 생성", func(t *testing.T) {
+		raw := signRequestObject(t, private, "client-key", validClaims())
+
+		request, err := verifier.Verify(context.Background(), newClient(), raw)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test_client", request.Client)
+		assert.Equal(t, ResponseTypeCode, request.ResponseType)
+		assert.Equal(t, "read write", request.Scopes)
+		assert.Equal(t, "https://client.example.com/callback", request.Redirect)
+	})
+
+	t.Run("jwks_uri 로 조회한 공개키로 서명 검증", func(t *testing.T) {
+		c := client.New("test_client", "", "test", client.TypeConfidential)
+		c.SetJWKSURI("https://client.example.com/jwks.json")
+		v := &RequestObjectVerifier{
+			Audience: testIssuer,
+			FetchJWKS: func(ctx context.Context, uri string) (*jose.JSONWebKeySet, error) {
+				assert.Equal(t, "https://client.example.com/jwks.json", uri)
+				return jwks, nil
+			},
+		}
+
+		_, err := v.Verify(context.Background(), c, signRequestObject(t, private, "client-key", validClaims()))
+		assert.NoError(t, err)
+	})
+
+	t.Run("요청 객체에 client_id 가 없는 경우 인가 요청의 client_id 사용", func(t *testing.T) {
+		claims := validClaims()
+		claims.Client = ""
+
+		request, err := verifier.Verify(context.Background(), newClient(), signRequestObject(t, private, "client-key", claims))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test_client", request.Client)
+	})
+
+	tests := []struct {
+		name   string
+		raw    func() string
+		client func() *client.Client
+	}{
+		{
+			name: "다른 키로 서명된 요청 객체",
+			raw: func() string {
+				return signRequestObject(t, other, "client-key", validClaims())
+			},
+		},
+		{
+			name: "iss 가 클라이언트 아이디와 다름",
+			raw: func() string {
+				claims := validClaims()
+				claims.Issuer = "other_client"
+				return signRequestObject(t, private, "client-key", claims)
+			},
+		},
+		{
+			name: "aud 에 인가 서버 식별자가 없음",
+			raw: func() string {
+				claims := validClaims()
+				claims.Audience = jwt.Audience{"https://other.example.com"}
+				return signRequestObject(t, private, "client-key", claims)
+			},
+		},
+		{
+			name: "만료된 요청 객체",
+			raw: func() string {
+				claims := validClaims()
+				claims.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+				return signRequestObject(t, private, "client-key", claims)
+			},
+		},
+		{
+			name: "요청 객체의 client_id 가 인가 요청의 client_id 와 다름",
+			raw: func() string {
+				claims := validClaims()
+				claims.Client = "other_client"
+				return signRequestObject(t, private, "client-key", claims)
+			},
+		},
+		{
+			name: "서명된 요청 객체만 받는 클라이언트에 서명되지 않은 요청 객체",
+			raw: func() string {
+				return unsignedRequestObject(validClaims())
+			},
+			client: func() *client.Client {
+				c := newClient()
+				c.SetRequireSignedRequestObject(true)
+				return c
+			},
+		},
+		{
+			name: "JWT 형식이 아닌 요청 객체",
+			raw: func() string {
+				return "not jwt"
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run("검증 실패시 ErrInvalidRequestObject/"+tc.name, func(t *testing.T) {
+			c := newClient()
+			if tc.client != nil {
+				c = tc.client()
+			}
+
+			_, err := verifier.Verify(context.Background(), c, tc.raw())
+			assert.ErrorIs(t, err, oautherr.ErrInvalidRequestObject)
+		})
+	}
+
+	t.Run("서명되지 않은 요청 객체 허용", func(t *testing.T) {
+		request, err := verifier.Verify(context.Background(), newClient(), unsignedRequestObject(validClaims()))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "state", request.State)
+	})
+}
@@ -0,0 +1,44 @@
+package authorization
+
+import (
+	"net/url"
+	"time"
+)
+
+// responseJWTExpiresIn [JARM] 응답 JWT의 만료 시간 (10분)
+//
+// [JARM]: https://openid.net/specs/oauth-v2-jarm.html#section-2.1
+const responseJWTExpiresIn = time.Minute * 10
+
+// Sign 클레임을 서명하여 JWS Compact 형식의 문자열로 반환하는 함수
+//
+// Parameters:
+//   - typ: JOSE 헤더의 typ 값. 공백일 경우 typ 헤더를 설정하지 않는다.
+//   - claims: 서명할 클레임
+type Sign func(typ string, claims any) (string, error)
+
+// ResponseJWTEncoder [JARM] 인가 응답 파라미터를 서명된 JWT로 인코딩 한다.
+//
+// 응답 파라미터(code, state, access_token, 에러 등)는 그대로 클레임이 되며 iss, aud, exp 클레임이 추가된다.
+//
+// [JARM]: https://openid.net/specs/oauth-v2-jarm.html#section-2.1
+type ResponseJWTEncoder struct {
+	// Issuer 응답 발행자(iss) 식별자
+	Issuer string
+
+	// Sign 클레임 서명 함수
+	Sign Sign
+}
+
+// Encode 응답 파라미터로 클레임을 만들고 서명하여 JWT 문자열을 반환한다.
+// aud 클레임은 인가 요청을 한 클라이언트의 아이디로 설정된다.
+func (e *ResponseJWTEncoder) Encode(clientID string, params url.Values, now time.Time) (string, error) {
+	claims := make(map[string]any, len(params)+3)
+	for k := range params {
+		claims[k] = params.Get(k)
+	}
+	claims["iss"] = e.Issuer
+	claims["aud"] = clientID
+	claims["exp"] = now.Add(responseJWTExpiresIn).Unix()
+	return e.Sign("", claims)
+}
@@ -0,0 +1,34 @@
+package authorization
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestResponseJWTEncoder_Encode(t *testing.T) {
+	var typ string
+	var claims map[string]any
+	encoder := ResponseJWTEncoder{
+		Issuer: "https://issuer.example.com",
+		Sign: func(t string, c any) (string, error) {
+			typ, claims = t, c.(map[string]any)
+			return "signed", nil
+		},
+	}
+	now := time.Now()
+
+	signed, err := encoder.Encode("test_client", url.Values{"code": {"code_value"}, "state": {"state_value"}}, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "signed", signed)
+	assert.Equal(t, "", typ)
+	assert.Equal(t, map[string]any{
+		"iss":   "https://issuer.example.com",
+		"aud":   "test_client",
+		"exp":   now.Add(responseJWTExpiresIn).Unix(),
+		"code":  "code_value",
+		"state": "state_value",
+	}, claims)
+}
@@ -0,0 +1,73 @@
+package authorization
+
+import (
+	"fmt"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/pkg/period"
+	"time"
+)
+
+const (
+	// RequestURIPrefix [RFC 9126] 에 정의된 푸시된 인가 요청 URI의 접두사
+	//
+	// [RFC 9126]: https://datatracker.ietf.org/doc/html/rfc9126#section-2.2
+	RequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+
+	// pushedRequestExpiresSecond 푸시된 인가 요청의 만료 시간 (60초)
+	pushedRequestExpiresSecond = time.Second * 60
+)
+
+// PushedRequest [RFC 9126] 푸시된 인가 요청
+//
+// 클라이언트는 인가 요청 파라미터를 인가 엔드포인트의 쿼리 파라미터 대신 클라이언트 인증을 거친 백채널로 먼저 전송하고,
+// 발급 받은 요청 URI(request_uri)만 인가 엔드포인트에 전달한다.
+// 이를 통해 인가 요청이 브라우저에서 변조되거나 로그에 노출되는 것을 막는다.
+//
+// [RFC 9126]: https://datatracker.ietf.org/doc/html/rfc9126
+type PushedRequest struct {
+	// requestURI 인가 엔드포인트에서 인가 요청 대신 사용하는 요청 URI
+	requestURI string
+
+	// request 검증이 완료된 인가 요청
+	request *Request
+
+	period.Range
+}
+
+// NewPushedRequest 검증이 완료된 인가 요청으로 새 푸시된 인가 요청을 생성한다.
+// 요청 URI는 [RequestURIPrefix] 뒤에 생성된 랜덤 문자열을 붙여 생성한다.
+func NewPushedRequest(request *Request, g GenerateCode) *PushedRequest {
+	return &PushedRequest{
+		requestURI: RequestURIPrefix + g(),
+		request:    request,
+		Range:      period.New(pushedRequestExpiresSecond),
+	}
+}
+
+// NewPushedRequestWithState 저장소에 저장된 값으로 푸시된 인가 요청을 생성한다.
+func NewPushedRequestWithState(requestURI string, request *Request, r period.Range) *PushedRequest {
+	return &PushedRequest{
+		requestURI: requestURI,
+		request:    request,
+		Range:      r,
+	}
+}
+
+func (r *PushedRequest) RequestURI() string {
+	return r.requestURI
+}
+
+func (r *PushedRequest) Request() *Request {
+	return r.request
+}
+
+// Resolve 인가 엔드포인트로 요청한 클라이언트의 푸시된 인가 요청인지 검증하고 인가 요청을 반환한다.
+func (r *PushedRequest) Resolve(clientID string) (*Request, error) {
+	if r.request.Client != clientID {
+		return nil, fmt.Errorf("%w: request_uri was not issued to client(%s)", oautherr.ErrInvalidRequest, clientID)
+	}
+	if !r.Available() {
+		return nil, fmt.Errorf("%w: request_uri is expired", oautherr.ErrInvalidRequest)
+	}
+	return r.request, nil
+}
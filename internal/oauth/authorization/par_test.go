@@ -0,0 +1,45 @@
+package authorization
+
+import (
+	"github.com/stretchr/testify/assert"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/pkg/period"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewPushedRequest(t *testing.T) {
+	r := NewPushedRequest(&Request{Client: "test_client"}, func() string { return "random" })
+
+	assert.True(t, strings.HasPrefix(r.RequestURI(), RequestURIPrefix))
+	assert.Equal(t, RequestURIPrefix+"random", r.RequestURI())
+	assert.True(t, r.Available())
+}
+
+func TestPushedRequest_Resolve(t *testing.T) {
+	now := time.Now()
+	request := &Request{Client: "test_client", ResponseType: ResponseTypeCode}
+
+	t.Run("요청 URI를 발급 받은 클라이언트가 아닌 경우 ErrInvalidRequest", func(t *testing.T) {
+		r := NewPushedRequestWithState("uri", request, period.New(time.Minute))
+
+		_, err := r.Resolve("other_client")
+		assert.ErrorIs(t, err, oautherr.ErrInvalidRequest)
+	})
+
+	t.Run("만료된 요청 URI는 ErrInvalidRequest", func(t *testing.T) {
+		r := NewPushedRequestWithState("uri", request, period.NewWithStartEnd(now.Add(-time.Hour), now.Add(-time.Minute)))
+
+		_, err := r.Resolve("test_client")
+		assert.ErrorIs(t, err, oautherr.ErrInvalidRequest)
+	})
+
+	t.Run("유효한 요청 URI는 저장된 인가 요청 반환", func(t *testing.T) {
+		r := NewPushedRequestWithState("uri", request, period.New(time.Minute))
+
+		resolved, err := r.Resolve("test_client")
+		assert.NoError(t, err)
+		assert.Equal(t, request, resolved)
+	})
+}
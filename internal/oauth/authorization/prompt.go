@@ -0,0 +1,112 @@
+package authorization
+
+import (
+	"fmt"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/pkg/auth"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Prompt [OpenID Connect] 인가 서버가 자원 소유자에게 재인증과 동의를 요구할지 지정하는 값
+//
+// 인가 요청의 prompt 파라미터로 공백으로 구분하여 여러개를 입력 받을 수 있으며, none 은 다른 값과 함께 사용 할 수 없다.
+//
+// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#AuthRequest
+type Prompt string
+
+const (
+	// PromptNone 로그인 페이지나 인가 승인 페이지를 보여주지 않는다. 자원 소유자와의 상호작용이 필요한 경우 에러를 리다이렉트 URI로 전달한다.
+	PromptNone Prompt = "none"
+
+	// PromptLogin 이미 로그인 한 자원 소유자라도 다시 로그인 하도록 한다.
+	PromptLogin Prompt = "login"
+
+	// PromptConsent 자원 소유자가 이미 동의한 스코프라도 인가 승인 페이지를 보여주어 동의를 다시 받는다.
+	PromptConsent Prompt = "consent"
+
+	// PromptSelectAccount 자원 소유자가 인가에 사용할 계정을 선택하도록 한다.
+	// 계정 선택 페이지를 따로 제공하지 않으므로 다시 로그인 하여 계정을 선택하도록 한다.
+	PromptSelectAccount Prompt = "select_account"
+)
+
+// Prompts 지원하는 모든 [Prompt]
+var Prompts = []Prompt{PromptNone, PromptLogin, PromptConsent, PromptSelectAccount}
+
+// Prompts 공백으로 구분된 prompt 파라미터를 [Prompt] 목록으로 반환한다.
+func (r *Request) Prompts() []Prompt {
+	var prompts []Prompt
+	for _, p := range strings.Fields(r.Prompt) {
+		prompts = append(prompts, Prompt(p))
+	}
+	return prompts
+}
+
+// PromptNone prompt 파라미터로 none 을 요청했는지 여부를 반환한다.
+func (r *Request) PromptNone() bool {
+	return slices.Contains(r.Prompts(), PromptNone)
+}
+
+// ValidatePrompt prompt 와 max_age 파라미터를 검증한다.
+// 지원하지 않는 prompt 값이 있거나 none 을 다른 값과 함께 요청한 경우, max_age 가 음수인 경우 [oautherr.ErrInvalidRequest] 를 반환한다.
+func (r *Request) ValidatePrompt() error {
+	prompts := r.Prompts()
+	for _, p := range prompts {
+		if !slices.Contains(Prompts, p) {
+			return fmt.Errorf("%w: unsupported prompt(%s)", oautherr.ErrInvalidRequest, p)
+		}
+	}
+	if slices.Contains(prompts, PromptNone) && len(prompts) > 1 {
+		return fmt.Errorf("%w: prompt none must not be used with other values", oautherr.ErrInvalidRequest)
+	}
+	if r.MaxAge != nil && *r.MaxAge < 0 {
+		return fmt.Errorf("%w: max_age must not be negative", oautherr.ErrInvalidRequest)
+	}
+	return nil
+}
+
+// Authenticate 로그인한 자원 소유자로 인가 요청을 처리 할 수 있는지 확인한다.
+//
+// 아래의 경우 자원 소유자가 다시 로그인 해야 하므로 [oautherr.ErrLoginRequired] 를 반환한다.
+//   - 로그인하지 않은 경우(username 이 공백)
+//   - prompt 에 login 이나 select_account 가 포함된 경우
+//   - max_age 가 입력되었고 로그인 후 max_age 초 이상 지난 경우
+//   - acr_values 가 입력되었고 로그인의 인증 컨텍스트 클래스(acr)가 이를 만족하지 않는 경우 (자세한 사항은 [auth.SatisfyACR] 를 참고)
+//
+// 로그인한 자원 소유자가 login_hint 와 다른 경우 계정을 바꾸어야 하므로 [oautherr.ErrInteractionRequired] 를 반환한다.
+//
+// 이 인가 요청을 위해 다시 로그인을 요구한 뒤(LoginRequestedAt) 로그인 한 경우 login_hint 와 다른 계정으로 로그인 했더라도
+// 자원 소유자가 선택한 것으로 보고 prompt, max_age, login_hint 를 확인하지 않는다.
+// 다만 다시 로그인 한 뒤에도 acr_values 를 만족하지 않는 경우 로그인을 반복해서 요구하지 않고 [oautherr.ErrUnmetAuthenticationRequirements] 를 반환한다.
+func (r *Request) Authenticate(username, acr string, authTime, now time.Time) error {
+	if username == "" {
+		return fmt.Errorf("%w: resource owner is not logged in", oautherr.ErrLoginRequired)
+	}
+	if !r.LoginRequestedAt.IsZero() && !authTime.Before(r.LoginRequestedAt) {
+		if !r.satisfyACR(acr) {
+			return fmt.Errorf("%w: acr(%s) does not satisfy acr_values(%s)", oautherr.ErrUnmetAuthenticationRequirements, acr, r.ACRValues)
+		}
+		return nil
+	}
+	if r.LoginHint != "" && r.LoginHint != username {
+		return fmt.Errorf("%w: logged in resource owner is not matched with login_hint", oautherr.ErrInteractionRequired)
+	}
+
+	prompts := r.Prompts()
+	if slices.Contains(prompts, PromptLogin) || slices.Contains(prompts, PromptSelectAccount) {
+		return fmt.Errorf("%w: prompt(%s) requires re-authentication", oautherr.ErrLoginRequired, r.Prompt)
+	}
+	if r.MaxAge != nil && now.Sub(authTime) > time.Duration(*r.MaxAge)*time.Second {
+		return fmt.Errorf("%w: authentication is older than max_age(%d)", oautherr.ErrLoginRequired, *r.MaxAge)
+	}
+	if !r.satisfyACR(acr) {
+		return fmt.Errorf("%w: acr(%s) does not satisfy acr_values(%s)", oautherr.ErrLoginRequired, acr, r.ACRValues)
+	}
+	return nil
+}
+
+// satisfyACR 로그인의 인증 컨텍스트 클래스(acr)가 acr_values 를 만족하는지 여부. acr_values 가 입력되지 않은 경우 true 를 반환한다.
+func (r *Request) satisfyACR(acr string) bool {
+	return r.ACRValues == "" || auth.SatisfyACR(acr, strings.Fields(r.ACRValues))
+}
@@ -0,0 +1,102 @@
+package authorization
+
+import (
+	"github.com/stretchr/testify/assert"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/pkg/auth"
+	"testing"
+	"time"
+)
+
+func TestRequest_ValidatePrompt(t *testing.T) {
+	negative := int64(-1)
+	zero := int64(0)
+
+	tests := []struct {
+		name    string
+		request Request
+		err     error
+	}{
+		{name: "생략된 prompt", request: Request{}},
+		{name: "여러개의 prompt", request: Request{Prompt: "login consent"}},
+		{name: "max_age 0", request: Request{MaxAge: &zero}},
+		{name: "지원하지 않는 prompt", request: Request{Prompt: "login create"}, err: oautherr.ErrInvalidRequest},
+		{name: "다른 값과 함께 사용된 none", request: Request{Prompt: "none consent"}, err: oautherr.ErrInvalidRequest},
+		{name: "음수 max_age", request: Request{MaxAge: &negative}, err: oautherr.ErrInvalidRequest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.request.ValidatePrompt()
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestRequest_Authenticate(t *testing.T) {
+	now := time.Now()
+	authTime := now.Add(-time.Hour)
+	maxAge := int64(60)
+	zero := int64(0)
+
+	tests := []struct {
+		name     string
+		request  Request
+		username string
+		acr      string
+		authTime time.Time
+		err      error
+	}{
+		{name: "로그인 하지 않음", request: Request{}, username: "", err: oautherr.ErrLoginRequired},
+		{name: "로그인 함", request: Request{}, username: "user", authTime: authTime},
+		{name: "prompt login", request: Request{Prompt: "login"}, username: "user", authTime: authTime, err: oautherr.ErrLoginRequired},
+		{name: "prompt select_account", request: Request{Prompt: "select_account"}, username: "user", authTime: authTime, err: oautherr.ErrLoginRequired},
+		{name: "prompt consent", request: Request{Prompt: "consent"}, username: "user", authTime: authTime},
+		{name: "max_age 이내", request: Request{MaxAge: &maxAge}, username: "user", authTime: now.Add(-time.Second)},
+		{name: "max_age 초과", request: Request{MaxAge: &maxAge}, username: "user", authTime: authTime, err: oautherr.ErrLoginRequired},
+		{name: "max_age 0", request: Request{MaxAge: &zero}, username: "user", authTime: now.Add(-time.Second), err: oautherr.ErrLoginRequired},
+		{name: "login_hint 일치", request: Request{LoginHint: "user"}, username: "user", authTime: authTime},
+		{name: "login_hint 불일치", request: Request{LoginHint: "other"}, username: "user", authTime: authTime, err: oautherr.ErrInteractionRequired},
+		{name: "acr_values 만족", request: Request{ACRValues: auth.ACRMultiFactor}, username: "user", acr: auth.ACRMultiFactor, authTime: authTime},
+		{name: "더 높은 보증 수준의 acr", request: Request{ACRValues: auth.ACRPassword}, username: "user", acr: auth.ACRMultiFactor, authTime: authTime},
+		{name: "acr_values 불만족", request: Request{ACRValues: auth.ACRMultiFactor}, username: "user", acr: auth.ACRPassword, authTime: authTime, err: oautherr.ErrLoginRequired},
+		{name: "지원하지 않는 acr_values", request: Request{ACRValues: "urn:unknown"}, username: "user", acr: auth.ACRPassword, authTime: authTime},
+		{
+			name:     "재로그인 요구 이후 로그인 함",
+			request:  Request{Prompt: "login", MaxAge: &zero, LoginHint: "other", ACRValues: auth.ACRMultiFactor, LoginRequestedAt: now.Add(-time.Minute)},
+			username: "user",
+			acr:      auth.ACRMultiFactor,
+			authTime: now.Add(-time.Second),
+		},
+		{
+			name:     "재로그인 요구 이후 로그인 했지만 acr_values 불만족",
+			request:  Request{ACRValues: auth.ACRMultiFactor, LoginRequestedAt: now.Add(-time.Minute)},
+			username: "user",
+			acr:      auth.ACRPassword,
+			authTime: now.Add(-time.Second),
+			err:      oautherr.ErrUnmetAuthenticationRequirements,
+		},
+		{
+			name:     "재로그인 요구 이후 로그인 하지 않음",
+			request:  Request{Prompt: "login", LoginRequestedAt: now.Add(-time.Minute)},
+			username: "user",
+			authTime: authTime,
+			err:      oautherr.ErrLoginRequired,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.request.Authenticate(tc.username, tc.acr, tc.authTime, now)
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package authorization
 
+import "time"
+
 // Request [RFC 6749] 에 정의된 [Authorization Code Grant] 와 [Implicit Grant] 에서 사용할 요청 형태
 //
 // [RFC 6749]: https://datatracker.ietf.org/doc/html/rfc6749
@@ -14,4 +16,86 @@ type Request struct {
 	ResponseType        ResponseType    `form:"response_type"`
 	CodeChallenge       Challenge       `form:"code_challenge"`
 	CodeChallengeMethod ChallengeMethod `form:"code_challenge_method"`
+
+	// Nonce [OpenID Connect] 에서 ID 토큰의 재전송 공격을 막기 위해 사용하는 문자열
+	// 인가 요청시 받은 값을 그대로 ID 토큰의 nonce 클레임에 담아 반환한다.
+	//
+	// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#AuthRequest
+	Nonce string `form:"nonce"`
+
+	// ResponseMode [OAuth 2.0 Multiple Response Types], [OAuth 2.0 Form Post Response Mode], [JARM] 에 정의된 인가 응답 전달 방식
+	// 생략시 응답 방식(response_type)의 기본 전달 방식을 사용한다. 자세한 사항은 [ResponseMode.Resolve] 를 참고
+	//
+	// [OAuth 2.0 Multiple Response Types]: https://openid.net/specs/oauth-v2-multiple-response-types-1_0.html#ResponseModes
+	// [OAuth 2.0 Form Post Response Mode]: https://openid.net/specs/oauth-v2-form-post-response-mode-1_0.html
+	// [JARM]: https://openid.net/specs/oauth-v2-jarm.html#section-2.3
+	ResponseMode ResponseMode `form:"response_mode"`
+
+	// Resource [RFC 8707] 발급 받을 토큰을 사용할 자원 서버의 자원 지시자 목록
+	// 인가 서버에 등록된 자원 서버만 요청 할 수 있으며 인가 코드로 토큰 발급시 이 목록 이내로 토큰의 대상을 제한 할 수 있다.
+	//
+	// [RFC 8707]: https://datatracker.ietf.org/doc/html/rfc8707#section-2.1
+	Resource []string `form:"resource"`
+
+	// AuthorizationDetails [RFC 9396] 스코프로 표현 할 수 없는 세분화된 권한을 나타내는 인가 상세 정보의 JSON 배열 문자열
+	// 인가 서버에 등록된 타입의 스키마로 검증되며 인가 승인 페이지에서 자원 소유자에게 보여진다.
+	//
+	// [RFC 9396]: https://datatracker.ietf.org/doc/html/rfc9396#section-3
+	AuthorizationDetails string `form:"authorization_details"`
+
+	// RequestURI [RFC 9126] 푸시된 인가 요청으로 발급 받은 요청 URI 혹은 [RFC 9101] 요청 객체를 조회할 수 있는 URI
+	// 입력된 경우 나머지 인가 요청 파라미터 대신 푸시된 인가 요청이나 조회한 요청 객체를 사용한다.
+	//
+	// [RFC 9126]: https://datatracker.ietf.org/doc/html/rfc9126#section-4
+	// [RFC 9101]: https://datatracker.ietf.org/doc/html/rfc9101#section-5.2
+	RequestURI string `form:"request_uri"`
+
+	// RequestObject [RFC 9101] 인가 요청 파라미터를 클레임으로 가지는 JWT 형식의 요청 객체
+	// 입력된 경우 나머지 인가 요청 파라미터 대신 요청 객체의 클레임을 사용한다.
+	//
+	// [RFC 9101]: https://datatracker.ietf.org/doc/html/rfc9101#section-5.1
+	RequestObject string `form:"request"`
+
+	// Prompt [OpenID Connect] 자원 소유자에게 재인증과 동의를 요구할지 지정하는 공백으로 구분된 [Prompt] 목록
+	//
+	// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#AuthRequest
+	Prompt string `form:"prompt"`
+
+	// MaxAge [OpenID Connect] 자원 소유자가 로그인 한 후 인가 요청을 처리 할 수 있는 최대 경과 시간(초)
+	// 경과 시간이 지난 경우 자원 소유자는 다시 로그인 해야 한다.
+	//
+	// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#AuthRequest
+	MaxAge *int64 `form:"max_age"`
+
+	// LoginHint [OpenID Connect] 로그인 할 자원 소유자의 식별자(아이디) 힌트
+	// 로그인 페이지의 아이디로 미리 입력되며 로그인한 자원 소유자와 다른 경우 다시 로그인 하도록 한다.
+	//
+	// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#AuthRequest
+	LoginHint string `form:"login_hint"`
+
+	// ACRValues [OpenID Connect] 요청하는 인증 컨텍스트 클래스 참조값(acr)의 공백으로 구분된 목록
+	// 로그인의 인증 컨텍스트 클래스가 이 중 하나 이상의 보증 수준을 만족하지 않는 경우 다시 로그인 하도록 한다.
+	//
+	// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#AuthRequest
+	ACRValues string `form:"acr_values"`
+
+	// AuthTime 자원 소유자가 인증을 완료한 시간
+	// 요청 파라미터로 받지 않으며 인가 요청 처리 중 설정되어 ID 토큰의 auth_time 클레임으로 사용된다.
+	AuthTime time.Time `form:"-"`
+
+	// ACR 자원 소유자 로그인의 인증 컨텍스트 클래스 참조값
+	// 요청 파라미터로 받지 않으며 인가 요청 처리 중 설정되어 ID 토큰과 엑세스 토큰의 acr 클레임으로 사용된다.
+	ACR string `form:"-"`
+
+	// AMR 자원 소유자가 로그인에 사용한 인증 방식 참조값 목록
+	// 요청 파라미터로 받지 않으며 인가 요청 처리 중 설정되어 ID 토큰과 엑세스 토큰의 amr 클레임으로 사용된다.
+	AMR []string `form:"-"`
+
+	// LoginRequestedAt 인가 요청을 처리하기 위해 자원 소유자에게 다시 로그인을 요구한 시간
+	// 요청 파라미터로 받지 않으며 이 시간 이후에 로그인 한 경우 prompt, max_age, login_hint 를 만족한 것으로 본다.
+	LoginRequestedAt time.Time `form:"-"`
+
+	// ConsentedScopes 요청한 스코프 중 자원 소유자가 이미 동의하여 인가 승인 페이지에서 묻지 않은 스코프 (공백으로 구분)
+	// 요청 파라미터로 받지 않으며 자원 소유자가 인가를 승인하면 승인한 스코프와 함께 부여된다.
+	ConsentedScopes string `form:"-"`
 }
@@ -1,5 +1,11 @@
 package authorization
 
+import (
+	"fmt"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"slices"
+)
+
 // Challenge OAuth2 인증 코드 사용(교환) 때 인증에 사용될 코드 [RFC 7636]
 //
 // [RFC 7636]: https://datatracker.ietf.org/doc/html/rfc7636
@@ -21,6 +27,9 @@ const (
 	ChallengeS256 ChallengeMethod = "S256"
 )
 
+// ChallengeMethods 지원하는 모든 [ChallengeMethod]
+var ChallengeMethods = []ChallengeMethod{ChallengePlan, ChallengeS256}
+
 // Verifier 인가코드(authorization_code) 발급에 사용된 [Challenge]
 type Verifier string
 
@@ -31,3 +40,87 @@ const (
 	ResponseTypeCode  ResponseType = "code"
 	ResponseTypeToken ResponseType = "token"
 )
+
+// ResponseTypes 지원하는 모든 [ResponseType]
+var ResponseTypes = []ResponseType{ResponseTypeCode, ResponseTypeToken}
+
+// ResponseMode 인가 응답 파라미터를 클라이언트에 전달하는 방식
+//
+// query, fragment 는 리다이렉트 URI의 쿼리 파라미터나 프래그먼트로, form_post 는 리다이렉트 URI로 자동 제출되는 HTML 폼으로 전달한다.
+// jwt 가 붙은 방식은 [JARM] 에 따라 응답 파라미터를 인가 서버가 서명한 JWT 하나(response)로 만들어 전달한다.
+//
+// [JARM]: https://openid.net/specs/oauth-v2-jarm.html
+type ResponseMode string
+
+const (
+	ResponseModeQuery       ResponseMode = "query"
+	ResponseModeFragment    ResponseMode = "fragment"
+	ResponseModeFormPost    ResponseMode = "form_post"
+	ResponseModeJWT         ResponseMode = "jwt"
+	ResponseModeQueryJWT    ResponseMode = "query.jwt"
+	ResponseModeFragmentJWT ResponseMode = "fragment.jwt"
+	ResponseModeFormPostJWT ResponseMode = "form_post.jwt"
+)
+
+// ResponseModes 지원하는 모든 [ResponseMode]
+var ResponseModes = []ResponseMode{
+	ResponseModeQuery, ResponseModeFragment, ResponseModeFormPost,
+	ResponseModeJWT, ResponseModeQueryJWT, ResponseModeFragmentJWT, ResponseModeFormPostJWT,
+}
+
+// Resolve 응답 방식(response_type)에 따라 실제로 사용할 전달 방식을 반환한다.
+//
+// 전달 방식이 생략되었거나 지원하지 않는 방식인 경우 응답 방식의 기본 전달 방식(code 는 query, token 은 fragment)을,
+// jwt 인 경우 기본 전달 방식에 jwt 를 붙인 방식(code 는 query.jwt, token 은 fragment.jwt)을 반환한다.
+func (m ResponseMode) Resolve(t ResponseType) ResponseMode {
+	switch m {
+	case ResponseModeJWT:
+		if t == ResponseTypeToken {
+			return ResponseModeFragmentJWT
+		}
+		return ResponseModeQueryJWT
+	case ResponseModeQuery, ResponseModeFragment, ResponseModeFormPost,
+		ResponseModeQueryJWT, ResponseModeFragmentJWT, ResponseModeFormPostJWT:
+		return m
+	default:
+		if t == ResponseTypeToken {
+			return ResponseModeFragment
+		}
+		return ResponseModeQuery
+	}
+}
+
+// JWT [JARM] 에 따라 응답 파라미터를 JWT로 만들어 전달하는 방식인지 여부를 반환한다.
+//
+// [JARM]: https://openid.net/specs/oauth-v2-jarm.html
+func (m ResponseMode) JWT() bool {
+	return m == ResponseModeJWT || m == ResponseModeQueryJWT || m == ResponseModeFragmentJWT || m == ResponseModeFormPostJWT
+}
+
+// FormPost 응답 파라미터를 자동 제출되는 HTML 폼으로 전달하는 방식인지 여부를 반환한다.
+func (m ResponseMode) FormPost() bool {
+	return m == ResponseModeFormPost || m == ResponseModeFormPostJWT
+}
+
+// Query 응답 파라미터를 리다이렉트 URI의 쿼리 파라미터로 전달하는 방식인지 여부를 반환한다.
+func (m ResponseMode) Query() bool {
+	return m == ResponseModeQuery || m == ResponseModeQueryJWT
+}
+
+// Validate 응답 방식(response_type)에 사용 할 수 있는 전달 방식인지 확인한다.
+// 생략된 경우 기본 전달 방식을 사용하므로 유효한 것으로 본다.
+//
+// 지원하지 않는 전달 방식이거나 엑세스 토큰이 서버 로그 등에 남지 않도록 token 응답 방식에 쿼리 파라미터 전달 방식을 요청한 경우
+// [oautherr.ErrInvalidRequest] 를 반환한다.
+func (m ResponseMode) Validate(t ResponseType) error {
+	if m == "" {
+		return nil
+	}
+	if !slices.Contains(ResponseModes, m) {
+		return fmt.Errorf("%w: unsupported response_mode(%s)", oautherr.ErrInvalidRequest, m)
+	}
+	if t == ResponseTypeToken && m.Resolve(t).Query() {
+		return fmt.Errorf("%w: response_mode(%s) is not allowed for response_type(%s)", oautherr.ErrInvalidRequest, m, t)
+	}
+	return nil
+}
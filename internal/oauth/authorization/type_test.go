@@ -0,0 +1,56 @@
+package authorization
+
+import (
+	"github.com/stretchr/testify/assert"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"testing"
+)
+
+func TestResponseMode_Resolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     ResponseMode
+		t        ResponseType
+		expected ResponseMode
+	}{
+		{name: "code 응답 방식의 기본 전달 방식", mode: "", t: ResponseTypeCode, expected: ResponseModeQuery},
+		{name: "token 응답 방식의 기본 전달 방식", mode: "", t: ResponseTypeToken, expected: ResponseModeFragment},
+		{name: "지원하지 않는 전달 방식은 기본 전달 방식", mode: "web_message", t: ResponseTypeCode, expected: ResponseModeQuery},
+		{name: "code 응답 방식의 jwt 전달 방식", mode: ResponseModeJWT, t: ResponseTypeCode, expected: ResponseModeQueryJWT},
+		{name: "token 응답 방식의 jwt 전달 방식", mode: ResponseModeJWT, t: ResponseTypeToken, expected: ResponseModeFragmentJWT},
+		{name: "명시된 전달 방식", mode: ResponseModeFormPostJWT, t: ResponseTypeCode, expected: ResponseModeFormPostJWT},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.mode.Resolve(tc.t))
+		})
+	}
+}
+
+func TestResponseMode_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		mode ResponseMode
+		t    ResponseType
+		err  error
+	}{
+		{name: "생략된 전달 방식", mode: "", t: ResponseTypeToken},
+		{name: "code 응답 방식의 form_post 전달 방식", mode: ResponseModeFormPost, t: ResponseTypeCode},
+		{name: "token 응답 방식의 jwt 전달 방식", mode: ResponseModeJWT, t: ResponseTypeToken},
+		{name: "지원하지 않는 전달 방식", mode: "web_message", t: ResponseTypeCode, err: oautherr.ErrInvalidRequest},
+		{name: "token 응답 방식의 query 전달 방식", mode: ResponseModeQuery, t: ResponseTypeToken, err: oautherr.ErrInvalidRequest},
+		{name: "token 응답 방식의 query.jwt 전달 방식", mode: ResponseModeQueryJWT, t: ResponseTypeToken, err: oautherr.ErrInvalidRequest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.mode.Validate(tc.t)
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
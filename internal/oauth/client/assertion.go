@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-jose/go-jose/v4/jwt"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"slices"
+	"time"
+)
+
+// AssertionTypeJWTBearer [RFC 7523] JWT 클라이언트 어설션 타입
+//
+// [RFC 7523]: https://datatracker.ietf.org/doc/html/rfc7523#section-2.2
+const AssertionTypeJWTBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// AssertionMaxLifetime 클라이언트 어설션의 최대 유효기간
+// 사용된 어설션은 만료될 때 까지 저장되므로 만료 시간(exp)이 현재 시간으로 부터 이 기간을 넘는 어설션은 거부한다.
+const AssertionMaxLifetime = time.Hour
+
+// assertionLeeway 클라이언트 어설션의 시간 관련 클레임(exp, nbf, iat) 검증시 허용하는 시간 오차
+const assertionLeeway = time.Second * 30
+
+// Assertion 인증에 사용된 클라이언트 어설션
+// 어설션의 재사용을 막기 위해 어설션이 만료될 때 까지 클라이언트 아이디와 어설션 식별자(jti)를 저장한다.
+// 만료 시간(exp)이 지나도 허용 오차 동안은 어설션이 검증을 통과하므로 만료 시간에 허용 오차를 더한 시간까지 저장한다.
+type Assertion struct {
+	clientID  string
+	jti       string
+	expiresAt time.Time
+}
+
+func (a *Assertion) ClientID() string {
+	return a.clientID
+}
+
+func (a *Assertion) JTI() string {
+	return a.jti
+}
+
+func (a *Assertion) ExpiresAt() time.Time {
+	return a.expiresAt
+}
+
+// RecordAssertion 사용된 클라이언트 어설션을 기록한다. 이미 사용된 어설션인 경우 false 를 반환한다.
+type RecordAssertion func(ctx context.Context, a *Assertion) (bool, error)
+
+// AssertionAuthenticationProvider [RFC 7523] private_key_jwt, client_secret_jwt 클라이언트 인증을 제공하는 구조체
+//
+// [RFC 7523]: https://datatracker.ietf.org/doc/html/rfc7523#section-2.2
+type AssertionAuthenticationProvider struct {
+	retriever Retrieve
+
+	// audience 어설션의 aud 클레임에 포함되어야 하는 값 목록. 인가 서버 식별자나 토큰 엔드포인트 URL 중 하나를 포함해야 한다.
+	audience []string
+
+	// fetch 클라이언트의 jwks_uri 에서 공개키 목록을 조회하는 함수
+	fetch FetchJWKS
+
+	// record 사용된 어설션을 기록해 재사용 여부를 확인하는 함수
+	record RecordAssertion
+}
+
+func NewAssertionAuthenticationProvider(retriever Retrieve, audience []string, fetch FetchJWKS, record RecordAssertion) *AssertionAuthenticationProvider {
+	return &AssertionAuthenticationProvider{retriever: retriever, audience: audience, fetch: fetch, record: record}
+}
+
+// Authenticate 클라이언트 어설션으로 인증을 진행한다.
+//
+// 어설션의 sub 클레임으로 클라이언트를 조회하고 클라이언트의 인증 방식에 따라 공개키(private_key_jwt)나
+// 클라이언트 시크릿(client_secret_jwt)으로 서명을 검증한다.
+// iss 와 sub 클레임은 클라이언트 아이디와 같아야 하며, aud 클레임은 인가 서버를 포함해야 하고, exp 와 jti 클레임은 필수이다.
+// 검증된 어설션은 만료될 때 까지 기록되며 같은 jti 를 가진 어설션은 다시 사용할 수 없다.
+//
+// 인증에 실패한 경우 [oautherr.ErrInvalidClient] 를 반환한다.
+func (a *AssertionAuthenticationProvider) Authenticate(ctx context.Context, assertionType, assertion string) (*Client, error) {
+	if assertionType != AssertionTypeJWTBearer {
+		return nil, fmt.Errorf("%w: unsupported client_assertion_type(%s)", oautherr.ErrInvalidClient, assertionType)
+	}
+	if assertion == "" {
+		return nil, fmt.Errorf("%w: client_assertion is required", oautherr.ErrInvalidClient)
+	}
+
+	id, err := assertionSubject(assertion)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := a.retriever(id)
+	if !ok {
+		return nil, fmt.Errorf("%w: client could not find: %s", oautherr.ErrInvalidClient, id)
+	}
+
+	var claims jwt.Claims
+	switch c.AuthMethod() {
+	case AuthMethodPrivateKeyJWT:
+		err = c.VerifyJWT(ctx, a.fetch, assertion, &claims)
+	case AuthMethodClientSecretJWT:
+		err = c.VerifyHMAC(assertion, &claims)
+	default:
+		return nil, fmt.Errorf("%w: client(%s) is not allowed to authenticate with client_assertion", oautherr.ErrInvalidClient, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expected := jwt.Expected{Issuer: id, Subject: id, AnyAudience: a.audience, Time: now}
+	if err = claims.ValidateWithLeeway(expected, assertionLeeway); err != nil {
+		return nil, fmt.Errorf("%w: client_assertion is invalid: %v", oautherr.ErrInvalidClient, err)
+	}
+	if claims.ID == "" || claims.Expiry == nil {
+		return nil, fmt.Errorf("%w: client_assertion must contain jti and exp", oautherr.ErrInvalidClient)
+	}
+	expiresAt := claims.Expiry.Time()
+	if expiresAt.After(now.Add(AssertionMaxLifetime)) {
+		return nil, fmt.Errorf("%w: client_assertion lifetime exceeds %s", oautherr.ErrInvalidClient, AssertionMaxLifetime)
+	}
+
+	recorded, err := a.record(ctx, &Assertion{clientID: id, jti: claims.ID, expiresAt: expiresAt.Add(assertionLeeway)})
+	if err != nil {
+		return nil, err
+	}
+	if !recorded {
+		return nil, fmt.Errorf("%w: client_assertion(%s) is already used", oautherr.ErrInvalidClient, claims.ID)
+	}
+	return c, nil
+}
+
+// assertionSubject 서명을 검증하기 전 클라이언트를 찾기 위해 어설션의 sub 클레임을 반환한다.
+func assertionSubject(assertion string) (string, error) {
+	token, err := jwt.ParseSigned(assertion, append(slices.Clone(SigningAlgorithms), HMACAlgorithms...))
+	if err != nil {
+		return "", fmt.Errorf("%w: client_assertion is malformed: %v", oautherr.ErrInvalidClient, err)
+	}
+
+	var claims jwt.Claims
+	if err = token.UnsafeClaimsWithoutVerification(&claims); err != nil || claims.Subject == "" {
+		return "", fmt.Errorf("%w: client_assertion must contain sub", oautherr.ErrInvalidClient)
+	}
+	return claims.Subject, nil
+}
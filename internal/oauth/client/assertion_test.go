@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"testing"
+	"time"
+)
+
+// testAssertionAudience 테스트용 인가 서버 토큰 엔드포인트
+const testAssertionAudience = "https://auth.example.com/oauth/auth/token"
+
+// signTestAssertion 주어진 키로 테스트용 클라이언트 어설션을 서명한다.
+func signTestAssertion(t *testing.T, private *ecdsa.PrivateKey, claims jwt.Claims) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: private}, nil)
+	assert.NoError(t, err)
+
+	raw, err := jwt.Signed(signer).Claims(claims).Serialize()
+	assert.NoError(t, err)
+	return raw
+}
+
+// testHMACSecret client_secret_jwt 테스트에 사용할 클라이언트 시크릿. HS256 키 길이(32바이트) 이상이어야 한다.
+const testHMACSecret = "3d8b0d3c-6f3a-4b8e-9f5e-0c1f7b2d9a11"
+
+// signTestHMACAssertion 주어진 시크릿으로 테스트용 클라이언트 어설션을 HMAC 서명한다.
+func signTestHMACAssertion(t *testing.T, secret string, claims jwt.Claims) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte(secret)}, nil)
+	assert.NoError(t, err)
+
+	raw, err := jwt.Signed(signer).Claims(claims).Serialize()
+	assert.NoError(t, err)
+	return raw
+}
+
+func TestAssertionAuthenticationProvider_Authenticate(t *testing.T) {
+	private, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	c := New(testClientID, "", testName, TypeConfidential)
+	c.SetAuthMethod(AuthMethodPrivateKeyJWT)
+	c.SetJWKS(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: private.Public()}}})
+
+	validClaims := func() jwt.Claims {
+		return jwt.Claims{
+			Issuer:   testClientID,
+			Subject:  testClientID,
+			Audience: jwt.Audience{testAssertionAudience},
+			ID:       "test_jti",
+			Expiry:   jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		}
+	}
+	newProvider := func(c *Client) *AssertionAuthenticationProvider {
+		used := make(map[string]bool)
+		return NewAssertionAuthenticationProvider(func(id string) (*Client, bool) {
+			return c, true
+		}, []string{testAssertionAudience}, nil, func(ctx context.Context, a *Assertion) (bool, error) {
+			if used[a.ClientID()+a.JTI()] {
+				return false, nil
+			}
+			used[a.ClientID()+a.JTI()] = true
+			return true, nil
+		})
+	}
+
+	t.Run("등록된 공개키로 서명된 어설션으로 인증 성공", func(t *testing.T) {
+		authenticated, err := newProvider(c).Authenticate(context.Background(), AssertionTypeJWTBearer, signTestAssertion(t, private, validClaims()))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c, authenticated)
+	})
+
+	t.Run("같은 jti 를 가진 어설션 재사용시 ErrInvalidClient", func(t *testing.T) {
+		provider := newProvider(c)
+		assertion := signTestAssertion(t, private, validClaims())
+
+		_, err := provider.Authenticate(context.Background(), AssertionTypeJWTBearer, assertion)
+		assert.NoError(t, err)
+		_, err = provider.Authenticate(context.Background(), AssertionTypeJWTBearer, assertion)
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("허용 오차 안에서 만료된 어설션도 재사용시 ErrInvalidClient", func(t *testing.T) {
+		// 저장소와 같이 기록된 만료 시간이 지난 어설션은 삭제 후 기록한다.
+		used := make(map[string]time.Time)
+		provider := NewAssertionAuthenticationProvider(func(id string) (*Client, bool) {
+			return c, true
+		}, []string{testAssertionAudience}, nil, func(ctx context.Context, a *Assertion) (bool, error) {
+			for k, expiresAt := range used {
+				if expiresAt.Before(time.Now()) {
+					delete(used, k)
+				}
+			}
+			if _, ok := used[a.ClientID()+a.JTI()]; ok {
+				return false, nil
+			}
+			used[a.ClientID()+a.JTI()] = a.ExpiresAt()
+			return true, nil
+		})
+		claims := validClaims()
+		claims.Expiry = jwt.NewNumericDate(time.Now().Add(-assertionLeeway / 2))
+		assertion := signTestAssertion(t, private, claims)
+
+		_, err := provider.Authenticate(context.Background(), AssertionTypeJWTBearer, assertion)
+		assert.NoError(t, err)
+		assert.Equal(t, claims.Expiry.Time().Add(assertionLeeway), used[testClientID+claims.ID])
+		_, err = provider.Authenticate(context.Background(), AssertionTypeJWTBearer, assertion)
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("지원하지 않는 어설션 타입은 ErrInvalidClient", func(t *testing.T) {
+		_, err := newProvider(c).Authenticate(context.Background(), "urn:unknown", signTestAssertion(t, private, validClaims()))
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("등록되지 않은 키로 서명된 어설션은 ErrInvalidClient", func(t *testing.T) {
+		_, err := newProvider(c).Authenticate(context.Background(), AssertionTypeJWTBearer, signTestAssertion(t, other, validClaims()))
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("private_key_jwt 를 사용하지 않는 클라이언트는 ErrInvalidClient", func(t *testing.T) {
+		secretClient := New(testClientID, testSecret, testName, TypeConfidential)
+		secretClient.SetJWKS(c.JWKS())
+
+		_, err := newProvider(secretClient).Authenticate(context.Background(), AssertionTypeJWTBearer, signTestAssertion(t, private, validClaims()))
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("private_key_jwt 클라이언트에 HMAC 서명된 어설션은 ErrInvalidClient", func(t *testing.T) {
+		_, err := newProvider(c).Authenticate(context.Background(), AssertionTypeJWTBearer, signTestHMACAssertion(t, testHMACSecret, validClaims()))
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	tests := []struct {
+		name string
+		edit func(c *jwt.Claims)
+	}{
+		{name: "iss 가 클라이언트 아이디와 다름", edit: func(c *jwt.Claims) { c.Issuer = "other_client" }},
+		{name: "aud 에 인가 서버가 없음", edit: func(c *jwt.Claims) { c.Audience = jwt.Audience{"https://other.example.com"} }},
+		{name: "만료된 어설션", edit: func(c *jwt.Claims) { c.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour)) }},
+		{name: "exp 가 없음", edit: func(c *jwt.Claims) { c.Expiry = nil }},
+		{name: "jti 가 없음", edit: func(c *jwt.Claims) { c.ID = "" }},
+		{name: "최대 유효기간을 넘는 exp", edit: func(c *jwt.Claims) { c.Expiry = jwt.NewNumericDate(time.Now().Add(AssertionMaxLifetime * 2)) }},
+	}
+	for _, tc := range tests {
+		t.Run("검증 실패시 ErrInvalidClient/"+tc.name, func(t *testing.T) {
+			claims := validClaims()
+			tc.edit(&claims)
+
+			_, err := newProvider(c).Authenticate(context.Background(), AssertionTypeJWTBearer, signTestAssertion(t, private, claims))
+			assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+		})
+	}
+}
+
+func TestAssertionAuthenticationProvider_AuthenticateClientSecretJWT(t *testing.T) {
+	private, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	c := New(testClientID, testSecret, testName, TypeConfidential)
+	c.SetAuthMethod(AuthMethodClientSecretJWT)
+	c.SetJWTSecret(testHMACSecret)
+
+	claims := func(jti string) jwt.Claims {
+		return jwt.Claims{
+			Issuer:   testClientID,
+			Subject:  testClientID,
+			Audience: jwt.Audience{testAssertionAudience},
+			ID:       jti,
+			Expiry:   jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		}
+	}
+	provider := NewAssertionAuthenticationProvider(func(id string) (*Client, bool) {
+		return c, true
+	}, []string{testAssertionAudience}, nil, func(ctx context.Context, a *Assertion) (bool, error) {
+		return true, nil
+	})
+
+	t.Run("클라이언트 시크릿으로 서명된 어설션으로 인증 성공", func(t *testing.T) {
+		authenticated, err := provider.Authenticate(context.Background(), AssertionTypeJWTBearer, signTestHMACAssertion(t, testHMACSecret, claims("jti_1")))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c, authenticated)
+	})
+
+	tests := []struct {
+		name      string
+		assertion func(t *testing.T) string
+	}{
+		{name: "다른 시크릿으로 서명된 어설션", assertion: func(t *testing.T) string {
+			return signTestHMACAssertion(t, "other-secret-other-secret-other-secret", claims("jti_2"))
+		}},
+		{name: "개인키로 서명된 어설션", assertion: func(t *testing.T) string {
+			return signTestAssertion(t, private, claims("jti_3"))
+		}},
+	}
+	for _, tc := range tests {
+		t.Run("검증 실패시 ErrInvalidClient/"+tc.name, func(t *testing.T) {
+			_, err := provider.Authenticate(context.Background(), AssertionTypeJWTBearer, tc.assertion(t))
+			assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+		})
+	}
+
+	t.Run("시크릿 원문이 없는 클라이언트는 ErrInvalidClient", func(t *testing.T) {
+		withoutSecret := New(testClientID, testSecret, testName, TypeConfidential)
+		withoutSecret.SetAuthMethod(AuthMethodClientSecretJWT)
+		provider := NewAssertionAuthenticationProvider(func(id string) (*Client, bool) {
+			return withoutSecret, true
+		}, []string{testAssertionAudience}, nil, func(ctx context.Context, a *Assertion) (bool, error) {
+			return true, nil
+		})
+
+		_, err := provider.Authenticate(context.Background(), AssertionTypeJWTBearer, signTestHMACAssertion(t, testHMACSecret, claims("jti_4")))
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+}
@@ -44,6 +44,10 @@ func (a *AuthenticationProvider) Authenticate(id, secret string) (*Client, error
 		return c, nil
 	}
 
+	if !c.AuthMethod().RequiresSecret() {
+		return nil, fmt.Errorf("%w: client(%s) must authenticate with %s", oautherr.ErrInvalidClient, id, c.AuthMethod())
+	}
+
 	if secret == "" {
 		return nil, fmt.Errorf("%w: secret", oautherr.ErrMissingParameter)
 	}
@@ -63,4 +63,16 @@ func TestAuthenticationProvider_Authenticate(t *testing.T) {
 		_, err := provider.Authenticate(testClientID, "wrong password")
 		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
 	})
+
+	t.Run("상호 TLS 인증을 사용하는 클라이언트는 패스워드로 인증 할 수 없음", func(t *testing.T) {
+		originClient := New(testClientID, testSecret, testName, TypeConfidential)
+		originClient.SetAuthMethod(AuthMethodTLSClientAuth)
+
+		provider.retriever = func(id string) (*Client, bool) {
+			return originClient, true
+		}
+
+		_, err := provider.Authenticate(testClientID, testSecret)
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
 }
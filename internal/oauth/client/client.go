@@ -1,9 +1,12 @@
 package client
 
 import (
+	"context"
 	"fmt"
+	"github.com/go-jose/go-jose/v4"
 	oautherr "oauth-server-go/internal/oauth/errors"
 	"slices"
+	"strings"
 	"time"
 )
 
@@ -17,6 +20,93 @@ const (
 	TypeConfidential Type = "confidential"
 )
 
+// TokenFormat 클라이언트에 발급할 엑세스 토큰의 형식
+type TokenFormat string
+
+const (
+	// TokenFormatOpaque 아무런 의미가 없는 랜덤한 문자열 형식의 토큰
+	// 토큰의 정보를 확인하기 위해선 토큰 질의 API를 호출해야 한다.
+	TokenFormatOpaque TokenFormat = "opaque"
+
+	// TokenFormatJWT [RFC 9068] 에 정의된 서명된 JWT 형식의 토큰
+	// 리소스 서버는 토큰 질의 API 호출 없이 서명 검증 만으로 토큰의 정보를 확인 할 수 있다.
+	//
+	// [RFC 9068]: https://datatracker.ietf.org/doc/html/rfc9068
+	TokenFormatJWT TokenFormat = "jwt"
+)
+
+// AuthMethod 토큰 엔드포인트에서 사용할 클라이언트 인증 방식 [RFC 7591]
+//
+// [RFC 7591]: https://datatracker.ietf.org/doc/html/rfc7591#section-2
+type AuthMethod string
+
+const (
+	// AuthMethodNone 클라이언트 인증을 하지 않는 공개 클라이언트
+	AuthMethodNone AuthMethod = "none"
+
+	// AuthMethodClientSecretBasic HTTP Basic Authentication을 이용한 인증
+	AuthMethodClientSecretBasic AuthMethod = "client_secret_basic"
+
+	// AuthMethodClientSecretPost 요청 바디의 client_id, client_secret 파라미터를 이용한 인증
+	AuthMethodClientSecretPost AuthMethod = "client_secret_post"
+
+	// AuthMethodTLSClientAuth [RFC 8705] 신뢰하는 인증 기관이 발급한 클라이언트 인증서를 이용한 상호 TLS 인증
+	//
+	// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-2.1
+	AuthMethodTLSClientAuth AuthMethod = "tls_client_auth"
+
+	// AuthMethodSelfSignedTLSClientAuth [RFC 8705] 클라이언트의 공개키 목록에 등록된 자체 서명 인증서를 이용한 상호 TLS 인증
+	//
+	// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-2.2
+	AuthMethodSelfSignedTLSClientAuth AuthMethod = "self_signed_tls_client_auth"
+
+	// AuthMethodPrivateKeyJWT [RFC 7523] 클라이언트의 개인키로 서명한 JWT 어설션을 이용한 인증
+	//
+	// [RFC 7523]: https://datatracker.ietf.org/doc/html/rfc7523#section-2.2
+	AuthMethodPrivateKeyJWT AuthMethod = "private_key_jwt"
+
+	// AuthMethodClientSecretJWT [RFC 7523] 클라이언트 시크릿으로 HMAC 서명한 JWT 어설션을 이용한 인증
+	//
+	// [RFC 7523]: https://datatracker.ietf.org/doc/html/rfc7523#section-2.2
+	AuthMethodClientSecretJWT AuthMethod = "client_secret_jwt"
+)
+
+// BackchannelTokenDeliveryMode [OpenID CIBA] 백채널 인증 요청의 결과를 클라이언트에 전달하는 방식
+//
+// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.5
+type BackchannelTokenDeliveryMode string
+
+const (
+	// BackchannelTokenDeliveryPoll 클라이언트가 토큰 엔드포인트를 폴링하여 결과를 확인하는 방식
+	BackchannelTokenDeliveryPoll BackchannelTokenDeliveryMode = "poll"
+
+	// BackchannelTokenDeliveryPing 자원 소유자가 인증을 마치면 인가 서버가 클라이언트의 알림 엔드포인트를 호출하고
+	// 클라이언트는 알림을 받은 후 토큰 엔드포인트에서 토큰을 발급 받는 방식
+	BackchannelTokenDeliveryPing BackchannelTokenDeliveryMode = "ping"
+)
+
+// RequiresSecret 클라이언트 시크릿을 사용하는 인증 방식인지 여부를 반환한다.
+func (m AuthMethod) RequiresSecret() bool {
+	return m == AuthMethodClientSecretBasic || m == AuthMethodClientSecretPost
+}
+
+// IssuesSecret 클라이언트 시크릿을 발급해야 하는 인증 방식인지 여부를 반환한다.
+// 시크릿을 직접 전달하는 방식 외에 시크릿으로 어설션을 서명하는 [AuthMethodClientSecretJWT] 도 포함한다.
+func (m AuthMethod) IssuesSecret() bool {
+	return m.RequiresSecret() || m == AuthMethodClientSecretJWT
+}
+
+// UsesCertificate 클라이언트 인증서를 사용하는 상호 TLS 인증 방식인지 여부를 반환한다.
+func (m AuthMethod) UsesCertificate() bool {
+	return m == AuthMethodTLSClientAuth || m == AuthMethodSelfSignedTLSClientAuth
+}
+
+// DefaultConsentTTL 클라이언트를 생성할 때 설정되는 자원 소유자 동의의 기본 유효 기간 (30일)
+const DefaultConsentTTL = 30 * 24 * time.Hour
+
+// FetchJWKS 클라이언트의 jwks_uri 에서 공개키 목록을 조회하는 함수
+type FetchJWKS func(ctx context.Context, uri string) (*jose.JSONWebKeySet, error)
+
 // Client OAuth2 클라이언트
 type Client struct {
 	id           string
@@ -26,7 +116,68 @@ type Client struct {
 	owner        string
 	redirects    []string
 	scopes       []string
+	tokenFormat  TokenFormat
 	registeredAt time.Time
+
+	grantTypes        []string
+	authMethod        AuthMethod
+	logoURI           string
+	contacts          []string
+	registrationToken string
+
+	// jwtSecret client_secret_jwt 어설션의 HMAC 서명을 검증할 클라이언트 시크릿 원문
+	// secret 은 해싱되어 비교만 할 수 있으므로 저장소에는 암호화되어 따로 저장된다.
+	jwtSecret string
+
+	// requirePushedAuthorizationRequests 인가 요청시 푸시된 인가 요청 [RFC 9126] 사용을 강제할지 여부
+	//
+	// [RFC 9126]: https://datatracker.ietf.org/doc/html/rfc9126#section-6
+	requirePushedAuthorizationRequests bool
+
+	// jwks, jwksURI 클라이언트의 공개키 목록과 공개키 목록을 조회할 수 있는 URI
+	// 둘 중 하나만 등록 할 수 있으며 클라이언트가 서명한 JWT를 검증할 때 사용된다.
+	jwks    *jose.JSONWebKeySet
+	jwksURI string
+
+	// requireSignedRequestObject 인가 요청 객체 [RFC 9101] 를 서명된 JWT로만 받을지 여부
+	//
+	// [RFC 9101]: https://datatracker.ietf.org/doc/html/rfc9101#section-10.5
+	requireSignedRequestObject bool
+
+	// requestURIs 인가 요청 객체를 조회 할 수 있도록 미리 등록된 요청 URI(request_uri) 목록
+	// 등록되지 않은 요청 URI로는 인가 요청 객체를 조회하지 않는다.
+	requestURIs []string
+
+	// tlsClientAuthSubjectDN, tlsClientAuthSANDNS [RFC 8705] tls_client_auth 인증시 클라이언트 인증서와 비교할 주체 DN 이나 SAN DNS 이름
+	// 둘 중 하나만 등록 할 수 있다.
+	//
+	// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-2.1.2
+	tlsClientAuthSubjectDN string
+	tlsClientAuthSANDNS    string
+
+	// certificateBoundAccessTokens [RFC 8705] 발급되는 엑세스 토큰을 클라이언트 인증서에 바인딩할지 여부
+	//
+	// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-3.4
+	certificateBoundAccessTokens bool
+
+	// backchannelTokenDeliveryMode, backchannelClientNotificationEndpoint [OpenID CIBA] 백채널 인증 결과 전달 방식과
+	// ping 방식에서 인가 서버가 호출할 클라이언트의 알림 엔드포인트
+	//
+	// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.4
+	backchannelTokenDeliveryMode          BackchannelTokenDeliveryMode
+	backchannelClientNotificationEndpoint string
+
+	// introspectionSignedResponseAlg, introspectionEncryptedResponseAlg, introspectionEncryptedResponseEnc
+	// [RFC 9701] JWT 토큰 질의 응답의 서명 알고리즘과 암호화 알고리즘
+	//
+	// [RFC 9701]: https://datatracker.ietf.org/doc/html/rfc9701#section-6
+	introspectionSignedResponseAlg    jose.SignatureAlgorithm
+	introspectionEncryptedResponseAlg jose.KeyAlgorithm
+	introspectionEncryptedResponseEnc jose.ContentEncryption
+
+	// consentTTL 자원 소유자가 클라이언트에게 동의한 스코프를 기억할 기간
+	// 기간 내에 이미 동의한 스코프로 인가 요청을 하면 인가 승인 페이지를 보여주지 않는다. 0 인 경우 동의를 기억하지 않는다.
+	consentTTL time.Duration
 }
 
 func New(id, secret, name string, t Type) *Client {
@@ -36,6 +187,7 @@ func New(id, secret, name string, t Type) *Client {
 		t:            t,
 		secret:       secret,
 		registeredAt: time.Now(),
+		consentTTL:   DefaultConsentTTL,
 	}
 }
 
@@ -75,6 +227,255 @@ func (c *Client) Scopes() []string {
 	return c.scopes
 }
 
+// TokenFormat 클라이언트에 발급할 엑세스 토큰의 형식을 반환한다.
+// 따로 설정된 형식이 없을 경우 [TokenFormatOpaque] 를 반환한다.
+func (c *Client) TokenFormat() TokenFormat {
+	if c.tokenFormat == "" {
+		return TokenFormatOpaque
+	}
+	return c.tokenFormat
+}
+
+func (c *Client) SetTokenFormat(f TokenFormat) {
+	c.tokenFormat = f
+}
+
+// GrantTypes 클라이언트가 사용할 수 있도록 등록된 인가 방식 목록을 반환한다.
+func (c *Client) GrantTypes() []string {
+	return c.grantTypes
+}
+
+func (c *Client) SetGrantTypes(grantTypes []string) {
+	c.grantTypes = grantTypes
+}
+
+// AllowGrantType 클라이언트가 인자로 받은 인가 방식을 사용할 수 있는지 여부를 반환한다.
+// 등록된 인가 방식이 없는 경우 모든 인가 방식을 허용한다.
+func (c *Client) AllowGrantType(grantType string) bool {
+	return len(c.grantTypes) == 0 || slices.Contains(c.grantTypes, grantType)
+}
+
+// AuthMethod 토큰 엔드포인트에서 사용할 클라이언트 인증 방식을 반환한다.
+// 따로 설정된 방식이 없을 경우 클라이언트 타입에 따라 [AuthMethodNone] 이나 [AuthMethodClientSecretBasic] 을 반환한다.
+func (c *Client) AuthMethod() AuthMethod {
+	if c.authMethod != "" {
+		return c.authMethod
+	}
+	if c.t == TypePublic {
+		return AuthMethodNone
+	}
+	return AuthMethodClientSecretBasic
+}
+
+func (c *Client) SetAuthMethod(m AuthMethod) {
+	c.authMethod = m
+}
+
+func (c *Client) LogoURI() string {
+	return c.logoURI
+}
+
+func (c *Client) SetLogoURI(uri string) {
+	c.logoURI = uri
+}
+
+func (c *Client) Contacts() []string {
+	return c.contacts
+}
+
+func (c *Client) SetContacts(contacts []string) {
+	c.contacts = contacts
+}
+
+// RegistrationToken 클라이언트 설정 엔드포인트 [RFC 7592] 접근에 사용되는 해싱된 등록 엑세스 토큰을 반환한다.
+//
+// [RFC 7592]: https://datatracker.ietf.org/doc/html/rfc7592
+func (c *Client) RegistrationToken() string {
+	return c.registrationToken
+}
+
+func (c *Client) SetRegistrationToken(hashed string) {
+	c.registrationToken = hashed
+}
+
+// RequirePushedAuthorizationRequests 인가 요청시 푸시된 인가 요청만 허용하는지 여부를 반환한다.
+func (c *Client) RequirePushedAuthorizationRequests() bool {
+	return c.requirePushedAuthorizationRequests
+}
+
+func (c *Client) SetRequirePushedAuthorizationRequests(require bool) {
+	c.requirePushedAuthorizationRequests = require
+}
+
+// JWKS 클라이언트에 직접 등록된 공개키 목록을 반환한다.
+func (c *Client) JWKS() *jose.JSONWebKeySet {
+	return c.jwks
+}
+
+func (c *Client) SetJWKS(jwks *jose.JSONWebKeySet) {
+	c.jwks = jwks
+}
+
+// KeySet 클라이언트가 서명한 JWT를 검증할 공개키 목록을 반환한다.
+// 직접 등록된 공개키 목록이 있는 경우 이를 반환하고, 없는 경우 인자로 받은 함수로 jwks_uri 에서 공개키 목록을 조회한다.
+// 둘 다 등록되어 있지 않은 경우 에러를 반환한다.
+func (c *Client) KeySet(ctx context.Context, fetch FetchJWKS) (*jose.JSONWebKeySet, error) {
+	if c.jwks != nil {
+		return c.jwks, nil
+	}
+	if c.jwksURI == "" {
+		return nil, fmt.Errorf("%w: client(%s) has no registered keys", oautherr.ErrInvalidClient, c.id)
+	}
+	return fetch(ctx, c.jwksURI)
+}
+
+// JWKSURI 클라이언트의 공개키 목록을 조회 할 수 있는 URI를 반환한다.
+func (c *Client) JWKSURI() string {
+	return c.jwksURI
+}
+
+func (c *Client) SetJWKSURI(uri string) {
+	c.jwksURI = uri
+}
+
+// RequireSignedRequestObject 인가 요청 객체를 서명된 JWT로만 받는지 여부를 반환한다.
+func (c *Client) RequireSignedRequestObject() bool {
+	return c.requireSignedRequestObject
+}
+
+func (c *Client) SetRequireSignedRequestObject(require bool) {
+	c.requireSignedRequestObject = require
+}
+
+// RequestURIs 인가 요청 객체를 조회 할 수 있도록 등록된 요청 URI 목록을 반환한다.
+func (c *Client) RequestURIs() []string {
+	return c.requestURIs
+}
+
+func (c *Client) SetRequestURIs(uris []string) {
+	c.requestURIs = uris
+}
+
+// AllowRequestURI 인자로 받은 요청 URI가 클라이언트에 등록된 요청 URI 인지 여부를 반환한다.
+// [OpenID Connect] 에 따라 요청 객체의 버전을 구분하기 위한 프래그먼트는 비교하지 않는다.
+//
+// [OpenID Connect]: https://openid.net/specs/openid-connect-registration-1_0.html#ClientMetadata
+func (c *Client) AllowRequestURI(uri string) bool {
+	uri, _, _ = strings.Cut(uri, "#")
+	return slices.ContainsFunc(c.requestURIs, func(registered string) bool {
+		registered, _, _ = strings.Cut(registered, "#")
+		return registered == uri
+	})
+}
+
+// TLSClientAuthSubjectDN tls_client_auth 인증시 클라이언트 인증서의 주체와 비교할 DN을 반환한다.
+func (c *Client) TLSClientAuthSubjectDN() string {
+	return c.tlsClientAuthSubjectDN
+}
+
+func (c *Client) SetTLSClientAuthSubjectDN(dn string) {
+	c.tlsClientAuthSubjectDN = dn
+}
+
+// TLSClientAuthSANDNS tls_client_auth 인증시 클라이언트 인증서의 SAN 에 포함되어야 하는 DNS 이름을 반환한다.
+func (c *Client) TLSClientAuthSANDNS() string {
+	return c.tlsClientAuthSANDNS
+}
+
+func (c *Client) SetTLSClientAuthSANDNS(dns string) {
+	c.tlsClientAuthSANDNS = dns
+}
+
+// CertificateBoundAccessTokens 발급되는 엑세스 토큰을 클라이언트 인증서에 바인딩하는지 여부를 반환한다.
+func (c *Client) CertificateBoundAccessTokens() bool {
+	return c.certificateBoundAccessTokens
+}
+
+func (c *Client) SetCertificateBoundAccessTokens(bound bool) {
+	c.certificateBoundAccessTokens = bound
+}
+
+// BackchannelTokenDeliveryMode 백채널 인증 결과 전달 방식을 반환한다. 등록되지 않은 경우 poll 방식을 사용한다.
+func (c *Client) BackchannelTokenDeliveryMode() BackchannelTokenDeliveryMode {
+	if c.backchannelTokenDeliveryMode == "" {
+		return BackchannelTokenDeliveryPoll
+	}
+	return c.backchannelTokenDeliveryMode
+}
+
+func (c *Client) SetBackchannelTokenDeliveryMode(mode BackchannelTokenDeliveryMode) {
+	c.backchannelTokenDeliveryMode = mode
+}
+
+// BackchannelClientNotificationEndpoint ping 방식에서 인가 서버가 호출할 클라이언트의 알림 엔드포인트를 반환한다.
+func (c *Client) BackchannelClientNotificationEndpoint() string {
+	return c.backchannelClientNotificationEndpoint
+}
+
+func (c *Client) SetBackchannelClientNotificationEndpoint(endpoint string) {
+	c.backchannelClientNotificationEndpoint = endpoint
+}
+
+// IntrospectionSignedResponseAlg JWT 토큰 질의 응답의 서명 알고리즘을 반환한다.
+// 등록되지 않은 경우 공백("")을 반환하며 인가 서버의 기본 서명 알고리즘을 사용한다.
+func (c *Client) IntrospectionSignedResponseAlg() jose.SignatureAlgorithm {
+	return c.introspectionSignedResponseAlg
+}
+
+func (c *Client) SetIntrospectionSignedResponseAlg(alg jose.SignatureAlgorithm) {
+	c.introspectionSignedResponseAlg = alg
+}
+
+// IntrospectionEncryptedResponseAlg JWT 토큰 질의 응답의 암호화 키 관리 알고리즘을 반환한다.
+// 등록되지 않은 경우 공백("")을 반환하며 응답을 암호화하지 않는다.
+func (c *Client) IntrospectionEncryptedResponseAlg() jose.KeyAlgorithm {
+	return c.introspectionEncryptedResponseAlg
+}
+
+func (c *Client) SetIntrospectionEncryptedResponseAlg(alg jose.KeyAlgorithm) {
+	c.introspectionEncryptedResponseAlg = alg
+}
+
+// IntrospectionEncryptedResponseEnc JWT 토큰 질의 응답의 컨텐츠 암호화 알고리즘을 반환한다.
+// 암호화 키 관리 알고리즘만 등록된 경우 [RFC 9701] 에 따라 A128CBC-HS256 을 사용한다.
+//
+// [RFC 9701]: https://datatracker.ietf.org/doc/html/rfc9701#section-6
+func (c *Client) IntrospectionEncryptedResponseEnc() jose.ContentEncryption {
+	if c.introspectionEncryptedResponseEnc == "" && c.introspectionEncryptedResponseAlg != "" {
+		return jose.A128CBC_HS256
+	}
+	return c.introspectionEncryptedResponseEnc
+}
+
+func (c *Client) SetIntrospectionEncryptedResponseEnc(enc jose.ContentEncryption) {
+	c.introspectionEncryptedResponseEnc = enc
+}
+
+// ConsentTTL 자원 소유자의 동의를 기억할 기간을 반환한다. 0 인 경우 동의를 기억하지 않고 매번 인가 승인 페이지를 보여준다.
+func (c *Client) ConsentTTL() time.Duration {
+	return c.consentTTL
+}
+
+func (c *Client) SetConsentTTL(ttl time.Duration) {
+	c.consentTTL = ttl
+}
+
+func (c *Client) SetSecret(hashed string) {
+	c.secret = hashed
+}
+
+func (c *Client) JWTSecret() string {
+	return c.jwtSecret
+}
+
+func (c *Client) SetJWTSecret(secret string) {
+	c.jwtSecret = secret
+}
+
+func (c *Client) SetOwner(owner string) {
+	c.owner = owner
+}
+
 func (c *Client) RegisteredAt() time.Time {
 	return c.registeredAt
 }
@@ -67,3 +67,27 @@ func TestClient_RedirectURL(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_AllowRequestURI(t *testing.T) {
+	client := New("test_client", "", "test", TypeConfidential)
+	client.SetRequestURIs([]string{"https://client.example.com/request.jwt"})
+
+	tests := []struct {
+		name     string
+		uri      string
+		expected bool
+	}{
+		{name: "등록된 요청 URI", uri: "https://client.example.com/request.jwt", expected: true},
+		{name: "프래그먼트가 추가된 등록된 요청 URI", uri: "https://client.example.com/request.jwt#v2", expected: true},
+		{name: "등록되지 않은 요청 URI", uri: "https://client.example.com/other.jwt", expected: false},
+		{name: "내부 네트워크 주소", uri: "http://169.254.169.254/latest/meta-data", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if client.AllowRequestURI(tc.uri) != tc.expected {
+				t.Errorf("요청 URI(%s)의 허용 여부는 %v 이어야 합니다.", tc.uri, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	oautherr "oauth-server-go/internal/oauth/errors"
+)
+
+// SigningAlgorithms 클라이언트가 JWT 서명에 사용할 수 있는 알고리즘 목록
+// 인가 서버에서 서명에 사용하는 알고리즘과 동일하게 비대칭 키 알고리즘만 허용한다.
+var SigningAlgorithms = []jose.SignatureAlgorithm{jose.RS256, jose.PS256, jose.ES256}
+
+// HMACAlgorithms client_secret_jwt 어설션 서명에 사용할 수 있는 알고리즘 목록
+// 발급되는 클라이언트 시크릿의 길이로 키 길이 요건을 만족하는 HS256 만 허용한다.
+var HMACAlgorithms = []jose.SignatureAlgorithm{jose.HS256}
+
+// EncryptionAlgorithms 인가 서버가 클라이언트에게 보내는 JWT를 암호화 할 때 사용할 수 있는 키 관리 알고리즘 목록
+// 클라이언트의 공개키로 암호화하므로 비대칭 키 알고리즘만 허용한다.
+var EncryptionAlgorithms = []jose.KeyAlgorithm{jose.RSA_OAEP, jose.RSA_OAEP_256, jose.ECDH_ES, jose.ECDH_ES_A128KW, jose.ECDH_ES_A256KW}
+
+// EncryptionMethods 인가 서버가 클라이언트에게 보내는 JWT를 암호화 할 때 사용할 수 있는 컨텐츠 암호화 알고리즘 목록
+var EncryptionMethods = []jose.ContentEncryption{jose.A128CBC_HS256, jose.A256CBC_HS512, jose.A128GCM, jose.A256GCM}
+
+// VerifyJWT 클라이언트가 서명한 JWT를 클라이언트의 공개키로 검증하고 클레임을 dest 에 담는다.
+// JOSE 헤더에 kid 가 있는 경우 해당 키로만 검증하며, 없는 경우 서명용 공개키를 차례로 검증해 하나라도 성공하면 검증된 것으로 본다.
+//
+// 서명이 유효하지 않거나 검증할 공개키가 없는 경우 [oautherr.ErrInvalidClient] 를 반환한다.
+// 클레임의 값(iss, aud, exp 등)은 검증하지 않으므로 호출자가 직접 검증해야 한다.
+func (c *Client) VerifyJWT(ctx context.Context, fetch FetchJWKS, raw string, dest ...any) error {
+	token, err := jwt.ParseSigned(raw, SigningAlgorithms)
+	if err != nil {
+		return fmt.Errorf("%w: jwt is malformed: %v", oautherr.ErrInvalidClient, err)
+	}
+
+	jwks, err := c.KeySet(ctx, fetch)
+	if err != nil {
+		return err
+	}
+
+	keys := jwks.Keys
+	if kid := token.Headers[0].KeyID; kid != "" {
+		keys = jwks.Key(kid)
+	}
+	for _, k := range keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if err = token.Claims(k.Key, dest...); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: jwt signature could not be verified with client(%s) keys", oautherr.ErrInvalidClient, c.id)
+}
+
+// VerifyHMAC 클라이언트가 클라이언트 시크릿으로 서명한 JWT를 검증하고 클레임을 dest 에 담는다.
+//
+// 서명이 유효하지 않거나 검증할 시크릿이 없는 경우 [oautherr.ErrInvalidClient] 를 반환한다.
+// 클레임의 값(iss, aud, exp 등)은 검증하지 않으므로 호출자가 직접 검증해야 한다.
+func (c *Client) VerifyHMAC(raw string, dest ...any) error {
+	token, err := jwt.ParseSigned(raw, HMACAlgorithms)
+	if err != nil {
+		return fmt.Errorf("%w: jwt is malformed: %v", oautherr.ErrInvalidClient, err)
+	}
+	if c.jwtSecret == "" {
+		return fmt.Errorf("%w: client(%s) has no secret for hmac", oautherr.ErrInvalidClient, c.id)
+	}
+	if err = token.Claims([]byte(c.jwtSecret), dest...); err != nil {
+		return fmt.Errorf("%w: jwt signature could not be verified with client(%s) secret", oautherr.ErrInvalidClient, c.id)
+	}
+	return nil
+}
+
+// EncryptJWT 인가 서버가 서명한 JWT를 클라이언트의 공개키로 암호화하여 [RFC 7519] 의 중첩 JWT(JWE Compact 형식)로 반환한다.
+// 클라이언트의 공개키 중 암호화 용도(use 가 enc 이거나 없는 경우)이고 키 관리 알고리즘에 맞는 첫번째 키를 사용한다.
+//
+// 암호화에 사용할 공개키가 없는 경우 [oautherr.ErrInvalidClient] 를 반환한다.
+//
+// [RFC 7519]: https://datatracker.ietf.org/doc/html/rfc7519#section-5.2
+func (c *Client) EncryptJWT(ctx context.Context, fetch FetchJWKS, alg jose.KeyAlgorithm, enc jose.ContentEncryption, jws string) (string, error) {
+	jwks, err := c.KeySet(ctx, fetch)
+	if err != nil {
+		return "", err
+	}
+
+	var recipient *jose.JSONWebKey
+	for _, k := range jwks.Keys {
+		if (k.Use == "" || k.Use == "enc") && (k.Algorithm == "" || k.Algorithm == string(alg)) && acceptEncryptionKey(alg, k.Key) {
+			recipient = &k
+			break
+		}
+	}
+	if recipient == nil {
+		return "", fmt.Errorf("%w: client(%s) has no encryption key for %s", oautherr.ErrInvalidClient, c.id, alg)
+	}
+
+	opts := (&jose.EncrypterOptions{}).WithContentType("JWT")
+	encrypter, err := jose.NewEncrypter(enc, jose.Recipient{Algorithm: alg, Key: recipient.Key, KeyID: recipient.KeyID}, opts)
+	if err != nil {
+		return "", fmt.Errorf("%w: error occurred during create encrypter: %v", oautherr.ErrUnknown, err)
+	}
+	jwe, err := encrypter.Encrypt([]byte(jws))
+	if err != nil {
+		return "", fmt.Errorf("%w: error occurred during encrypt jwt: %v", oautherr.ErrUnknown, err)
+	}
+	return jwe.CompactSerialize()
+}
+
+// acceptEncryptionKey 공개키의 타입이 키 관리 알고리즘에 사용 할 수 있는 타입인지 확인한다.
+func acceptEncryptionKey(alg jose.KeyAlgorithm, key any) bool {
+	switch alg {
+	case jose.RSA_OAEP, jose.RSA_OAEP_256:
+		_, ok := key.(*rsa.PublicKey)
+		return ok
+	case jose.ECDH_ES, jose.ECDH_ES_A128KW, jose.ECDH_ES_A256KW:
+		_, ok := key.(*ecdsa.PublicKey)
+		return ok
+	default:
+		return false
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"slices"
+)
+
+// CertificateAuthenticationProvider [RFC 8705] 상호 TLS 클라이언트 인증을 제공하는 구조체
+//
+// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-2
+type CertificateAuthenticationProvider struct {
+	retriever Retrieve
+
+	// roots tls_client_auth 인증시 클라이언트 인증서 체인을 검증할 신뢰하는 인증 기관 목록
+	// nil 인 경우 tls_client_auth 인증을 할 수 없다.
+	roots *x509.CertPool
+
+	// fetch self_signed_tls_client_auth 인증시 클라이언트의 jwks_uri 에서 공개키 목록을 조회하는 함수
+	fetch FetchJWKS
+}
+
+func NewCertificateAuthenticationProvider(retriever Retrieve, roots *x509.CertPool, fetch FetchJWKS) *CertificateAuthenticationProvider {
+	return &CertificateAuthenticationProvider{retriever: retriever, roots: roots, fetch: fetch}
+}
+
+// Authenticate 클라이언트 아이디와 TLS 연결에서 받은 클라이언트 인증서 체인으로 인증을 진행한다.
+//
+// 클라이언트의 인증 방식이 상호 TLS 인증이 아닌 경우 인증을 하지 않으며, 인증서는 토큰 바인딩 등 다른 용도로만 사용된다.
+//   - tls_client_auth: 인증서 체인을 신뢰하는 인증 기관으로 검증하고 인증서의 주체 DN 이나 SAN DNS 이름이 등록된 값과 같은지 확인한다.
+//   - self_signed_tls_client_auth: 인증서의 공개키가 클라이언트의 공개키 목록에 등록되어 있는지 확인한다.
+//
+// Returns:
+//   - *Client: 인증된 클라이언트
+//   - bool: 상호 TLS 인증 수행 여부
+//   - error: 인증 실패시 [oautherr.ErrInvalidClient]
+func (a *CertificateAuthenticationProvider) Authenticate(ctx context.Context, id string, chain []*x509.Certificate) (*Client, bool, error) {
+	if id == "" || len(chain) == 0 {
+		return nil, false, nil
+	}
+
+	c, ok := a.retriever(id)
+	if !ok {
+		return nil, false, fmt.Errorf("%w: client could not find: %s", oautherr.ErrInvalidClient, id)
+	}
+
+	var err error
+	switch c.AuthMethod() {
+	case AuthMethodTLSClientAuth:
+		err = a.verifyPKI(c, chain)
+	case AuthMethodSelfSignedTLSClientAuth:
+		err = a.verifySelfSigned(ctx, c, chain[0])
+	default:
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, true, err
+	}
+	return c, true, nil
+}
+
+// verifyPKI 인증서 체인을 신뢰하는 인증 기관으로 검증하고 인증서가 클라이언트에 등록된 주체와 같은지 확인한다.
+func (a *CertificateAuthenticationProvider) verifyPKI(c *Client, chain []*x509.Certificate) error {
+	if a.roots == nil {
+		return fmt.Errorf("%w: no trusted certificate authority is configured", oautherr.ErrInvalidClient)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	opts := x509.VerifyOptions{
+		Roots:         a.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if _, err := chain[0].Verify(opts); err != nil {
+		return fmt.Errorf("%w: client(%s) certificate could not be verified: %v", oautherr.ErrInvalidClient, c.Id(), err)
+	}
+
+	leaf := chain[0]
+	switch {
+	case c.TLSClientAuthSubjectDN() != "" && leaf.Subject.String() == c.TLSClientAuthSubjectDN():
+		return nil
+	case c.TLSClientAuthSANDNS() != "" && slices.Contains(leaf.DNSNames, c.TLSClientAuthSANDNS()):
+		return nil
+	}
+	return fmt.Errorf("%w: client(%s) certificate subject is not matched", oautherr.ErrInvalidClient, c.Id())
+}
+
+// verifySelfSigned 인증서의 공개키가 클라이언트의 공개키 목록에 등록되어 있는지 확인한다.
+func (a *CertificateAuthenticationProvider) verifySelfSigned(ctx context.Context, c *Client, cert *x509.Certificate) error {
+	keySet, err := c.KeySet(ctx, a.fetch)
+	if err != nil {
+		return fmt.Errorf("%w: %v", oautherr.ErrInvalidClient, err)
+	}
+
+	type publicKey interface {
+		Equal(x crypto.PublicKey) bool
+	}
+	for _, k := range keySet.Keys {
+		if pub, ok := k.Key.(publicKey); ok && pub.Equal(cert.PublicKey) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: client(%s) certificate is not registered", oautherr.ErrInvalidClient, c.Id())
+}
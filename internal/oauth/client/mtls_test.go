@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"testing"
+	"time"
+)
+
+// testCertificate 테스트용 인증서와 개인키
+type testCertificate struct {
+	cert    *x509.Certificate
+	private *ecdsa.PrivateKey
+}
+
+// newTestCertificate 테스트용 인증서를 생성한다. parent 가 nil 인 경우 자체 서명된 인증 기관 인증서를 생성한다.
+func newTestCertificate(t *testing.T, parent *testCertificate, subject pkix.Name, dnsNames ...string) *testCertificate {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      subject,
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	signerCert, signerKey := template, private
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		signerCert, signerKey = parent.cert, parent.private
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, private.Public(), signerKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &testCertificate{cert: cert, private: private}
+}
+
+func TestCertificateAuthenticationProvider_Authenticate(t *testing.T) {
+	ca := newTestCertificate(t, nil, pkix.Name{CommonName: "Test CA"})
+	leaf := newTestCertificate(t, ca, pkix.Name{CommonName: "partner", Organization: []string{"Partner"}}, "partner.example.com")
+	selfSigned := newTestCertificate(t, nil, pkix.Name{CommonName: "self"})
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	newProvider := func(c *Client) *CertificateAuthenticationProvider {
+		return NewCertificateAuthenticationProvider(func(id string) (*Client, bool) {
+			return c, true
+		}, roots, nil)
+	}
+	newPKIClient := func() *Client {
+		c := New(testClientID, "", testName, TypeConfidential)
+		c.SetAuthMethod(AuthMethodTLSClientAuth)
+		return c
+	}
+
+	t.Run("tls_client_auth 클라이언트의 주체 DN 일치시 인증 성공", func(t *testing.T) {
+		c := newPKIClient()
+		c.SetTLSClientAuthSubjectDN("CN=partner,O=Partner")
+
+		authenticated, ok, err := newProvider(c).Authenticate(context.Background(), testClientID, []*x509.Certificate{leaf.cert})
+
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, c, authenticated)
+	})
+
+	t.Run("tls_client_auth 클라이언트의 SAN DNS 일치시 인증 성공", func(t *testing.T) {
+		c := newPKIClient()
+		c.SetTLSClientAuthSANDNS("partner.example.com")
+
+		_, ok, err := newProvider(c).Authenticate(context.Background(), testClientID, []*x509.Certificate{leaf.cert})
+
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("tls_client_auth 클라이언트의 주체 불일치시 ErrInvalidClient", func(t *testing.T) {
+		c := newPKIClient()
+		c.SetTLSClientAuthSubjectDN("CN=other")
+
+		_, _, err := newProvider(c).Authenticate(context.Background(), testClientID, []*x509.Certificate{leaf.cert})
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("tls_client_auth 클라이언트가 신뢰하지 않는 인증서 제출시 ErrInvalidClient", func(t *testing.T) {
+		c := newPKIClient()
+		c.SetTLSClientAuthSubjectDN("CN=self")
+
+		_, _, err := newProvider(c).Authenticate(context.Background(), testClientID, []*x509.Certificate{selfSigned.cert})
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("self_signed_tls_client_auth 클라이언트는 등록된 공개키로 인증", func(t *testing.T) {
+		c := New(testClientID, "", testName, TypeConfidential)
+		c.SetAuthMethod(AuthMethodSelfSignedTLSClientAuth)
+		c.SetJWKS(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: selfSigned.private.Public()}}})
+
+		_, ok, err := newProvider(c).Authenticate(context.Background(), testClientID, []*x509.Certificate{selfSigned.cert})
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		_, _, err = newProvider(c).Authenticate(context.Background(), testClientID, []*x509.Certificate{leaf.cert})
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("상호 TLS 인증을 사용하지 않는 클라이언트는 인증하지 않음", func(t *testing.T) {
+		c := New(testClientID, testSecret, testName, TypeConfidential)
+
+		authenticated, ok, err := newProvider(c).Authenticate(context.Background(), testClientID, []*x509.Certificate{leaf.cert})
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, authenticated)
+	})
+}
@@ -0,0 +1,266 @@
+package client
+
+import (
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"net/url"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/scope"
+	"slices"
+)
+
+// [RFC 7591] 에서 사용하는 인가 방식
+// token 패키지의 인가 방식을 참조 할 수 없어 필요한 값만 정의한다.
+//
+// [RFC 7591]: https://datatracker.ietf.org/doc/html/rfc7591#section-2
+const (
+	grantTypeAuthorizationCode = "authorization_code"
+	grantTypeImplicit          = "implicit"
+	grantTypeClientCredentials = "client_credentials"
+	grantTypeCIBA              = "urn:openid:params:grant-type:ciba"
+)
+
+// Metadata [RFC 7591] 에 정의된 클라이언트 메타데이터
+// 클라이언트 등록 및 수정 요청과 응답에 사용된다.
+//
+// [RFC 7591]: https://datatracker.ietf.org/doc/html/rfc7591#section-2
+type Metadata struct {
+	RedirectURIs            []string   `json:"redirect_uris,omitempty"`
+	TokenEndpointAuthMethod AuthMethod `json:"token_endpoint_auth_method,omitempty"`
+	GrantTypes              []string   `json:"grant_types,omitempty"`
+	ClientName              string     `json:"client_name,omitempty"`
+	LogoURI                 string     `json:"logo_uri,omitempty"`
+	Scope                   string     `json:"scope,omitempty"`
+	Contacts                []string   `json:"contacts,omitempty"`
+
+	// RequirePushedAuthorizationRequests [RFC 9126] 인가 요청시 푸시된 인가 요청 사용을 강제할지 여부
+	//
+	// [RFC 9126]: https://datatracker.ietf.org/doc/html/rfc9126#section-6
+	RequirePushedAuthorizationRequests bool `json:"require_pushed_authorization_requests,omitempty"`
+
+	// JWKSURI, JWKS 클라이언트의 공개키 목록을 조회할 수 있는 URI와 공개키 목록으로 둘 중 하나만 입력 할 수 있다.
+	JWKSURI string              `json:"jwks_uri,omitempty"`
+	JWKS    *jose.JSONWebKeySet `json:"jwks,omitempty"`
+
+	// RequireSignedRequestObject [RFC 9101] 인가 요청 객체를 서명된 JWT로만 받을지 여부
+	//
+	// [RFC 9101]: https://datatracker.ietf.org/doc/html/rfc9101#section-10.5
+	RequireSignedRequestObject bool `json:"require_signed_request_object,omitempty"`
+
+	// RequestURIs [RFC 9101] 인가 요청 객체를 조회 할 수 있는 요청 URI 목록으로 https 절대 경로 URI 여야 한다.
+	// 인가 요청의 request_uri 는 이 목록에 등록된 URI만 사용 할 수 있다.
+	//
+	// [RFC 9101]: https://datatracker.ietf.org/doc/html/rfc9101#section-10.4
+	RequestURIs []string `json:"request_uris,omitempty"`
+
+	// TLSClientAuthSubjectDN, TLSClientAuthSANDNS [RFC 8705] tls_client_auth 인증시 클라이언트 인증서와 비교할 주체 DN 이나 SAN DNS 이름으로
+	// tls_client_auth 인증 방식을 사용하는 경우 둘 중 하나만 입력 해야 한다.
+	//
+	// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-2.1.2
+	TLSClientAuthSubjectDN string `json:"tls_client_auth_subject_dn,omitempty"`
+	TLSClientAuthSANDNS    string `json:"tls_client_auth_san_dns,omitempty"`
+
+	// TLSClientCertificateBoundAccessTokens [RFC 8705] 발급되는 엑세스 토큰을 클라이언트 인증서에 바인딩할지 여부
+	//
+	// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-3.4
+	TLSClientCertificateBoundAccessTokens bool `json:"tls_client_certificate_bound_access_tokens,omitempty"`
+
+	// BackchannelTokenDeliveryMode, BackchannelClientNotificationEndpoint [OpenID CIBA] 백채널 인증 결과 전달 방식과 클라이언트의 알림 엔드포인트
+	// 백채널 인증 인가 방식을 사용하는 경우 전달 방식은 필수이며, ping 방식은 https 알림 엔드포인트가 필요하다.
+	//
+	// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.4
+	BackchannelTokenDeliveryMode          BackchannelTokenDeliveryMode `json:"backchannel_token_delivery_mode,omitempty"`
+	BackchannelClientNotificationEndpoint string                       `json:"backchannel_client_notification_endpoint,omitempty"`
+
+	// IntrospectionSignedResponseAlg, IntrospectionEncryptedResponseAlg, IntrospectionEncryptedResponseEnc
+	// [RFC 9701] JWT 토큰 질의 응답의 서명 알고리즘과 암호화 알고리즘으로, 응답을 암호화하는 경우 공개키(jwks_uri 또는 jwks)가 필요하다.
+	//
+	// [RFC 9701]: https://datatracker.ietf.org/doc/html/rfc9701#section-6
+	IntrospectionSignedResponseAlg    jose.SignatureAlgorithm `json:"introspection_signed_response_alg,omitempty"`
+	IntrospectionEncryptedResponseAlg jose.KeyAlgorithm       `json:"introspection_encrypted_response_alg,omitempty"`
+	IntrospectionEncryptedResponseEnc jose.ContentEncryption  `json:"introspection_encrypted_response_enc,omitempty"`
+}
+
+// Validate 메타데이터를 검증하고 생략된 항목에 [RFC 7591] 에 정의된 기본값을 설정한다.
+// supportedGrantTypes 는 서버에서 지원하는 인가 방식 목록으로 등록하려는 인가 방식은 모두 이 목록에 포함 되어야 하며,
+// supportedSigningAlgorithms 는 서버의 서명키로 서명 할 수 있는 알고리즘 목록으로 토큰 질의 응답 서명 알고리즘은 이 목록에 포함 되어야 한다.
+//
+// 리다이렉트 URI가 유효하지 않은 경우 [oautherr.ErrInvalidRedirectURI] 를,
+// 그 외의 메타데이터가 유효하지 않은 경우 [oautherr.ErrInvalidClientMetadata] 를 반환한다.
+//
+// [RFC 7591]: https://datatracker.ietf.org/doc/html/rfc7591#section-2
+func (m *Metadata) Validate(supportedGrantTypes []string, supportedSigningAlgorithms []jose.SignatureAlgorithm) error {
+	if m.TokenEndpointAuthMethod == "" {
+		m.TokenEndpointAuthMethod = AuthMethodClientSecretBasic
+	}
+	switch m.TokenEndpointAuthMethod {
+	case AuthMethodNone, AuthMethodClientSecretBasic, AuthMethodClientSecretPost,
+		AuthMethodTLSClientAuth, AuthMethodSelfSignedTLSClientAuth, AuthMethodPrivateKeyJWT, AuthMethodClientSecretJWT:
+	default:
+		return fmt.Errorf("%w: unsupported token_endpoint_auth_method(%s)", oautherr.ErrInvalidClientMetadata, m.TokenEndpointAuthMethod)
+	}
+
+	if len(m.GrantTypes) == 0 {
+		m.GrantTypes = []string{grantTypeAuthorizationCode}
+	}
+	for _, t := range m.GrantTypes {
+		if !slices.Contains(supportedGrantTypes, t) {
+			return fmt.Errorf("%w: unsupported grant_type(%s)", oautherr.ErrInvalidClientMetadata, t)
+		}
+	}
+
+	// 공개 클라이언트는 클라이언트 자격 증명 방식을 사용 할 수 없다.
+	if m.TokenEndpointAuthMethod == AuthMethodNone && slices.Contains(m.GrantTypes, grantTypeClientCredentials) {
+		return fmt.Errorf("%w: public client cannot use client_credentials", oautherr.ErrInvalidClientMetadata)
+	}
+
+	for _, uri := range m.RedirectURIs {
+		if u, err := url.Parse(uri); err != nil || !u.IsAbs() || u.Fragment != "" {
+			return fmt.Errorf("%w: redirect_uri(%s) must be absolute and must not contain fragment", oautherr.ErrInvalidRedirectURI, uri)
+		}
+	}
+	if len(m.RedirectURIs) == 0 &&
+		(slices.Contains(m.GrantTypes, grantTypeAuthorizationCode) || slices.Contains(m.GrantTypes, grantTypeImplicit)) {
+		return fmt.Errorf("%w: redirect_uris is required", oautherr.ErrInvalidRedirectURI)
+	}
+
+	if m.LogoURI != "" {
+		if u, err := url.Parse(m.LogoURI); err != nil || !u.IsAbs() {
+			return fmt.Errorf("%w: logo_uri(%s) must be absolute", oautherr.ErrInvalidClientMetadata, m.LogoURI)
+		}
+	}
+
+	if m.JWKSURI != "" && m.JWKS != nil {
+		return fmt.Errorf("%w: jwks_uri and jwks must not both be present", oautherr.ErrInvalidClientMetadata)
+	}
+	if m.JWKSURI != "" && !isHTTPS(m.JWKSURI) {
+		return fmt.Errorf("%w: jwks_uri(%s) must be https url", oautherr.ErrInvalidClientMetadata, m.JWKSURI)
+	}
+	for _, uri := range m.RequestURIs {
+		if !isHTTPS(uri) {
+			return fmt.Errorf("%w: request_uri(%s) must be https url", oautherr.ErrInvalidClientMetadata, uri)
+		}
+	}
+	if m.JWKS != nil {
+		for _, k := range m.JWKS.Keys {
+			if !k.IsPublic() {
+				return fmt.Errorf("%w: jwks must contain only public keys", oautherr.ErrInvalidClientMetadata)
+			}
+		}
+	}
+
+	switch m.TokenEndpointAuthMethod {
+	case AuthMethodTLSClientAuth:
+		if (m.TLSClientAuthSubjectDN == "") == (m.TLSClientAuthSANDNS == "") {
+			return fmt.Errorf("%w: exactly one of tls_client_auth_subject_dn and tls_client_auth_san_dns is required", oautherr.ErrInvalidClientMetadata)
+		}
+	case AuthMethodSelfSignedTLSClientAuth, AuthMethodPrivateKeyJWT:
+		if m.JWKSURI == "" && m.JWKS == nil {
+			return fmt.Errorf("%w: jwks_uri or jwks is required for %s", oautherr.ErrInvalidClientMetadata, m.TokenEndpointAuthMethod)
+		}
+	}
+
+	if slices.Contains(m.GrantTypes, grantTypeCIBA) {
+		if m.TokenEndpointAuthMethod == AuthMethodNone {
+			return fmt.Errorf("%w: public client cannot use %s", oautherr.ErrInvalidClientMetadata, grantTypeCIBA)
+		}
+		switch m.BackchannelTokenDeliveryMode {
+		case BackchannelTokenDeliveryPoll:
+		case BackchannelTokenDeliveryPing:
+			if u, err := url.Parse(m.BackchannelClientNotificationEndpoint); err != nil || u.Scheme != "https" || u.Host == "" {
+				return fmt.Errorf("%w: backchannel_client_notification_endpoint must be https url", oautherr.ErrInvalidClientMetadata)
+			}
+		default:
+			return fmt.Errorf("%w: unsupported backchannel_token_delivery_mode(%s)", oautherr.ErrInvalidClientMetadata, m.BackchannelTokenDeliveryMode)
+		}
+	}
+
+	if m.IntrospectionSignedResponseAlg != "" && !slices.Contains(supportedSigningAlgorithms, m.IntrospectionSignedResponseAlg) {
+		return fmt.Errorf("%w: unsupported introspection_signed_response_alg(%s)", oautherr.ErrInvalidClientMetadata, m.IntrospectionSignedResponseAlg)
+	}
+	if m.IntrospectionEncryptedResponseAlg == "" && m.IntrospectionEncryptedResponseEnc != "" {
+		return fmt.Errorf("%w: introspection_encrypted_response_alg is required when introspection_encrypted_response_enc is present", oautherr.ErrInvalidClientMetadata)
+	}
+	if m.IntrospectionEncryptedResponseAlg != "" {
+		if !slices.Contains(EncryptionAlgorithms, m.IntrospectionEncryptedResponseAlg) {
+			return fmt.Errorf("%w: unsupported introspection_encrypted_response_alg(%s)", oautherr.ErrInvalidClientMetadata, m.IntrospectionEncryptedResponseAlg)
+		}
+		if m.IntrospectionEncryptedResponseEnc != "" && !slices.Contains(EncryptionMethods, m.IntrospectionEncryptedResponseEnc) {
+			return fmt.Errorf("%w: unsupported introspection_encrypted_response_enc(%s)", oautherr.ErrInvalidClientMetadata, m.IntrospectionEncryptedResponseEnc)
+		}
+		if m.JWKSURI == "" && m.JWKS == nil {
+			return fmt.Errorf("%w: jwks_uri or jwks is required for introspection response encryption", oautherr.ErrInvalidClientMetadata)
+		}
+	}
+	return nil
+}
+
+// isHTTPS 인자로 받은 URI가 호스트를 가진 https 절대 경로 URI 인지 여부를 반환한다.
+func isHTTPS(uri string) bool {
+	u, err := url.Parse(uri)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
+
+// Type 메타데이터의 클라이언트 인증 방식에 따른 클라이언트 타입을 반환한다.
+// 인증 방식이 [AuthMethodNone] 인 경우 공개 클라이언트로 판단한다.
+func (m *Metadata) Type() Type {
+	if m.TokenEndpointAuthMethod == AuthMethodNone {
+		return TypePublic
+	}
+	return TypeConfidential
+}
+
+// ApplyMetadata 클라이언트에 메타데이터를 적용한다.
+// 클라이언트 타입은 메타데이터의 클라이언트 인증 방식에 따라 결정된다.
+func (c *Client) ApplyMetadata(m *Metadata) {
+	c.name = m.ClientName
+	c.t = m.Type()
+	c.redirects = m.RedirectURIs
+	c.scopes = scope.Split(m.Scope)
+	c.grantTypes = m.GrantTypes
+	c.authMethod = m.TokenEndpointAuthMethod
+	c.logoURI = m.LogoURI
+	c.contacts = m.Contacts
+	c.requirePushedAuthorizationRequests = m.RequirePushedAuthorizationRequests
+	c.jwksURI = m.JWKSURI
+	c.jwks = m.JWKS
+	c.requireSignedRequestObject = m.RequireSignedRequestObject
+	c.requestURIs = m.RequestURIs
+	c.tlsClientAuthSubjectDN = m.TLSClientAuthSubjectDN
+	c.tlsClientAuthSANDNS = m.TLSClientAuthSANDNS
+	c.certificateBoundAccessTokens = m.TLSClientCertificateBoundAccessTokens
+	c.backchannelTokenDeliveryMode = m.BackchannelTokenDeliveryMode
+	c.backchannelClientNotificationEndpoint = m.BackchannelClientNotificationEndpoint
+	c.introspectionSignedResponseAlg = m.IntrospectionSignedResponseAlg
+	c.introspectionEncryptedResponseAlg = m.IntrospectionEncryptedResponseAlg
+	c.introspectionEncryptedResponseEnc = m.IntrospectionEncryptedResponseEnc
+}
+
+// Metadata 클라이언트의 메타데이터를 반환한다.
+func (c *Client) Metadata() *Metadata {
+	return &Metadata{
+		RedirectURIs:            c.redirects,
+		TokenEndpointAuthMethod: c.AuthMethod(),
+		GrantTypes:              c.grantTypes,
+		ClientName:              c.name,
+		LogoURI:                 c.logoURI,
+		Scope:                   scope.Join(c.scopes),
+		Contacts:                c.contacts,
+
+		RequirePushedAuthorizationRequests: c.requirePushedAuthorizationRequests,
+		JWKSURI:                            c.jwksURI,
+		JWKS:                               c.jwks,
+		RequireSignedRequestObject:         c.requireSignedRequestObject,
+		RequestURIs:                        c.requestURIs,
+
+		TLSClientAuthSubjectDN:                c.tlsClientAuthSubjectDN,
+		TLSClientAuthSANDNS:                   c.tlsClientAuthSANDNS,
+		TLSClientCertificateBoundAccessTokens: c.certificateBoundAccessTokens,
+		BackchannelTokenDeliveryMode:          c.backchannelTokenDeliveryMode,
+		BackchannelClientNotificationEndpoint: c.backchannelClientNotificationEndpoint,
+
+		IntrospectionSignedResponseAlg:    c.introspectionSignedResponseAlg,
+		IntrospectionEncryptedResponseAlg: c.introspectionEncryptedResponseAlg,
+		IntrospectionEncryptedResponseEnc: c.introspectionEncryptedResponseEnc,
+	}
+}
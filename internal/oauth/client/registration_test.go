@@ -0,0 +1,196 @@
+package client
+
+import (
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"testing"
+)
+
+// testSupportedGrantTypes 테스트로 사용할 서버에서 지원하는 인가 방식
+var testSupportedGrantTypes = []string{"authorization_code", "implicit", "client_credentials", "refresh_token", grantTypeCIBA}
+
+// testSupportedSigningAlgorithms 테스트로 사용할 서버의 서명키로 서명 할 수 있는 알고리즘
+var testSupportedSigningAlgorithms = []jose.SignatureAlgorithm{jose.RS256, jose.ES256}
+
+func TestMetadata_Validate(t *testing.T) {
+	t.Run("생략된 항목은 기본값으로 설정", func(t *testing.T) {
+		m := &Metadata{RedirectURIs: []string{"https://client.example.com/callback"}}
+
+		err := m.Validate(testSupportedGrantTypes, testSupportedSigningAlgorithms)
+
+		assert.NoError(t, err)
+		assert.Equal(t, AuthMethodClientSecretBasic, m.TokenEndpointAuthMethod)
+		assert.Equal(t, []string{"authorization_code"}, m.GrantTypes)
+		assert.Equal(t, TypeConfidential, m.Type())
+	})
+
+	tests := []struct {
+		name     string
+		metadata Metadata
+		err      error
+	}{
+		{
+			name:     "지원하지 않는 클라이언트 인증 방식",
+			metadata: Metadata{RedirectURIs: []string{"https://client.example.com/callback"}, TokenEndpointAuthMethod: "private_key_jwt"},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "지원하지 않는 인가 방식",
+			metadata: Metadata{GrantTypes: []string{"password"}},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "공개 클라이언트의 클라이언트 자격 증명 방식",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, TokenEndpointAuthMethod: AuthMethodNone},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "인가 코드 방식에서 리다이렉트 URI 누락",
+			metadata: Metadata{GrantTypes: []string{"authorization_code"}},
+			err:      oautherr.ErrInvalidRedirectURI,
+		},
+		{
+			name:     "상대 경로 리다이렉트 URI",
+			metadata: Metadata{RedirectURIs: []string{"/callback"}},
+			err:      oautherr.ErrInvalidRedirectURI,
+		},
+		{
+			name:     "프래그먼트가 포함된 리다이렉트 URI",
+			metadata: Metadata{RedirectURIs: []string{"https://client.example.com/callback#fragment"}},
+			err:      oautherr.ErrInvalidRedirectURI,
+		},
+		{
+			name:     "상대 경로 로고 URI",
+			metadata: Metadata{RedirectURIs: []string{"https://client.example.com/callback"}, LogoURI: "logo.png"},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "jwks_uri 와 jwks 를 함께 입력",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, JWKSURI: "https://client.example.com/jwks.json", JWKS: &jose.JSONWebKeySet{}},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "상대 경로 jwks_uri",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, JWKSURI: "jwks.json"},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "http jwks_uri",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, JWKSURI: "http://client.example.com/jwks.json"},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "http request_uri",
+			metadata: Metadata{RedirectURIs: []string{"https://client.example.com/callback"}, RequestURIs: []string{"http://client.example.com/request.jwt"}},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "tls_client_auth 인증 방식에서 인증서 주체 누락",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, TokenEndpointAuthMethod: AuthMethodTLSClientAuth},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name: "tls_client_auth 인증 방식에서 인증서 주체 DN 과 SAN DNS 를 함께 입력",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, TokenEndpointAuthMethod: AuthMethodTLSClientAuth,
+				TLSClientAuthSubjectDN: "CN=partner", TLSClientAuthSANDNS: "partner.example.com"},
+			err: oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "self_signed_tls_client_auth 인증 방식에서 공개키 누락",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, TokenEndpointAuthMethod: AuthMethodSelfSignedTLSClientAuth},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "private_key_jwt 인증 방식에서 공개키 누락",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, TokenEndpointAuthMethod: AuthMethodPrivateKeyJWT},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "백채널 인증 방식에서 지원하지 않는 전달 방식",
+			metadata: Metadata{GrantTypes: []string{grantTypeCIBA}, BackchannelTokenDeliveryMode: "push"},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "백채널 인증 ping 방식에서 https 가 아닌 알림 엔드포인트",
+			metadata: Metadata{GrantTypes: []string{grantTypeCIBA}, BackchannelTokenDeliveryMode: BackchannelTokenDeliveryPing, BackchannelClientNotificationEndpoint: "http://client.example.com/cb"},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "지원하지 않는 토큰 질의 응답 서명 알고리즘",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, IntrospectionSignedResponseAlg: jose.HS256},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "서버의 서명키로 서명 할 수 없는 토큰 질의 응답 서명 알고리즘",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, IntrospectionSignedResponseAlg: jose.PS256},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "토큰 질의 응답 암호화 알고리즘 없이 컨텐츠 암호화 알고리즘만 입력",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, JWKSURI: "https://client.example.com/jwks.json", IntrospectionEncryptedResponseEnc: jose.A128GCM},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "지원하지 않는 토큰 질의 응답 암호화 알고리즘",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, JWKSURI: "https://client.example.com/jwks.json", IntrospectionEncryptedResponseAlg: jose.DIRECT},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name:     "토큰 질의 응답 암호화에서 공개키 누락",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, IntrospectionEncryptedResponseAlg: jose.RSA_OAEP_256},
+			err:      oautherr.ErrInvalidClientMetadata,
+		},
+		{
+			name: "토큰 질의 응답 서명 및 암호화",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, JWKSURI: "https://client.example.com/jwks.json",
+				IntrospectionSignedResponseAlg: jose.ES256, IntrospectionEncryptedResponseAlg: jose.RSA_OAEP_256, IntrospectionEncryptedResponseEnc: jose.A256GCM},
+		},
+		{
+			name:     "백채널 인증 poll 방식",
+			metadata: Metadata{GrantTypes: []string{grantTypeCIBA}, BackchannelTokenDeliveryMode: BackchannelTokenDeliveryPoll},
+		},
+		{
+			name:     "tls_client_auth 인증 방식",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, TokenEndpointAuthMethod: AuthMethodTLSClientAuth, TLSClientAuthSubjectDN: "CN=partner"},
+		},
+		{
+			name:     "client_secret_jwt 인증 방식",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}, TokenEndpointAuthMethod: AuthMethodClientSecretJWT},
+		},
+		{
+			name:     "리다이렉트 URI가 필요 없는 인가 방식",
+			metadata: Metadata{GrantTypes: []string{"client_credentials"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.metadata.Validate(testSupportedGrantTypes, testSupportedSigningAlgorithms)
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestClient_ApplyMetadata(t *testing.T) {
+	c := New(testClientID, testSecret, testName, TypeConfidential)
+	m := &Metadata{
+		RedirectURIs:            []string{"https://client.example.com/callback"},
+		TokenEndpointAuthMethod: AuthMethodNone,
+		GrantTypes:              []string{"authorization_code"},
+		ClientName:              "registered client",
+		Scope:                   "read write",
+	}
+
+	c.ApplyMetadata(m)
+
+	assert.Equal(t, TypePublic, c.T())
+	assert.Equal(t, []string{"read", "write"}, c.Scopes())
+	assert.True(t, c.AllowGrantType("authorization_code"))
+	assert.False(t, c.AllowGrantType("refresh_token"))
+	assert.Equal(t, m, c.Metadata())
+}
@@ -0,0 +1,140 @@
+package detail
+
+import (
+	"encoding/json"
+	"fmt"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"reflect"
+	"slices"
+)
+
+// Detail [RFC 9396] 인가 상세 정보
+//
+// 공백으로 구분된 스코프로는 표현 할 수 없는 세분화된 권한(예: "계좌 X 로 45 EUR 송금")을 나타내는 JSON 객체로,
+// 필수 필드인 type 으로 객체의 형식을 구분한다. 나머지 필드는 타입별로 인가 서버에 등록된 스키마를 따른다.
+//
+// [RFC 9396]: https://datatracker.ietf.org/doc/html/rfc9396#section-2
+type Detail map[string]any
+
+// Type 인가 상세 정보의 타입을 반환한다. type 필드가 없거나 문자열이 아닌 경우 공백("")을 반환한다.
+func (d Detail) Type() string {
+	t, _ := d["type"].(string)
+	return t
+}
+
+// Details 인가 상세 정보 목록
+//
+// 인가 요청, 푸시된 인가 요청, 토큰 요청의 authorization_details 파라미터로 JSON 배열 형태로 입력 받으며
+// 인가 코드와 엑세스 토큰에 저장되어 토큰 응답과 토큰 질의 응답으로 반환된다.
+type Details []Detail
+
+// Parse JSON 배열 문자열을 인가 상세 정보 목록으로 변환한다. 빈 문자열인 경우 nil 을 반환한다.
+// JSON 객체 배열이 아니거나 type 필드가 없는 객체가 있는 경우 [oautherr.ErrInvalidAuthorizationDetails] 를 반환한다.
+func Parse(v string) (Details, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	var details Details
+	if err := json.Unmarshal([]byte(v), &details); err != nil {
+		return nil, fmt.Errorf("%w: authorization_details must be json array of object", oautherr.ErrInvalidAuthorizationDetails)
+	}
+	for i, d := range details {
+		if d == nil || d.Type() == "" {
+			return nil, fmt.Errorf("%w: authorization_details[%d] type is required", oautherr.ErrInvalidAuthorizationDetails, i)
+		}
+	}
+	if len(details) == 0 {
+		return nil, nil
+	}
+	return details, nil
+}
+
+// String 인가 상세 정보 목록을 JSON 배열 문자열로 변환한다. 목록이 비어 있는 경우 공백("")을 반환한다.
+// 인가 상세 정보는 JSON 으로 입력 받은 값이므로 변환에 실패하지 않는다.
+func (d Details) String() string {
+	if len(d) == 0 {
+		return ""
+	}
+	serial, _ := json.Marshal(d)
+	return string(serial)
+}
+
+// Types 인가 상세 정보 목록에 포함된 타입을 중복 없이 반환한다.
+func (d Details) Types() []string {
+	var types []string
+	for _, detail := range d {
+		if !slices.Contains(types, detail.Type()) {
+			types = append(types, detail.Type())
+		}
+	}
+	return types
+}
+
+// ContainsAll 인자로 받은 인가 상세 정보가 모두 이 목록에 포함되어 있는지 확인한다.
+// 각 인가 상세 정보는 필드와 값이 모두 일치해야 포함된 것으로 본다.
+func (d Details) ContainsAll(other Details) bool {
+	for _, o := range other {
+		if !slices.ContainsFunc(d, func(detail Detail) bool {
+			return reflect.DeepEqual(detail, o)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// Type 인가 서버에 등록된 인가 상세 정보 타입
+//
+// 등록된 타입의 인가 상세 정보만 요청 할 수 있으며, 요청된 인가 상세 정보는 타입의 스키마로 검증된다.
+type Type struct {
+	// name 인가 상세 정보의 type 필드 값
+	name string
+
+	// description 인가 승인 페이지에서 자원 소유자에게 보여줄 타입 설명
+	description string
+
+	// schema 인가 상세 정보 객체를 검증할 스키마. nil 인 경우 type 필드 외에 검증하지 않는다.
+	schema *Schema
+}
+
+func NewType(name, description string, schema *Schema) *Type {
+	return &Type{name: name, description: description, schema: schema}
+}
+
+func (t *Type) Name() string {
+	return t.name
+}
+
+func (t *Type) Description() string {
+	return t.description
+}
+
+func (t *Type) Schema() *Schema {
+	return t.schema
+}
+
+// Retrieve 인가 상세 정보 타입을 조회하는 함수
+//
+// Returns:
+//   - *Type: 조회된 인가 상세 정보 타입
+//   - bool: 조회 성공 여부
+type Retrieve func(name string) (*Type, bool)
+
+// Validate 인가 상세 정보 목록의 타입이 모두 등록되어 있고 타입의 스키마를 만족하는지 확인한다.
+// 그렇지 않은 경우 [oautherr.ErrInvalidAuthorizationDetails] 를 반환한다.
+func Validate(retrieve Retrieve, details Details) error {
+	for i, d := range details {
+		t, ok := retrieve(d.Type())
+		if !ok {
+			return fmt.Errorf("%w: authorization_details[%d] type(%s) is not supported", oautherr.ErrInvalidAuthorizationDetails, i, d.Type())
+		}
+		if t.schema == nil {
+			continue
+		}
+		if err := t.schema.Validate(map[string]any(d)); err != nil {
+			return fmt.Errorf("%w: authorization_details[%d] %v", oautherr.ErrInvalidAuthorizationDetails, i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package detail
+
+import (
+	"github.com/stretchr/testify/assert"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"testing"
+)
+
+const testPaymentDetails = `[{"type":"payment_initiation","instructedAmount":{"currency":"EUR","amount":"45.00"},"creditorAccount":{"iban":"DE02100100109307118603"}}]`
+
+var testPaymentSchema = `{
+	"type": "object",
+	"required": ["type", "instructedAmount", "creditorAccount"],
+	"properties": {
+		"type": {"type": "string"},
+		"instructedAmount": {
+			"type": "object",
+			"required": ["currency", "amount"],
+			"properties": {
+				"currency": {"type": "string", "enum": ["EUR", "KRW"]},
+				"amount": {"type": "string", "pattern": "^[0-9]+(\\.[0-9]{2})?$"}
+			}
+		},
+		"creditorAccount": {"type": "object", "required": ["iban"]}
+	}
+}`
+
+func retrieveTestType(name string) (*Type, bool) {
+	switch name {
+	case "payment_initiation":
+		schema, _ := ParseSchema(testPaymentSchema)
+		return NewType(name, "결제 요청", schema), true
+	case "account_information":
+		return NewType(name, "계좌 정보 조회", nil), true
+	default:
+		return nil, false
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Run("JSON 배열 변환", func(t *testing.T) {
+		details, err := Parse(testPaymentDetails)
+
+		assert.NoError(t, err)
+		assert.Len(t, details, 1)
+		assert.Equal(t, "payment_initiation", details[0].Type())
+		assert.JSONEq(t, testPaymentDetails, details.String())
+	})
+
+	t.Run("빈 문자열과 빈 배열은 nil 반환", func(t *testing.T) {
+		for _, v := range []string{"", "[]"} {
+			details, err := Parse(v)
+
+			assert.NoError(t, err)
+			assert.Nil(t, details)
+			assert.Equal(t, "", details.String())
+		}
+	})
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "JSON 배열이 아님", value: `{"type":"payment_initiation"}`},
+		{name: "객체가 아닌 요소", value: `["payment_initiation"]`},
+		{name: "type 누락", value: `[{"actions":["read"]}]`},
+		{name: "문자열이 아닌 type", value: `[{"type":1}]`},
+	}
+	for _, tc := range tests {
+		t.Run("ErrInvalidAuthorizationDetails 발생/"+tc.name, func(t *testing.T) {
+			_, err := Parse(tc.value)
+			assert.ErrorIs(t, err, oautherr.ErrInvalidAuthorizationDetails)
+		})
+	}
+}
+
+func TestDetails_ContainsAll(t *testing.T) {
+	granted, _ := Parse(`[{"type":"account_information","actions":["read"]},{"type":"payment_initiation","amount":"45.00"}]`)
+
+	t.Run("일부 포함", func(t *testing.T) {
+		requested, _ := Parse(`[{"type":"payment_initiation","amount":"45.00"}]`)
+		assert.True(t, granted.ContainsAll(requested))
+	})
+
+	t.Run("값이 다른 경우 포함되지 않음", func(t *testing.T) {
+		requested, _ := Parse(`[{"type":"payment_initiation","amount":"100.00"}]`)
+		assert.False(t, granted.ContainsAll(requested))
+	})
+
+	t.Run("타입 목록", func(t *testing.T) {
+		assert.Equal(t, []string{"account_information", "payment_initiation"}, granted.Types())
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("등록된 타입과 스키마를 만족", func(t *testing.T) {
+		details, _ := Parse(`[{"type":"account_information","actions":["read"]},` + testPaymentDetails[1:])
+		assert.NoError(t, Validate(retrieveTestType, details))
+	})
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "등록되지 않은 타입", value: `[{"type":"unknown"}]`},
+		{name: "필수 필드 누락", value: `[{"type":"payment_initiation","instructedAmount":{"currency":"EUR","amount":"45.00"}}]`},
+		{name: "허용되지 않은 값", value: `[{"type":"payment_initiation","instructedAmount":{"currency":"USD","amount":"45.00"},"creditorAccount":{"iban":"DE02"}}]`},
+		{name: "패턴 불일치", value: `[{"type":"payment_initiation","instructedAmount":{"currency":"EUR","amount":"45.0.0"},"creditorAccount":{"iban":"DE02"}}]`},
+	}
+	for _, tc := range tests {
+		t.Run("ErrInvalidAuthorizationDetails 발생/"+tc.name, func(t *testing.T) {
+			details, _ := Parse(tc.value)
+			assert.ErrorIs(t, Validate(retrieveTestType, details), oautherr.ErrInvalidAuthorizationDetails)
+		})
+	}
+}
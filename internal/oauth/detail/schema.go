@@ -0,0 +1,169 @@
+package detail
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"unicode/utf8"
+)
+
+// Schema 인가 상세 정보 타입별로 등록되는 [JSON Schema] 의 부분 집합
+//
+// 인가 상세 정보 검증에 필요한 아래 키워드만 지원하며 그 외의 키워드는 무시한다.
+//
+//	type, enum, properties, required, additionalProperties, items, minLength, maxLength, pattern, minimum, maximum
+//
+// [JSON Schema]: https://json-schema.org/draft/2020-12/json-schema-validation
+type Schema struct {
+	// Type 값의 JSON 타입. object, array, string, number, integer, boolean, null 중 하나이며 생략시 타입을 검증하지 않는다.
+	Type string `json:"type,omitempty"`
+
+	// Enum 값이 가질 수 있는 값 목록
+	Enum []any `json:"enum,omitempty"`
+
+	// Properties 객체 필드별 스키마
+	Properties map[string]*Schema `json:"properties,omitempty"`
+
+	// Required 객체의 필수 필드 목록
+	Required []string `json:"required,omitempty"`
+
+	// AdditionalProperties Properties 에 정의되지 않은 필드 허용 여부. 생략시 허용한다.
+	AdditionalProperties *bool `json:"additionalProperties,omitempty"`
+
+	// Items 배열 요소의 스키마
+	Items *Schema `json:"items,omitempty"`
+
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+
+	// pattern 컴파일된 Pattern
+	pattern *regexp.Regexp
+}
+
+// ParseSchema JSON 문자열을 스키마로 변환하고 하위 스키마를 포함한 모든 pattern 을 컴파일한다.
+func ParseSchema(v string) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal([]byte(v), &s); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *Schema) compile() error {
+	if s.Pattern != "" {
+		p, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid schema pattern(%s): %w", s.Pattern, err)
+		}
+		s.pattern = p
+	}
+	for _, p := range s.Properties {
+		if err := p.compile(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile()
+	}
+	return nil
+}
+
+// Validate JSON 으로 변환된 값(map[string]any, []any, string, float64, bool, nil)이 스키마를 만족하는지 확인한다.
+// 만족하지 않는 경우 만족하지 않는 값의 경로를 포함한 에러를 반환한다.
+func (s *Schema) Validate(v any) error {
+	return s.validate("$", v)
+}
+
+func (s *Schema) validate(path string, v any) error {
+	if s.Type != "" && !matchType(s.Type, v) {
+		return fmt.Errorf("%s must be %s", path, s.Type)
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool {
+		return reflect.DeepEqual(e, v)
+	}) {
+		return fmt.Errorf("%s must be one of %v", path, s.Enum)
+	}
+
+	switch value := v.(type) {
+	case map[string]any:
+		return s.validateObject(path, value)
+	case []any:
+		if s.Items == nil {
+			return nil
+		}
+		for i, item := range value {
+			if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(value)
+		if s.MinLength != nil && length < *s.MinLength {
+			return fmt.Errorf("%s must be at least %d characters", path, *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			return fmt.Errorf("%s must be %d characters or less", path, *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(value) {
+			return fmt.Errorf("%s must match pattern(%s)", path, s.Pattern)
+		}
+	case float64:
+		if s.Minimum != nil && value < *s.Minimum {
+			return fmt.Errorf("%s must be greater than or equal to %v", path, *s.Minimum)
+		}
+		if s.Maximum != nil && value > *s.Maximum {
+			return fmt.Errorf("%s must be less than or equal to %v", path, *s.Maximum)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateObject(path string, value map[string]any) error {
+	for _, r := range s.Required {
+		if _, ok := value[r]; !ok {
+			return fmt.Errorf("%s.%s is required", path, r)
+		}
+	}
+	for k, v := range value {
+		p, ok := s.Properties[k]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				return fmt.Errorf("%s.%s is not allowed", path, k)
+			}
+			continue
+		}
+		if err := p.validate(path+"."+k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchType 값이 스키마의 JSON 타입과 일치하는지 확인한다. integer 는 소수부가 없는 number 이다.
+func matchType(t string, v any) bool {
+	switch value := v.(type) {
+	case map[string]any:
+		return t == "object"
+	case []any:
+		return t == "array"
+	case string:
+		return t == "string"
+	case float64:
+		return t == "number" || (t == "integer" && value == math.Trunc(value))
+	case bool:
+		return t == "boolean"
+	case nil:
+		return t == "null"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,69 @@
+package detail
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseSchema(t *testing.T) {
+	t.Run("잘못된 JSON", func(t *testing.T) {
+		_, err := ParseSchema(`{"type":`)
+		assert.Error(t, err)
+	})
+
+	t.Run("하위 스키마의 잘못된 pattern", func(t *testing.T) {
+		_, err := ParseSchema(`{"type":"array","items":{"type":"string","pattern":"("}}`)
+		assert.Error(t, err)
+	})
+}
+
+func TestSchema_Validate(t *testing.T) {
+	schema, err := ParseSchema(`{
+		"type": "object",
+		"required": ["type", "actions"],
+		"additionalProperties": false,
+		"properties": {
+			"type": {"type": "string"},
+			"actions": {"type": "array", "items": {"type": "string", "enum": ["read", "write"]}},
+			"count": {"type": "integer", "minimum": 1, "maximum": 10},
+			"label": {"type": "string", "minLength": 2, "maxLength": 4},
+			"enabled": {"type": "boolean"}
+		}
+	}`)
+	assert.NoError(t, err)
+
+	decode := func(v string) any {
+		var value any
+		_ = json.Unmarshal([]byte(v), &value)
+		return value
+	}
+
+	t.Run("스키마를 만족", func(t *testing.T) {
+		err := schema.Validate(decode(`{"type":"account","actions":["read"],"count":3,"label":"계좌","enabled":true}`))
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name  string
+		value string
+		err   string
+	}{
+		{name: "객체가 아님", value: `["read"]`, err: "$ must be object"},
+		{name: "필수 필드 누락", value: `{"type":"account"}`, err: "$.actions is required"},
+		{name: "정의되지 않은 필드", value: `{"type":"account","actions":[],"extra":1}`, err: "$.extra is not allowed"},
+		{name: "배열 요소의 허용되지 않은 값", value: `{"type":"account","actions":["read","delete"]}`, err: "$.actions[1] must be one of [read write]"},
+		{name: "정수가 아님", value: `{"type":"account","actions":[],"count":1.5}`, err: "$.count must be integer"},
+		{name: "최소값 미만", value: `{"type":"account","actions":[],"count":0}`, err: "$.count must be greater than or equal to 1"},
+		{name: "최대값 초과", value: `{"type":"account","actions":[],"count":11}`, err: "$.count must be less than or equal to 10"},
+		{name: "최소 길이 미만", value: `{"type":"account","actions":[],"label":"a"}`, err: "$.label must be at least 2 characters"},
+		{name: "최대 길이 초과", value: `{"type":"account","actions":[],"label":"abcde"}`, err: "$.label must be 4 characters or less"},
+		{name: "불리언이 아님", value: `{"type":"account","actions":[],"enabled":"true"}`, err: "$.enabled must be boolean"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := schema.Validate(decode(tc.value))
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
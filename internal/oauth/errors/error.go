@@ -42,6 +42,83 @@ var (
 	//	 2. 토큰을 발급 할 수 없는 클라이언트인 경우
 	ErrInvalidClient = errors.New("invalid client")
 
+	// ErrInvalidToken 유효하지 않은 엑세스 토큰
+	//
+	// 보호된 자원에 접근할 때 사용한 엑세스 토큰이 만료 되었거나 찾을 수 없는 경우 사용한다.
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrInsufficientScope 엑세스 토큰의 스코프 부족
+	//
+	// 보호된 자원에 접근할 때 사용한 엑세스 토큰에 필요한 스코프가 부여되지 않은 경우 사용한다.
+	ErrInsufficientScope = errors.New("insufficient scope")
+
+	// ErrInvalidRedirectURI 잘못된 리다이렉트 URI
+	//
+	// 클라이언트 등록시 입력한 리다이렉트 URI가 유효하지 않은 경우 사용한다.
+	ErrInvalidRedirectURI = errors.New("invalid redirect uri")
+
+	// ErrInvalidClientMetadata 잘못된 클라이언트 메타데이터
+	//
+	// 클라이언트 등록시 입력한 메타데이터 중 리다이렉트 URI를 제외한 값이 유효하지 않은 경우 사용한다.
+	ErrInvalidClientMetadata = errors.New("invalid client metadata")
+
+	// ErrAccessDenied 자원 소유자가 접근을 거부함
+	ErrAccessDenied = errors.New("access denied")
+
+	// ErrAuthorizationPending 디바이스 인가 요청이나 백채널 인증 요청에 대한 자원 소유자의 승인을 기다리는 중
+	ErrAuthorizationPending = errors.New("authorization pending")
+
+	// ErrSlowDown 디바이스나 클라이언트가 폴링 간격보다 빠르게 토큰 엔드포인트를 폴링함
+	ErrSlowDown = errors.New("slow down")
+
+	// ErrExpiredToken 디바이스 코드나 백채널 인증 요청 식별자(auth_req_id)가 만료됨
+	ErrExpiredToken = errors.New("expired token")
+
+	// ErrInvalidTarget 요청한 대상 서비스(audience, resource)가 유효하지 않음
+	ErrInvalidTarget = errors.New("invalid target")
+
+	// ErrInvalidRequestObject 인가 요청 객체(request)가 유효하지 않음
+	//
+	// 요청 객체의 서명 검증에 실패하였거나 클레임이 유효하지 않은 경우 사용한다.
+	ErrInvalidRequestObject = errors.New("invalid request object")
+
+	// ErrInvalidRequestURI 인가 요청 객체를 참조하는 요청 URI(request_uri)가 유효하지 않음
+	//
+	// 요청 URI에서 요청 객체를 가져올 수 없는 경우 사용한다.
+	ErrInvalidRequestURI = errors.New("invalid request uri")
+
+	// ErrInvalidDPoPProof DPoP 증명이 유효하지 않음
+	ErrInvalidDPoPProof = errors.New("invalid dpop proof")
+
+	// ErrUseDPoPNonce DPoP 증명에 서버가 발급한 nonce 가 포함되지 않았거나 유효하지 않음
+	//
+	// 클라이언트는 응답의 DPoP-Nonce 헤더의 nonce 로 새 증명을 만들어 다시 요청해야 한다.
+	ErrUseDPoPNonce = errors.New("use dpop nonce")
+
+	// ErrUnknownUserID 백채널 인증 요청의 힌트로 자원 소유자를 식별 할 수 없음
+	ErrUnknownUserID = errors.New("unknown user id")
+
+	// ErrInvalidBindingMessage 백채널 인증 요청의 바인딩 메시지가 유효하지 않음
+	ErrInvalidBindingMessage = errors.New("invalid binding message")
+
+	// ErrInvalidAuthorizationDetails 인가 상세 정보(authorization_details)가 유효하지 않음
+	ErrInvalidAuthorizationDetails = errors.New("invalid authorization details")
+
+	// ErrLoginRequired 자원 소유자의 로그인이 필요하지만 prompt=none 으로 로그인 페이지를 보여줄 수 없음
+	ErrLoginRequired = errors.New("login required")
+
+	// ErrConsentRequired 자원 소유자의 동의가 필요하지만 prompt=none 으로 인가 승인 페이지를 보여줄 수 없음
+	ErrConsentRequired = errors.New("consent required")
+
+	// ErrInteractionRequired 로그인이나 동의 외에 자원 소유자와의 상호작용이 필요하지만 prompt=none 으로 페이지를 보여줄 수 없음
+	ErrInteractionRequired = errors.New("interaction required")
+
+	// ErrUnmetAuthenticationRequirements 자원 소유자가 다시 로그인 했지만 요청한 인증 수준(acr_values)을 만족하지 않음
+	ErrUnmetAuthenticationRequirements = errors.New("unmet authentication requirements")
+
+	// ErrInsufficientUserAuthentication 엑세스 토큰을 발급 받을 때의 자원 소유자 인증이 자원 서버가 요구하는 수준에 미치지 못함
+	ErrInsufficientUserAuthentication = errors.New("insufficient user authentication")
+
 	// ErrUnknown 알 수 없는 에러
 	ErrUnknown = errors.New("unknown error")
 )
@@ -81,6 +158,121 @@ const (
 	ErrCodeUnsupportedGrantType = "unsupported_grant_type"
 )
 
+// [RFC 6750] 에서 정의하는 에러 코드 리스트
+//
+// [RFC 6750]: https://datatracker.ietf.org/doc/html/rfc6750#section-3.1
+const (
+	// ErrCodeInvalidToken 엑세스 토큰이 만료 되었거나 취소 되었거나 잘못됨
+	ErrCodeInvalidToken = "invalid_token"
+
+	// ErrCodeInsufficientScope 엑세스 토큰에 요청을 처리하는데 필요한 스코프가 없음
+	ErrCodeInsufficientScope = "insufficient_scope"
+)
+
+// [RFC 7591] 에서 정의하는 에러 코드 리스트
+//
+// [RFC 7591]: https://datatracker.ietf.org/doc/html/rfc7591#section-3.2.2
+const (
+	// ErrCodeInvalidRedirectURI 리다이렉트 URI 중 하나 이상이 유효하지 않음
+	ErrCodeInvalidRedirectURI = "invalid_redirect_uri"
+
+	// ErrCodeInvalidClientMetadata 클라이언트 메타데이터 중 하나 이상이 유효하지 않음
+	ErrCodeInvalidClientMetadata = "invalid_client_metadata"
+)
+
+// [RFC 8628] 에서 정의하는 에러 코드 리스트
+//
+// [RFC 8628]: https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+const (
+	// ErrCodeAuthorizationPending 자원 소유자가 아직 디바이스 인가 요청을 승인하지 않음
+	ErrCodeAuthorizationPending = "authorization_pending"
+
+	// ErrCodeSlowDown 폴링 간격을 5초 늘려야 함
+	ErrCodeSlowDown = "slow_down"
+
+	// ErrCodeExpiredToken 디바이스 코드가 만료되어 새 디바이스 인가 요청이 필요함
+	ErrCodeExpiredToken = "expired_token"
+)
+
+// [RFC 8693] 에서 정의하는 에러 코드 리스트
+//
+// [RFC 8693]: https://datatracker.ietf.org/doc/html/rfc8693#section-2.2.2
+const (
+	// ErrCodeInvalidTarget 요청한 대상 서비스가 유효하지 않거나 대상 서비스에서 사용할 토큰을 발급 할 수 없음
+	ErrCodeInvalidTarget = "invalid_target"
+)
+
+// [RFC 9101] 에서 정의하는 에러 코드 리스트
+//
+// [RFC 9101]: https://datatracker.ietf.org/doc/html/rfc9101#section-6.3
+const (
+	// ErrCodeInvalidRequestObject 인가 요청 객체가 유효하지 않음
+	ErrCodeInvalidRequestObject = "invalid_request_object"
+
+	// ErrCodeInvalidRequestURI 요청 URI가 에러를 반환하거나 유효하지 않은 데이터를 포함함
+	ErrCodeInvalidRequestURI = "invalid_request_uri"
+)
+
+// [RFC 9449] 에서 정의하는 에러 코드 리스트
+//
+// [RFC 9449]: https://datatracker.ietf.org/doc/html/rfc9449#section-12.2
+const (
+	// ErrCodeInvalidDPoPProof DPoP 증명이 유효하지 않음
+	ErrCodeInvalidDPoPProof = "invalid_dpop_proof"
+
+	// ErrCodeUseDPoPNonce 서버가 발급한 nonce 를 DPoP 증명에 포함해야 함
+	ErrCodeUseDPoPNonce = "use_dpop_nonce"
+)
+
+// [OpenID CIBA] 에서 정의하는 에러 코드 리스트
+//
+// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.13
+const (
+	// ErrCodeUnknownUserID 힌트로 자원 소유자를 식별 할 수 없음
+	ErrCodeUnknownUserID = "unknown_user_id"
+
+	// ErrCodeInvalidBindingMessage 바인딩 메시지가 유효하지 않거나 인증 기기에 표시 할 수 없음
+	ErrCodeInvalidBindingMessage = "invalid_binding_message"
+)
+
+// [RFC 9396] 에서 정의하는 에러 코드 리스트
+//
+// [RFC 9396]: https://datatracker.ietf.org/doc/html/rfc9396#section-5
+const (
+	// ErrCodeInvalidAuthorizationDetails 인가 상세 정보의 타입을 알 수 없거나 형식이 유효하지 않음
+	ErrCodeInvalidAuthorizationDetails = "invalid_authorization_details"
+)
+
+// [OpenID Connect] 에서 정의하는 에러 코드 리스트
+//
+// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#AuthError
+const (
+	// ErrCodeLoginRequired 자원 소유자의 인증이 필요함
+	ErrCodeLoginRequired = "login_required"
+
+	// ErrCodeConsentRequired 자원 소유자의 동의가 필요함
+	ErrCodeConsentRequired = "consent_required"
+
+	// ErrCodeInteractionRequired 자원 소유자와의 상호작용이 필요함
+	ErrCodeInteractionRequired = "interaction_required"
+)
+
+// [OpenID Connect Unmet Authentication Requirements] 에서 정의하는 에러 코드 리스트
+//
+// [OpenID Connect Unmet Authentication Requirements]: https://openid.net/specs/openid-connect-unmet-authentication-requirements-1_0.html
+const (
+	// ErrCodeUnmetAuthenticationRequirements 인가 서버가 요청된 인증 요구 사항을 만족하는 인증을 할 수 없음
+	ErrCodeUnmetAuthenticationRequirements = "unmet_authentication_requirements"
+)
+
+// [RFC 9470] 에서 정의하는 에러 코드 리스트
+//
+// [RFC 9470]: https://datatracker.ietf.org/doc/html/rfc9470#section-3
+const (
+	// ErrCodeInsufficientUserAuthentication 자원 소유자의 인증 수준(acr)이나 인증 시간(auth_time)이 자원 서버의 요구를 만족하지 않음
+	ErrCodeInsufficientUserAuthentication = "insufficient_user_authentication"
+)
+
 // ErrorCode 인자로 받은 에러를 정의된 에러 코드로 변환한다.
 func ErrorCode(err error) string {
 	switch {
@@ -96,6 +288,48 @@ func ErrorCode(err error) string {
 		return ErrCodeInvalidScope
 	case errors.Is(err, ErrUnauthorizedClient):
 		return ErrCodeUnauthorizedClient
+	case errors.Is(err, ErrInvalidToken):
+		return ErrCodeInvalidToken
+	case errors.Is(err, ErrInsufficientScope):
+		return ErrCodeInsufficientScope
+	case errors.Is(err, ErrInvalidRedirectURI):
+		return ErrCodeInvalidRedirectURI
+	case errors.Is(err, ErrInvalidClientMetadata):
+		return ErrCodeInvalidClientMetadata
+	case errors.Is(err, ErrAccessDenied):
+		return ErrCodeAccessDenied
+	case errors.Is(err, ErrAuthorizationPending):
+		return ErrCodeAuthorizationPending
+	case errors.Is(err, ErrSlowDown):
+		return ErrCodeSlowDown
+	case errors.Is(err, ErrExpiredToken):
+		return ErrCodeExpiredToken
+	case errors.Is(err, ErrInvalidTarget):
+		return ErrCodeInvalidTarget
+	case errors.Is(err, ErrInvalidRequestObject):
+		return ErrCodeInvalidRequestObject
+	case errors.Is(err, ErrInvalidRequestURI):
+		return ErrCodeInvalidRequestURI
+	case errors.Is(err, ErrInvalidDPoPProof):
+		return ErrCodeInvalidDPoPProof
+	case errors.Is(err, ErrUseDPoPNonce):
+		return ErrCodeUseDPoPNonce
+	case errors.Is(err, ErrUnknownUserID):
+		return ErrCodeUnknownUserID
+	case errors.Is(err, ErrInvalidBindingMessage):
+		return ErrCodeInvalidBindingMessage
+	case errors.Is(err, ErrInvalidAuthorizationDetails):
+		return ErrCodeInvalidAuthorizationDetails
+	case errors.Is(err, ErrLoginRequired):
+		return ErrCodeLoginRequired
+	case errors.Is(err, ErrConsentRequired):
+		return ErrCodeConsentRequired
+	case errors.Is(err, ErrInteractionRequired):
+		return ErrCodeInteractionRequired
+	case errors.Is(err, ErrUnmetAuthenticationRequirements):
+		return ErrCodeUnmetAuthenticationRequirements
+	case errors.Is(err, ErrInsufficientUserAuthentication):
+		return ErrCodeInsufficientUserAuthentication
 	default:
 		return ErrCodeServerError
 	}
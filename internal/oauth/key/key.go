@@ -0,0 +1,189 @@
+package key
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"time"
+)
+
+// rsaKeySize RSA 키 생성시 사용할 키의 크기
+const rsaKeySize = 2048
+
+// Algorithm JWS 서명 알고리즘 [RFC 7518]
+//
+// [RFC 7518]: https://datatracker.ietf.org/doc/html/rfc7518#section-3.1
+type Algorithm string
+
+const (
+	AlgorithmRS256 Algorithm = "RS256"
+	AlgorithmPS256 Algorithm = "PS256"
+	AlgorithmES256 Algorithm = "ES256"
+)
+
+// State 키의 상태
+type State string
+
+const (
+	// StateNext 다음 교체시 활성화 될 키
+	// 서명에는 사용되지 않지만 리소스 서버가 미리 캐싱 할 수 있도록 공개키 목록(JWKS)에 게시된다.
+	StateNext State = "next"
+
+	// StateActive 현재 서명에 사용중인 키
+	StateActive State = "active"
+
+	// StateRetired 교체되어 더 이상 서명에 사용되지 않는 키
+	// 이 키로 서명된 토큰들이 모두 만료될 때까지 공개키 목록(JWKS)에 게시된다.
+	StateRetired State = "retired"
+)
+
+// GenerateID 키 식별자(kid) 생성 함수
+type GenerateID func() string
+
+// Key 토큰 서명에 사용되는 비대칭 키
+type Key struct {
+	// id 키 식별자로 JOSE 헤더의 kid 값으로 사용된다.
+	id string
+
+	// alg 이 키로 서명할 때 사용할 알고리즘
+	alg Algorithm
+
+	// private 서명에 사용할 개인키
+	private crypto.Signer
+
+	// state 키의 상태
+	state State
+
+	// createdAt, activatedAt, retiredAt 각각 키의 생성, 활성화, 퇴역 시간
+	// 활성화 되거나 퇴역하지 않은 키의 경우 zero time을 가진다.
+	createdAt, activatedAt, retiredAt time.Time
+}
+
+// New 새 키 인스턴스를 생성한다. 생성된 키는 [StateNext] 상태를 가진다.
+func New(id string, alg Algorithm, private crypto.Signer) *Key {
+	return &Key{
+		id:        id,
+		alg:       alg,
+		private:   private,
+		state:     StateNext,
+		createdAt: time.Now(),
+	}
+}
+
+// Generate 인자로 받은 알고리즘에 맞는 새 키를 생성한다.
+func Generate(alg Algorithm, g GenerateID) (*Key, error) {
+	var private crypto.Signer
+	var err error
+	switch alg {
+	case AlgorithmRS256, AlgorithmPS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeySize)
+	case AlgorithmES256:
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, fmt.Errorf("%w: unsupported algorithm(%s)", oautherr.ErrInvalidRequest, alg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error occurred during generate key: %v", oautherr.ErrUnknown, err)
+	}
+	return New(g(), alg, private), nil
+}
+
+func (k *Key) Id() string {
+	return k.id
+}
+
+func (k *Key) Algorithm() Algorithm {
+	return k.alg
+}
+
+func (k *Key) Private() crypto.Signer {
+	return k.private
+}
+
+func (k *Key) State() State {
+	return k.state
+}
+
+func (k *Key) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+func (k *Key) ActivatedAt() time.Time {
+	return k.activatedAt
+}
+
+func (k *Key) RetiredAt() time.Time {
+	return k.retiredAt
+}
+
+// SetLifecycle 키의 상태와 생성, 활성화, 퇴역 시간을 설정한다.
+// 저장소에 저장된 키를 도메인 모델로 복원 할 때 사용한다.
+func (k *Key) SetLifecycle(state State, createdAt, activatedAt, retiredAt time.Time) {
+	k.state = state
+	k.createdAt = createdAt
+	k.activatedAt = activatedAt
+	k.retiredAt = retiredAt
+}
+
+// Activate 키를 활성화 상태로 변경한다.
+func (k *Key) Activate(now time.Time) {
+	k.state = StateActive
+	k.activatedAt = now
+}
+
+// Retire 키를 퇴역 상태로 변경한다.
+func (k *Key) Retire(now time.Time) {
+	k.state = StateRetired
+	k.retiredAt = now
+}
+
+// JWK 공개키를 [RFC 7517] 에 정의된 JWK 형식으로 반환한다.
+//
+// [RFC 7517]: https://datatracker.ietf.org/doc/html/rfc7517
+func (k *Key) JWK() jose.JSONWebKey {
+	return jose.JSONWebKey{
+		Key:       k.Public(),
+		KeyID:     k.id,
+		Algorithm: string(k.alg),
+		Use:       "sig",
+	}
+}
+
+// Public 서명 검증에 사용할 공개키를 반환한다.
+func (k *Key) Public() crypto.PublicKey {
+	return k.private.Public()
+}
+
+// Sign 인자로 받은 클레임을 JSON으로 직렬화 하고 서명하여 JWS Compact 형식의 문자열로 반환한다.
+//
+// Parameters:
+//   - typ: JOSE 헤더의 typ 값. 공백일 경우 typ 헤더를 설정하지 않는다.
+//   - claims: 서명할 클레임
+func (k *Key) Sign(typ string, claims any) (string, error) {
+	opts := (&jose.SignerOptions{}).WithHeader(jose.HeaderKey("kid"), k.id)
+	if typ != "" {
+		opts = opts.WithType(jose.ContentType(typ))
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.SignatureAlgorithm(k.alg), Key: k.private}, opts)
+	if err != nil {
+		return "", fmt.Errorf("%w: error occurred during create signer: %v", oautherr.ErrUnknown, err)
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("%w: error occurred during marshal claims: %v", oautherr.ErrUnknown, err)
+	}
+
+	jws, err := signer.Sign(payload)
+	if err != nil {
+		return "", fmt.Errorf("%w: error occurred during sign claims: %v", oautherr.ErrUnknown, err)
+	}
+	return jws.CompactSerialize()
+}
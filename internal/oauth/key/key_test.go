@@ -0,0 +1,49 @@
+package key
+
+import (
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// testKeyID 테스트용으로 사용할 키 식별자
+const testKeyID = "test_key_id"
+
+func generateTestKeyID() string {
+	return testKeyID
+}
+
+func TestKey_Sign(t *testing.T) {
+	tests := []struct {
+		name string
+		alg  Algorithm
+	}{
+		{name: "RS256 알고리즘으로 서명", alg: AlgorithmRS256},
+		{name: "PS256 알고리즘으로 서명", alg: AlgorithmPS256},
+		{name: "ES256 알고리즘으로 서명", alg: AlgorithmES256},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, err := Generate(tc.alg, generateTestKeyID)
+			assert.Nil(t, err)
+
+			signed, err := k.Sign("at+jwt", map[string]string{"sub": "test_username"})
+			assert.Nil(t, err)
+
+			jws, err := jose.ParseSigned(signed, []jose.SignatureAlgorithm{jose.SignatureAlgorithm(tc.alg)})
+			assert.Nil(t, err)
+			assert.Equal(t, testKeyID, jws.Signatures[0].Header.KeyID)
+			assert.Equal(t, "at+jwt", jws.Signatures[0].Header.ExtraHeaders[jose.HeaderType])
+
+			payload, err := jws.Verify(k.Public())
+			assert.Nil(t, err)
+			assert.JSONEq(t, `{"sub":"test_username"}`, string(payload))
+		})
+	}
+
+	t.Run("지원하지 않는 알고리즘일 경우 에러 발생", func(t *testing.T) {
+		_, err := Generate("HS256", generateTestKeyID)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,139 @@
+package key
+
+import (
+	"github.com/go-jose/go-jose/v4"
+	"slices"
+	"time"
+)
+
+// Set 저장소에 저장된 키 목록
+type Set []*Key
+
+// Active 인자로 받은 알고리즘의 활성화 된 키를 반환한다. 활성화 된 키가 없을 경우 nil을 반환한다.
+func (s Set) Active(alg Algorithm) *Key {
+	return s.find(alg, StateActive)
+}
+
+// Next 인자로 받은 알고리즘의 다음 교체 대기중인 키를 반환한다. 대기중인 키가 없을 경우 nil을 반환한다.
+func (s Set) Next(alg Algorithm) *Key {
+	return s.find(alg, StateNext)
+}
+
+func (s Set) find(alg Algorithm, state State) *Key {
+	for _, k := range s {
+		if k.alg == alg && k.state == state {
+			return k
+		}
+	}
+	return nil
+}
+
+// JWKS 공개키 목록에 게시할 키들을 [RFC 7517] 에 정의된 JWK Set 형식으로 반환한다.
+// 저장소에 있는 키들 중 폐기 대상이 아닌 모든 키(다음, 활성화, 퇴역)가 게시된다.
+//
+// [RFC 7517]: https://datatracker.ietf.org/doc/html/rfc7517#section-5
+func (s Set) JWKS() jose.JSONWebKeySet {
+	keys := make([]jose.JSONWebKey, 0, len(s))
+	for _, k := range s {
+		keys = append(keys, k.JWK())
+	}
+	return jose.JSONWebKeySet{Keys: keys}
+}
+
+// Kids 키 식별자(kid) 목록을 반환한다.
+func (s Set) Kids() []string {
+	kids := make([]string, 0, len(s))
+	for _, k := range s {
+		kids = append(kids, k.id)
+	}
+	return kids
+}
+
+// Rotation 키 교체 정책
+type Rotation struct {
+	// Period 키가 활성화 된 후 교체 될 때까지의 기간
+	Period time.Duration
+
+	// Retention 퇴역한 키를 공개키 목록에 유지할 기간
+	// 퇴역한 키로 서명된 토큰들이 모두 만료 될 수 있도록 서명된 토큰의 최대 유효기간 이상으로 설정해야 한다.
+	Retention time.Duration
+}
+
+// Plan 키 교체 결과 저장소에 반영 되어야 할 변경 사항
+type Plan struct {
+	// Created 새로 생성된 키
+	Created []*Key
+
+	// Updated 상태가 변경된 키
+	Updated []*Key
+
+	// Deleted 공개키 목록 유지 기간이 지나 폐기 되어야 할 키
+	Deleted []*Key
+}
+
+// Empty 변경 사항이 없는지 여부
+func (p *Plan) Empty() bool {
+	return len(p.Created) == 0 && len(p.Updated) == 0 && len(p.Deleted) == 0
+}
+
+// Apply 인자로 받은 키 목록에 변경 사항을 적용한 새 키 목록을 반환한다.
+// 상태가 변경된 키는 [Rotation.Rotate] 에서 이미 변경 되었으므로 생성된 키를 추가하고 폐기 된 키를 제거한다.
+func (p *Plan) Apply(s Set) Set {
+	s = append(slices.Clone(s), p.Created...)
+	return slices.DeleteFunc(s, func(k *Key) bool {
+		return slices.Contains(p.Deleted, k)
+	})
+}
+
+// Rotate 인자로 받은 키 목록에 교체 정책을 적용하고 변경 사항을 반환한다.
+//
+// 알고리즘 별로 아래 규칙에 따라 키를 교체한다.
+//   - 활성화 된 키가 없을 경우 대기중인 키를 활성화 하며 대기중인 키도 없을 경우 새 키를 생성하여 바로 활성화 한다.
+//   - 활성화 된 키가 교체 기간을 넘은 경우 퇴역시키고 대기중인 키(없을 경우 새 키)를 활성화 한다.
+//   - 대기중인 키가 없을 경우 다음 교체를 위한 새 키를 생성한다.
+//
+// 인자로 받은 알고리즘에 포함되지 않은 알고리즘의 활성화 된 키는 퇴역시키며,
+// 퇴역한 후 유지 기간이 지난 키는 폐기 대상으로 반환한다.
+func (r *Rotation) Rotate(s Set, algs []Algorithm, now time.Time, g GenerateID) (*Plan, error) {
+	plan := &Plan{}
+	for _, alg := range algs {
+		active := s.Active(alg)
+		if active == nil || !now.Before(active.activatedAt.Add(r.Period)) {
+			if active != nil {
+				active.Retire(now)
+				plan.Updated = append(plan.Updated, active)
+			}
+			next := s.Next(alg)
+			if next == nil {
+				created, err := Generate(alg, g)
+				if err != nil {
+					return nil, err
+				}
+				s = append(s, created)
+				plan.Created = append(plan.Created, created)
+				next = created
+			} else {
+				plan.Updated = append(plan.Updated, next)
+			}
+			next.Activate(now)
+		}
+		if s.Next(alg) == nil {
+			created, err := Generate(alg, g)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, created)
+			plan.Created = append(plan.Created, created)
+		}
+	}
+	for _, k := range s {
+		switch {
+		case k.state == StateActive && !slices.Contains(algs, k.alg):
+			k.Retire(now)
+			plan.Updated = append(plan.Updated, k)
+		case k.state == StateRetired && !now.Before(k.retiredAt.Add(r.Retention)):
+			plan.Deleted = append(plan.Deleted, k)
+		}
+	}
+	return plan, nil
+}
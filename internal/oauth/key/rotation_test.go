@@ -0,0 +1,104 @@
+package key
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var testRotation = &Rotation{Period: 24 * time.Hour, Retention: time.Hour}
+
+var testNow = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newTestKey 테스트용 ES256 키를 생성하고 인자로 받은 상태를 설정한다.
+func newTestKey(id string, state State, activatedAt, retiredAt time.Time) *Key {
+	private, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	k := New(id, AlgorithmES256, private)
+	k.SetLifecycle(state, activatedAt, activatedAt, retiredAt)
+	return k
+}
+
+func TestRotation_Rotate(t *testing.T) {
+	algs := []Algorithm{AlgorithmES256}
+
+	t.Run("저장된 키가 없을 경우 키를 생성하여 활성화 하고 다음 키를 생성함", func(t *testing.T) {
+		plan, err := testRotation.Rotate(nil, algs, testNow, generateTestKeyID)
+		assert.Nil(t, err)
+		assert.Len(t, plan.Created, 2)
+		assert.Equal(t, StateActive, plan.Created[0].State())
+		assert.Equal(t, testNow, plan.Created[0].ActivatedAt())
+		assert.Equal(t, StateNext, plan.Created[1].State())
+	})
+
+	t.Run("활성화 된 키가 교체 기간을 넘지 않은 경우 변경 사항이 없음", func(t *testing.T) {
+		active := newTestKey("active", StateActive, testNow.Add(-time.Hour), time.Time{})
+		next := newTestKey("next", StateNext, time.Time{}, time.Time{})
+
+		plan, err := testRotation.Rotate(Set{active, next}, algs, testNow, generateTestKeyID)
+		assert.Nil(t, err)
+		assert.True(t, plan.Empty())
+		assert.Equal(t, StateActive, active.State())
+	})
+
+	t.Run("활성화 된 키가 교체 기간을 넘은 경우 퇴역시키고 다음 키를 활성화 함", func(t *testing.T) {
+		active := newTestKey("active", StateActive, testNow.Add(-testRotation.Period), time.Time{})
+		next := newTestKey("next", StateNext, time.Time{}, time.Time{})
+
+		plan, err := testRotation.Rotate(Set{active, next}, algs, testNow, generateTestKeyID)
+		assert.Nil(t, err)
+		assert.Equal(t, StateRetired, active.State())
+		assert.Equal(t, testNow, active.RetiredAt())
+		assert.Equal(t, StateActive, next.State())
+		assert.Equal(t, testNow, next.ActivatedAt())
+		assert.Equal(t, []*Key{active, next}, plan.Updated)
+		assert.Len(t, plan.Created, 1)
+		assert.Equal(t, StateNext, plan.Created[0].State())
+	})
+
+	t.Run("퇴역한 키가 유지 기간을 넘지 않은 경우 폐기하지 않음", func(t *testing.T) {
+		active := newTestKey("active", StateActive, testNow, time.Time{})
+		next := newTestKey("next", StateNext, time.Time{}, time.Time{})
+		retired := newTestKey("retired", StateRetired, testNow.Add(-48*time.Hour), testNow.Add(-testRotation.Retention+time.Second))
+
+		plan, err := testRotation.Rotate(Set{active, next, retired}, algs, testNow, generateTestKeyID)
+		assert.Nil(t, err)
+		assert.Empty(t, plan.Deleted)
+	})
+
+	t.Run("퇴역한 키가 유지 기간을 넘은 경우 폐기 대상으로 반환함", func(t *testing.T) {
+		active := newTestKey("active", StateActive, testNow, time.Time{})
+		next := newTestKey("next", StateNext, time.Time{}, time.Time{})
+		retired := newTestKey("retired", StateRetired, testNow.Add(-48*time.Hour), testNow.Add(-testRotation.Retention))
+
+		plan, err := testRotation.Rotate(Set{active, next, retired}, algs, testNow, generateTestKeyID)
+		assert.Nil(t, err)
+		assert.Equal(t, []*Key{retired}, plan.Deleted)
+	})
+
+	t.Run("사용하지 않는 알고리즘의 활성화 된 키는 퇴역시킴", func(t *testing.T) {
+		active := newTestKey("active", StateActive, testNow, time.Time{})
+
+		plan, err := testRotation.Rotate(Set{active}, []Algorithm{}, testNow, generateTestKeyID)
+		assert.Nil(t, err)
+		assert.Equal(t, StateRetired, active.State())
+		assert.Equal(t, []*Key{active}, plan.Updated)
+	})
+}
+
+func TestSet_JWKS(t *testing.T) {
+	active := newTestKey("active", StateActive, testNow, time.Time{})
+	next := newTestKey("next", StateNext, time.Time{}, time.Time{})
+	retired := newTestKey("retired", StateRetired, testNow.Add(-48*time.Hour), testNow)
+
+	jwks := Set{active, next, retired}.JWKS()
+	assert.Len(t, jwks.Keys, 3)
+	for _, k := range jwks.Keys {
+		assert.True(t, k.IsPublic())
+		assert.Equal(t, "sig", k.Use)
+		assert.Equal(t, string(AlgorithmES256), k.Algorithm)
+	}
+	assert.Equal(t, "retired", jwks.Key("retired")[0].KeyID)
+}
@@ -0,0 +1,107 @@
+package resource
+
+import (
+	"fmt"
+	"net/url"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"slices"
+)
+
+// Server [RFC 8707] 토큰의 대상(aud)이 될 수 있는 자원 서버
+//
+// 인가 서버에 등록된 자원 서버만 자원 지시자(resource)로 요청 할 수 있으며,
+// 자원 서버를 대상으로 발급되는 토큰에는 자원 서버가 소유한 스코프만 부여된다.
+//
+// [RFC 8707]: https://datatracker.ietf.org/doc/html/rfc8707
+type Server struct {
+	// uri 자원 서버를 식별하는 자원 지시자. 프래그먼트가 없는 절대 경로 URI 이다.
+	uri string
+
+	// name 자원 서버 이름
+	name string
+
+	// scopes 자원 서버가 소유한 스코프 목록
+	scopes []string
+
+	// clientID 자원 서버가 토큰 교환 등으로 인가 서버에 요청 할 때 사용하는 클라이언트 아이디
+	// 클라이언트로 등록되지 않은 자원 서버는 공백("")이다.
+	clientID string
+}
+
+func New(uri, name string, scopes []string) *Server {
+	return &Server{uri: uri, name: name, scopes: scopes}
+}
+
+func (s *Server) URI() string {
+	return s.uri
+}
+
+func (s *Server) Name() string {
+	return s.name
+}
+
+func (s *Server) Scopes() []string {
+	return s.scopes
+}
+
+func (s *Server) ClientID() string {
+	return s.clientID
+}
+
+func (s *Server) SetClientID(clientID string) {
+	s.clientID = clientID
+}
+
+// Retrieve 자원 지시자로 등록된 자원 서버를 조회하는 함수
+//
+// Returns:
+//   - *Server: 조회된 자원 서버
+//   - bool: 조회 성공 여부
+type Retrieve func(uri string) (*Server, bool)
+
+// RetrieveByClient 클라이언트 아이디로 클라이언트가 사용하는 자원 서버 목록을 조회하는 함수
+type RetrieveByClient func(clientID string) []*Server
+
+// ValidateIndicator 자원 지시자가 프래그먼트가 없는 절대 경로 URI 인지 확인한다.
+// 유효하지 않은 경우 [oautherr.ErrInvalidTarget] 을 반환한다.
+func ValidateIndicator(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil || !u.IsAbs() || u.Fragment != "" {
+		return fmt.Errorf("%w: invalid resource(%s)", oautherr.ErrInvalidTarget, uri)
+	}
+	return nil
+}
+
+// Resolve 자원 지시자 목록을 검증하고 등록된 자원 서버 목록을 반환한다.
+// 형식이 잘못되었거나 등록되지 않은 자원 지시자가 있는 경우 [oautherr.ErrInvalidTarget] 을 반환한다.
+func Resolve(retrieve Retrieve, resources []string) ([]*Server, error) {
+	servers := make([]*Server, 0, len(resources))
+	for _, uri := range resources {
+		if err := ValidateIndicator(uri); err != nil {
+			return nil, err
+		}
+		s, ok := retrieve(uri)
+		if !ok {
+			return nil, fmt.Errorf("%w: resource(%s) is not registered", oautherr.ErrInvalidTarget, uri)
+		}
+		servers = append(servers, s)
+	}
+	return servers, nil
+}
+
+// Restrict 스코프 목록에서 자원 서버들 중 하나라도 소유한 스코프만 선택하여 반환한다.
+// 요청된 스코프가 있지만 자원 서버들이 소유한 스코프가 하나도 없는 경우 [oautherr.ErrInvalidScope] 를 반환한다.
+func Restrict(servers []*Server, scopes []string) ([]string, error) {
+	var restricted []string
+	for _, s := range scopes {
+		if slices.ContainsFunc(servers, func(server *Server) bool {
+			return slices.Contains(server.scopes, s)
+		}) {
+			restricted = append(restricted, s)
+		}
+	}
+	if len(scopes) > 0 && len(restricted) == 0 {
+		return nil, fmt.Errorf("%w: requested scopes are not owned by resources", oautherr.ErrInvalidScope)
+	}
+	return restricted, nil
+}
@@ -0,0 +1,59 @@
+package resource
+
+import (
+	"github.com/stretchr/testify/assert"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"testing"
+)
+
+var (
+	testOrders  = New("https://orders.example.com", "orders", []string{"orders.read", "orders.write"})
+	testPayment = New("https://payment.example.com", "payment", []string{"payment.read"})
+)
+
+func retrieveTestServer(uri string) (*Server, bool) {
+	for _, s := range []*Server{testOrders, testPayment} {
+		if s.URI() == uri {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+func TestResolve(t *testing.T) {
+	t.Run("등록된 자원 서버 반환", func(t *testing.T) {
+		servers, err := Resolve(retrieveTestServer, []string{"https://orders.example.com", "https://payment.example.com"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*Server{testOrders, testPayment}, servers)
+	})
+
+	tests := []struct {
+		name     string
+		resource string
+	}{
+		{name: "상대 경로", resource: "/orders"},
+		{name: "프래그먼트 포함", resource: "https://orders.example.com#fragment"},
+		{name: "등록되지 않은 자원 서버", resource: "https://unknown.example.com"},
+	}
+	for _, tc := range tests {
+		t.Run("ErrInvalidTarget 발생/"+tc.name, func(t *testing.T) {
+			_, err := Resolve(retrieveTestServer, []string{tc.resource})
+			assert.ErrorIs(t, err, oautherr.ErrInvalidTarget)
+		})
+	}
+}
+
+func TestRestrict(t *testing.T) {
+	t.Run("자원 서버가 소유한 스코프만 선택", func(t *testing.T) {
+		scopes, err := Restrict([]*Server{testOrders}, []string{"orders.read", "payment.read"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"orders.read"}, scopes)
+	})
+
+	t.Run("자원 서버가 소유한 스코프가 없는 경우 ErrInvalidScope 발생", func(t *testing.T) {
+		_, err := Restrict([]*Server{testPayment}, []string{"orders.read"})
+		assert.ErrorIs(t, err, oautherr.ErrInvalidScope)
+	})
+}
@@ -4,6 +4,12 @@ import (
 	"strings"
 )
 
+// OpenID [OpenID Connect] 인증 요청임을 나타내는 스코프
+// 인가 코드 승인 방식에서 이 스코프가 부여된 경우 엑세스 토큰과 함께 ID 토큰이 발급된다.
+//
+// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#AuthRequest
+const OpenID = "openid"
+
 // Scope OAuth2 스코프
 //
 // 관리 포인트를 위한 구조체로 실제 클라이언트나 엑세스 토큰등에서는
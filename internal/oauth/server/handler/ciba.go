@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"oauth-server-go/internal/config/log"
+	"oauth-server-go/internal/oauth/authorization"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/server/pkg/security"
+	"oauth-server-go/internal/oauth/server/service"
+	"oauth-server-go/internal/pkg/web"
+	"time"
+)
+
+// sessionKeyBackchannelAuthReqID 자원 소유자가 확인한 백채널 인증 요청 식별자를 세션에 저장할 때 사용하는 키
+//
+// 인증 요청 페이지에서 요청이 확인되면 세션에 저장되었다가 자원 소유자가 승인/거부 할 때 사용되며 처리가 완료되면 세션에서 삭제한다.
+const sessionKeyBackchannelAuthReqID = "sessions/backchannelAuthReqID"
+
+// BackchannelAuthenticationResponse [OpenID CIBA] 백채널 인증 응답
+//
+// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.7.3
+type BackchannelAuthenticationResponse struct {
+	AuthReqID string `json:"auth_req_id"`
+	ExpiresIn uint   `json:"expires_in"`
+
+	// Interval poll 방식의 클라이언트에만 응답한다.
+	Interval uint `json:"interval,omitempty"`
+}
+
+// BackchannelHandler [OpenID CIBA] 백채널 인증 엔드포인트와 자원 소유자의 인증 요청 페이지를 처리하는 핸들러 구조체
+//
+// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html
+type BackchannelHandler struct {
+	BackchannelAuthenticationService *service.BackchannelAuthenticationService
+	ScopeService                     *service.ScopeService
+}
+
+// BackchannelAuthorize 새 백채널 인증 요청을 생성하고 인증 요청 식별자를 발급한다.
+//
+// Parameter(application/form-data): [authorization.BackchannelRequest]
+//
+// Returns: [BackchannelAuthenticationResponse]
+func (h *BackchannelHandler) BackchannelAuthorize(ctx *gin.Context) error {
+	var request authorization.BackchannelRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		return NewOAuth2Error(oautherr.ErrInvalidRequest, "invalid request")
+	}
+
+	clt, exists := security.RetrieveClientAuthentication(ctx)
+	if !exists {
+		return NewOAuth2Error(oautherr.ErrInvalidClient, "invalid client")
+	}
+
+	authentication, err := h.BackchannelAuthenticationService.Authenticate(ctx.Request.Context(), clt, &request)
+	if err != nil {
+		log.Sugared().Errorf("error occurred during backchannel authentication: %v", err)
+		return NewOAuth2Error(err, "error occurred during backchannel authentication")
+	}
+
+	response := BackchannelAuthenticationResponse{
+		AuthReqID: authentication.AuthReqID(),
+		ExpiresIn: uint(time.Until(authentication.End()).Seconds()),
+	}
+	if clt.BackchannelTokenDeliveryMode() == client.BackchannelTokenDeliveryPoll {
+		response.Interval = uint(authentication.Interval() / time.Second)
+	}
+	ctx.JSON(http.StatusOK, response)
+	return nil
+}
+
+// Verification 자원 소유자의 인증 요청 페이지
+//
+// 인증 요청 식별자가 입력되지 않은 경우 자원 소유자의 승인을 기다리는 인증 요청 목록 페이지를 반환하며,
+// 입력된 경우 인증 요청 식별자를 세션에 저장하고 인가 승인 페이지를 반환한다.
+//
+// Parameter(query):
+//   - auth_req_id: 승인할 인증 요청 식별자
+//
+// Note: 이 페이지는 사용자의 로그인이 완료 된 후 접근 해야 한다.
+func (h *BackchannelHandler) Verification(ctx *gin.Context) error {
+	requestContext := ctx.Request.Context()
+	user, _ := web.RetrieveAuthentication(ctx)
+	pending := h.BackchannelAuthenticationService.RetrievePending(requestContext, user.Username)
+
+	authReqID := ctx.Query("auth_req_id")
+	if authReqID == "" {
+		ctx.HTML(http.StatusOK, "backchannel.html", gin.H{"requests": pending})
+		return nil
+	}
+
+	var authentication *authorization.BackchannelAuthentication
+	for _, p := range pending {
+		if p.AuthReqID() == authReqID {
+			authentication = p
+			break
+		}
+	}
+	if authentication == nil {
+		ctx.HTML(http.StatusOK, "backchannel.html", gin.H{
+			"requests": pending,
+			"error":    "유효하지 않거나 만료된 요청입니다.",
+		})
+		return nil
+	}
+
+	session := sessions.Default(ctx)
+	session.Set(sessionKeyBackchannelAuthReqID, authentication.AuthReqID())
+	if err := session.Save(); err != nil {
+		return web.Wrap(err, web.ErrCodeUnknown, "알 수 없는 에러")
+	}
+
+	ctx.HTML(http.StatusOK, "approval.html", gin.H{
+		"scopes":         h.ScopeService.Retrieve(requestContext, authentication.Scopes()...),
+		"c":              authentication.Client().Name(),
+		"bindingMessage": authentication.BindingMessage(),
+		"action":         ctx.Request.URL.Path,
+	})
+	return nil
+}
+
+// Approve 자원 소유자가 백채널 인증 요청을 승인/거부한다.
+// 스코프의 경우 클라이언트가 요청했던 스코프 중 자원 소유자가 승인한 스코프만 부여한다.
+//
+// Parameters(application/form-data):
+//   - scope: 사용자가 승인 한 스코프 만약 이 값이 비어 있을 경우 사용자가 승인을 거부한 것으로 간주한다.
+//
+// Note: 이 페이지는 사용자의 로그인이 완료 된 후 접근 해야 한다.
+func (h *BackchannelHandler) Approve(ctx *gin.Context) error {
+	session := sessions.Default(ctx)
+	authReqID, ok := session.Get(sessionKeyBackchannelAuthReqID).(string)
+	if !ok {
+		ctx.HTML(http.StatusOK, "backchannel.html", gin.H{"error": "인증 요청을 먼저 선택해 주세요."})
+		return nil
+	}
+
+	session.Delete(sessionKeyBackchannelAuthReqID)
+	if err := session.Save(); err != nil {
+		return web.Wrap(err, web.ErrCodeUnknown, "알 수 없는 에러")
+	}
+
+	user, ok := web.RetrieveAuthentication(ctx)
+	if !ok {
+		user = &web.Authentication{}
+	}
+	approvedScopes := ctx.PostFormArray("scope")
+	if _, err := h.BackchannelAuthenticationService.Approve(ctx.Request.Context(), authReqID, user, approvedScopes); err != nil {
+		log.Sugared().Warnf("error occurred during approve backchannel authentication(%s): %v", authReqID, err)
+		ctx.HTML(http.StatusOK, "backchannel.html", gin.H{"error": "유효하지 않거나 만료된 요청입니다."})
+		return nil
+	}
+
+	message := "인증 요청이 승인되었습니다. 요청한 기기로 돌아가 계속 진행해 주세요."
+	if len(approvedScopes) == 0 {
+		message = "인증 요청이 거부되었습니다."
+	}
+	ctx.HTML(http.StatusOK, "backchannel.html", gin.H{"message": message})
+	return nil
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"encoding/json"
+	"oauth-server-go/internal/oauth/detail"
+	"strconv"
+)
+
+// AuthorizationDetailView 인가 승인 페이지에서 자원 소유자에게 보여줄 인가 상세 정보
+type AuthorizationDetailView struct {
+	// Type 인가 상세 정보의 타입
+	Type string
+
+	// Description 타입 설명. 타입에 설명이 등록되지 않은 경우 타입 이름을 사용한다.
+	Description string
+
+	// Content 들여쓰기 된 인가 상세 정보의 JSON 문자열
+	Content string
+}
+
+// newAuthorizationDetailViews 인가 상세 정보 목록을 인가 승인 페이지에서 보여줄 형태로 변환한다.
+func newAuthorizationDetailViews(retrieve detail.Retrieve, details detail.Details) []AuthorizationDetailView {
+	views := make([]AuthorizationDetailView, 0, len(details))
+	for _, d := range details {
+		view := AuthorizationDetailView{Type: d.Type(), Description: d.Type()}
+		if t, ok := retrieve(d.Type()); ok && t.Description() != "" {
+			view.Description = t.Description()
+		}
+		content, _ := json.MarshalIndent(d, "", "  ")
+		view.Content = string(content)
+		views = append(views, view)
+	}
+	return views
+}
+
+// selectAuthorizationDetails 인가 요청의 인가 상세 정보 중 자원 소유자가 승인한 인덱스의 인가 상세 정보만 선택하여 반환한다.
+// 숫자가 아니거나 범위를 벗어난 인덱스는 무시한다.
+func selectAuthorizationDetails(requested string, indexes []string) (detail.Details, error) {
+	details, err := detail.Parse(requested)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected detail.Details
+	for i, d := range details {
+		for _, index := range indexes {
+			if n, err := strconv.Atoi(index); err == nil && n == i {
+				selected = append(selected, d)
+				break
+			}
+		}
+	}
+	return selected, nil
+}
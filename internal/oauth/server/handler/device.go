@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"oauth-server-go/internal/config/log"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/server/pkg/security"
+	"oauth-server-go/internal/oauth/server/service"
+	"oauth-server-go/internal/pkg/web"
+	"time"
+)
+
+// sessionKeyDeviceUserCode 자원 소유자가 입력한 사용자 코드를 세션에 저장할 때 사용하는 키
+//
+// 인증 페이지에서 사용자 코드가 확인되면 세션에 저장되었다가 자원 소유자가 승인/거부 할 때 사용되며 처리가 완료되면 세션에서 삭제한다.
+const sessionKeyDeviceUserCode = "sessions/deviceUserCode"
+
+// DeviceAuthorizationResponse [RFC 8628] 디바이스 인가 응답
+//
+// [RFC 8628]: https://datatracker.ietf.org/doc/html/rfc8628#section-3.2
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               uint   `json:"expires_in"`
+	Interval                uint   `json:"interval"`
+}
+
+// DeviceHandler [RFC 8628] 디바이스 인가 엔드포인트와 자원 소유자의 인증 페이지를 처리하는 핸들러 구조체
+//
+// [RFC 8628]: https://datatracker.ietf.org/doc/html/rfc8628
+type DeviceHandler struct {
+	DeviceCodeService *service.DeviceCodeService
+	ScopeService      *service.ScopeService
+
+	// VerificationURI 자원 소유자가 사용자 코드를 입력하는 인증 페이지의 URL
+	VerificationURI string
+}
+
+// DeviceAuthorization 새 디바이스 코드와 사용자 코드를 발급한다.
+//
+// Parameter(application/form-data):
+//   - scope: 공백으로 구분된 요청 스코프
+//
+// Returns: [DeviceAuthorizationResponse]
+func (h *DeviceHandler) DeviceAuthorization(ctx *gin.Context) error {
+	clt, exists := security.RetrieveClientAuthentication(ctx)
+	if !exists {
+		return NewOAuth2Error(oautherr.ErrInvalidClient, "invalid client")
+	}
+
+	deviceCode, err := h.DeviceCodeService.NewDeviceCode(ctx.Request.Context(), clt, ctx.PostForm("scope"))
+	if err != nil {
+		log.Sugared().Errorf("error occurred during device authorization: %v", err)
+		return NewOAuth2Error(err, "error occurred during device authorization")
+	}
+
+	ctx.JSON(http.StatusOK, DeviceAuthorizationResponse{
+		DeviceCode:              deviceCode.DeviceCode(),
+		UserCode:                deviceCode.UserCode(),
+		VerificationURI:         h.VerificationURI,
+		VerificationURIComplete: h.VerificationURI + "?user_code=" + url.QueryEscape(deviceCode.UserCode()),
+		ExpiresIn:               uint(time.Until(deviceCode.End()).Seconds()),
+		Interval:                uint(deviceCode.Interval() / time.Second),
+	})
+	return nil
+}
+
+// Verification 자원 소유자의 인증 페이지
+//
+// 사용자 코드가 입력되지 않은 경우 사용자 코드 입력 페이지를 반환하며,
+// 입력된 경우 사용자 코드를 세션에 저장하고 인가 승인 페이지를 반환한다.
+//
+// Parameter(query):
+//   - user_code: 디바이스에 표시된 사용자 코드
+//
+// Note: 이 페이지는 사용자의 로그인이 완료 된 후 접근 해야 한다.
+func (h *DeviceHandler) Verification(ctx *gin.Context) error {
+	userCode := ctx.Query("user_code")
+	if userCode == "" {
+		ctx.HTML(http.StatusOK, "device.html", gin.H{})
+		return nil
+	}
+
+	requestContext := ctx.Request.Context()
+	deviceCode, ok := h.DeviceCodeService.RetrievePending(requestContext, userCode)
+	if !ok {
+		ctx.HTML(http.StatusOK, "device.html", gin.H{
+			"userCode": userCode,
+			"error":    "유효하지 않거나 만료된 코드입니다.",
+		})
+		return nil
+	}
+
+	session := sessions.Default(ctx)
+	session.Set(sessionKeyDeviceUserCode, deviceCode.UserCode())
+	if err := session.Save(); err != nil {
+		return web.Wrap(err, web.ErrCodeUnknown, "알 수 없는 에러")
+	}
+
+	ctx.HTML(http.StatusOK, "approval.html", gin.H{
+		"scopes": h.ScopeService.Retrieve(requestContext, deviceCode.Scopes()...),
+		"c":      deviceCode.Client().Name(),
+		"action": ctx.Request.URL.Path,
+	})
+	return nil
+}
+
+// Approve 자원 소유자가 디바이스 인가 요청을 승인/거부한다.
+// 스코프의 경우 디바이스가 요청했던 스코프 중 자원 소유자가 승인한 스코프만 부여한다.
+//
+// Parameters(application/form-data):
+//   - scope: 사용자가 승인 한 스코프 만약 이 값이 비어 있을 경우 사용자가 승인을 거부한 것으로 간주한다.
+//
+// Note: 이 페이지는 사용자의 로그인이 완료 된 후 접근 해야 한다.
+func (h *DeviceHandler) Approve(ctx *gin.Context) error {
+	session := sessions.Default(ctx)
+	userCode, ok := session.Get(sessionKeyDeviceUserCode).(string)
+	if !ok {
+		ctx.HTML(http.StatusOK, "device.html", gin.H{"error": "사용자 코드를 먼저 입력해 주세요."})
+		return nil
+	}
+
+	session.Delete(sessionKeyDeviceUserCode)
+	if err := session.Save(); err != nil {
+		return web.Wrap(err, web.ErrCodeUnknown, "알 수 없는 에러")
+	}
+
+	authentication, ok := web.RetrieveAuthentication(ctx)
+	if !ok {
+		authentication = &web.Authentication{}
+	}
+	approvedScopes := ctx.PostFormArray("scope")
+	if _, err := h.DeviceCodeService.Approve(ctx.Request.Context(), userCode, authentication, approvedScopes); err != nil {
+		log.Sugared().Warnf("error occurred during approve device code(%s): %v", userCode, err)
+		ctx.HTML(http.StatusOK, "device.html", gin.H{"error": "유효하지 않거나 만료된 코드입니다."})
+		return nil
+	}
+
+	message := "기기 연결이 승인되었습니다. 기기로 돌아가 계속 진행해 주세요."
+	if len(approvedScopes) == 0 {
+		message = "기기 연결이 거부되었습니다."
+	}
+	ctx.HTML(http.StatusOK, "device.html", gin.H{"message": message})
+	return nil
+}
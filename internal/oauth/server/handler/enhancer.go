@@ -1,16 +1,21 @@
 package handler
 
 import (
+	"github.com/gin-gonic/gin"
+	"net/http"
 	"net/url"
 	"oauth-server-go/internal/oauth/authorization"
 	"oauth-server-go/internal/oauth/scope"
 	"oauth-server-go/internal/oauth/token"
 	"strconv"
+	"time"
 )
 
 // Enhancer OAuth2 인가 요청 결과를 리다이렉트 URI에 추가하는 함수
 //
 // OAuth2 2.0의 다양한 인가 방식에 따라 인가 코드나 엑세스 토큰을 리다이렉트 URI에 적절히 추가하는 역할을 한다.
+// 응답 파라미터는 인가 요청의 전달 방식(response_mode)이 query 인 경우 쿼리 파라미터에, 그 외의 경우 프래그먼트에 추가되며
+// form_post 방식은 프래그먼트에 추가된 응답 파라미터를 [respondAuthorization] 에서 HTML 폼으로 변환하여 전달한다.
 //
 // Parameter:
 //   - request: 인가 요청 정보 원본
@@ -34,12 +39,12 @@ func ChainEnhancer(e ...Enhancer) Enhancer {
 }
 
 // EnhanceAuthorizationCode Authorization Code Grant 흐름([RFC 6749 섹션 4.1.2])을 처리한다.
-// response_type이 "code"인 경우 인가 코드를 리다이렉트 URL의 쿼리 파라미터로 추가한다.
+// response_type이 "code"인 경우 인가 코드를 리다이렉트 URL에 추가한다.
 //
 // 동작 방식:
 //  1. response_type이 "code"인지 확인하고 아니면 아무 처리도 하지 않음
 //  2. src가 AuthorizationCode 타입인지 확인
-//  3. 인가 코드를 리다이렉트 URL의 쿼리 파라미터로 추가
+//  3. 인가 코드를 전달 방식에 따라 리다이렉트 URL의 쿼리 파라미터나 프래그먼트로 추가
 //  4. state 값이 있으면 함께 추가
 //
 // [RFC 6749 섹션 4.1.2]: https://datatracker.ietf.org/doc/html/rfc6749#section-4.1.2
@@ -49,25 +54,25 @@ func EnhanceAuthorizationCode(request *authorization.Request, src any, redirect
 	}
 
 	if c, ok := src.(*authorization.Code); ok {
-		q := redirect.Query()
-		q.Set("code", c.Value())
+		params := url.Values{}
+		params.Set("code", c.Value())
 		if c.State() != "" {
-			q.Set("state", c.State())
+			params.Set("state", c.State())
 		}
-		redirect.RawQuery = q.Encode()
+		setResponseParams(request, redirect, params)
 	}
 
 	return nil
 }
 
 // EnhanceImplicit Implicit Grant 흐름([RFC 6749 섹션 4.2])을 처리한다.
-// response_type이 "token"인 경우 액세스 토큰을 리다이렉트 URL의 프래그먼트로 추가한다.
+// response_type이 "token"인 경우 액세스 토큰을 리다이렉트 URL에 추가한다.
 //
 // 동작 방식:
 //  1. response_type이 "token"인지 확인하고 아니면 아무 처리도 하지 않음
 //  2. src가 Token 타입인지 확인
 //  3. 액세스 토큰 및 관련 정보(token_type, expires_in, scope, state)를
-//     리다이렉트 URL의 프래그먼트(#)로 추가
+//     리다이렉트 URL의 프래그먼트(#)로 추가. 엑세스 토큰은 쿼리 파라미터로 전달하지 않는다.
 //
 // [RFC 6749 섹션 4.2.2]: https://datatracker.ietf.org/doc/html/rfc6749#section-4.2.2
 func EnhanceImplicit(request *authorization.Request, src any, redirect *url.URL) error {
@@ -75,15 +80,124 @@ func EnhanceImplicit(request *authorization.Request, src any, redirect *url.URL)
 		return nil
 	}
 
-	if t, ok := src.(token.AccessToken); ok {
-		q := redirect.Query()
-		q.Set("access_token", t.Value())
-		q.Set("token_type", string(token.TypeBearer))
-		q.Set("expires_in", strconv.FormatUint(uint64(t.ExpiresIn()), 10))
-		q.Set("scope", scope.Join(t.Scopes()))
-		q.Set("state", request.State)
-		redirect.Fragment = q.Encode()
+	if t, ok := src.(*token.AccessToken); ok {
+		params := url.Values{}
+		params.Set("access_token", t.Value())
+		params.Set("token_type", string(token.TypeBearer))
+		params.Set("expires_in", strconv.FormatUint(uint64(t.ExpiresIn()), 10))
+		params.Set("scope", scope.Join(t.Scopes()))
+		params.Set("state", request.State)
+		setResponseParams(request, redirect, params)
 	}
 
 	return nil
 }
+
+// EnhanceError 인가 요청 처리 중 발생한 에러를 리다이렉트 URL에 추가한다.
+// src가 ErrorResponse 타입인 경우 에러 코드와 메시지, state 를 전달 방식에 따라 쿼리 파라미터나 프래그먼트로 추가한다.
+func EnhanceError(request *authorization.Request, src any, redirect *url.URL) error {
+	if res, ok := src.(*ErrorResponse); ok {
+		setResponseParams(request, redirect, res.Values())
+	}
+	return nil
+}
+
+// EnhanceIssuer [RFC 9207] 인가 응답과 에러 응답에 인가 서버 식별자(iss)를 추가하는 Enhancer 를 생성한다.
+//
+// 여러 인가 서버를 사용하는 클라이언트는 iss 파라미터로 응답을 보낸 인가 서버를 확인하여 mix-up 공격을 막을 수 있다.
+// iss 는 인가 서버 메타데이터의 issuer 와 같은 값이어야 하며, JWT 전달 방식에서는 응답 JWT의 iss 클레임으로 전달된다.
+//
+// [RFC 9207]: https://datatracker.ietf.org/doc/html/rfc9207#section-2
+func EnhanceIssuer(issuer string) Enhancer {
+	return func(request *authorization.Request, src any, redirect *url.URL) error {
+		setResponseParams(request, redirect, url.Values{"iss": {issuer}})
+		return nil
+	}
+}
+
+// EncodeResponseJWT [JARM] 인가 응답 파라미터를 서명된 JWT로 인코딩하는 함수
+//
+// [JARM]: https://openid.net/specs/oauth-v2-jarm.html#section-2.1
+type EncodeResponseJWT func(clientID string, params url.Values, now time.Time) (string, error)
+
+// EnhanceJWTResponse [JARM] 에 정의된 JWT 전달 방식(jwt, query.jwt, fragment.jwt, form_post.jwt)을 처리하는 Enhancer 를 생성한다.
+//
+// 앞선 Enhancer 들이 프래그먼트에 추가한 응답 파라미터를 인자로 받은 함수로 JWT로 인코딩하고,
+// 프래그먼트를 비운 뒤 전달 방식에 따라 response 파라미터 하나로 쿼리 파라미터나 프래그먼트에 추가한다.
+// 에러 응답도 같은 방식으로 인코딩 되므로 인가 코드, 토큰, 에러, 인가 서버 식별자 Enhancer 뒤에 연결해야 한다.
+//
+// [JARM]: https://openid.net/specs/oauth-v2-jarm.html#section-2.3
+func EnhanceJWTResponse(encode EncodeResponseJWT) Enhancer {
+	return func(request *authorization.Request, src any, redirect *url.URL) error {
+		mode := request.ResponseMode.Resolve(request.ResponseType)
+		if !mode.JWT() {
+			return nil
+		}
+
+		params, _ := url.ParseQuery(redirect.EscapedFragment())
+		response, err := encode(request.Client, params, time.Now())
+		if err != nil {
+			return err
+		}
+
+		redirect.Fragment, redirect.RawFragment = "", ""
+		if mode.Query() {
+			q := redirect.Query()
+			q.Set("response", response)
+			redirect.RawQuery = q.Encode()
+		} else {
+			setFragment(redirect, url.Values{"response": {response}})
+		}
+		return nil
+	}
+}
+
+// setResponseParams 응답 파라미터를 인가 요청의 전달 방식에 따라 리다이렉트 URL에 추가한다.
+// query 방식은 쿼리 파라미터에, 그 외의 방식(fragment, form_post 및 JWT 방식)은 프래그먼트에 추가한다.
+//
+// 리다이렉트 URI는 프래그먼트를 가질 수 없으므로 프래그먼트에는 응답 파라미터만 담기며,
+// form_post 와 JWT 방식은 이를 이용해 응답 파라미터만 따로 꺼내어 사용한다.
+func setResponseParams(request *authorization.Request, redirect *url.URL, params url.Values) {
+	if request.ResponseMode.Resolve(request.ResponseType) == authorization.ResponseModeQuery {
+		q := redirect.Query()
+		for k, v := range params {
+			q[k] = v
+		}
+		redirect.RawQuery = q.Encode()
+		return
+	}
+
+	f, _ := url.ParseQuery(redirect.EscapedFragment())
+	for k, v := range params {
+		f[k] = v
+	}
+	setFragment(redirect, f)
+}
+
+// setFragment 리다이렉트 URL의 프래그먼트를 인자로 받은 파라미터로 교체한다.
+// 인코딩된 파라미터가 다시 이스케이프 되지 않도록 RawFragment 를 함께 설정한다.
+func setFragment(redirect *url.URL, params url.Values) {
+	redirect.RawFragment = params.Encode()
+	redirect.Fragment, _ = url.PathUnescape(redirect.RawFragment)
+}
+
+// respondAuthorization 응답 파라미터가 추가된 리다이렉트 URL로 인가 응답을 전달한다.
+//
+// [OAuth 2.0 Form Post Response Mode] 의 form_post 방식은 프래그먼트의 응답 파라미터를 리다이렉트 URI로 자동 제출되는 HTML 폼으로 응답하며,
+// 그 외의 방식은 인자로 받은 상태 코드로 리다이렉트 한다.
+//
+// [OAuth 2.0 Form Post Response Mode]: https://openid.net/specs/oauth-v2-form-post-response-mode-1_0.html#FormPostResponseMode
+func respondAuthorization(ctx *gin.Context, status int, request *authorization.Request, redirect *url.URL) {
+	if !request.ResponseMode.Resolve(request.ResponseType).FormPost() {
+		ctx.Redirect(status, redirect.String())
+		return
+	}
+
+	params, _ := url.ParseQuery(redirect.EscapedFragment())
+	action := *redirect
+	action.Fragment, action.RawFragment = "", ""
+	ctx.HTML(http.StatusOK, "form_post.html", gin.H{
+		"action": action.String(),
+		"params": params,
+	})
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"net/url"
@@ -43,18 +44,18 @@ func NewErrorResponse(err error) *ErrorResponse {
 	return res
 }
 
-// QueryParamTo 주어진 주소의 쿼리 파라미터로 저장된 프로퍼티들을 설정한다.
-func (m *ErrorResponse) QueryParamTo(u *url.URL) {
-	q := u.Query()
-	q.Set("code", m.Code)
-	q.Set("error_description", m.Message)
+// Values 저장된 프로퍼티들을 인가 응답 파라미터로 변환한다.
+func (m *ErrorResponse) Values() url.Values {
+	v := url.Values{}
+	v.Set("code", m.Code)
+	v.Set("error_description", m.Message)
 	if m.State != "" {
-		q.Set("state", m.State)
+		v.Set("state", m.State)
 	}
 	if m.Uri != "" {
-		q.Set("error_uri", m.Uri)
+		v.Set("error_uri", m.Uri)
 	}
-	u.RawQuery = q.Encode()
+	return v
 }
 
 // OAuth2Error OAuth2 에러 객체
@@ -137,23 +138,83 @@ func OAuth2ErrorWrappingHandler(c *gin.Context) {
 //
 // 이 함수는 Gin 컨텍스트의 마지막 에러를 확인하고 그 에러를 적절한 메시지로 변환한다.
 // 만약 마지막 에러의 타입이 OAuth2Error 이고 리다이렉트할 곳이 있다면, 에러 메시지와 관련된 프로퍼티들을
-// 쿼리 파라미터로 하여 지정된 콜백 주소로 리다이렉트한다.
+// 인가 요청의 전달 방식(response_mode)에 따라 지정된 콜백 주소로 전달한다.
+//
+// JWT 전달 방식([JARM])의 에러 응답이 필요한 경우 [NewOAuth2ErrorHandler] 를 사용해야 한다.
+//
+// [JARM]: https://openid.net/specs/oauth-v2-jarm.html
 func OAuth2ErrorHandler(c *gin.Context) {
+	NewOAuth2ErrorHandler(EnhanceError)(c)
+}
+
+// NewOAuth2ErrorHandler 인자로 받은 Enhancer 로 에러를 콜백 주소에 추가하는 OAuth2 Gin 에러 헨들링 미들웨어 함수를 생성한다.
+//
+// 리다이렉트할 곳이 있는 OAuth2Error 는 Enhancer 로 에러를 콜백 주소에 추가한 뒤 전달 방식에 따라 리다이렉트 하거나
+// 자동 제출되는 HTML 폼으로 응답한다. Enhancer 처리 중 에러가 발생한 경우 리다이렉트 하지 않고 서버 에러로 응답한다.
+func NewOAuth2ErrorHandler(enhancer Enhancer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if needErrorWrite(c) {
+			err := c.Errors.Last()
+			response := NewErrorResponse(err)
+
+			var oauth2Error *OAuth2Error
+			if errors.As(err, &oauth2Error) && oauth2Error.Redirect != nil {
+				request := oauth2Error.AuthorizationRequest
+				if request == nil {
+					request = &authorization.Request{}
+				}
+				redirect := *oauth2Error.Redirect
+				if enhanceErr := enhancer(request, response, &redirect); enhanceErr != nil {
+					c.JSON(http.StatusInternalServerError, NewErrorResponse(enhanceErr))
+					return
+				}
+				respondAuthorization(c, http.StatusFound, request, &redirect)
+				return
+			}
+			c.JSON(http.StatusBadRequest, response)
+		}
+	}
+}
+
+// BearerErrorHandler [RFC 6750] 에 정의된 방식으로 보호된 자원 접근 에러를 응답하는 Gin 에러 핸들링 미들웨어 함수
+// DPoP 증명 관련 에러는 [RFC 9449] 에 정의된 DPoP 인증 스킴으로 응답한다.
+//
+// 에러에 따라 아래의 상태 코드와 WWW-Authenticate 응답 헤더를 설정한다.
+//   - 엑세스 토큰이 없는 경우(ErrUnauthorized): 401, 에러 코드 없이 인증 스킴만 응답
+//   - 유효하지 않은 엑세스 토큰(ErrInvalidToken): 401, invalid_token
+//   - 스코프 부족(ErrInsufficientScope): 403, insufficient_scope
+//   - 자원 소유자 인증 수준 부족(ErrInsufficientUserAuthentication): 401, insufficient_user_authentication
+//   - 잘못된 요청(ErrInvalidRequest): 400, invalid_request
+//   - 유효하지 않은 DPoP 증명(ErrInvalidDPoPProof): 401, invalid_dpop_proof, DPoP 인증 스킴
+//   - 서버 nonce 필요(ErrUseDPoPNonce): 401, use_dpop_nonce, DPoP 인증 스킴
+//
+// [RFC 6750]: https://datatracker.ietf.org/doc/html/rfc6750#section-3
+// [RFC 9449]: https://datatracker.ietf.org/doc/html/rfc9449#section-7.1
+func BearerErrorHandler(c *gin.Context) {
 	c.Next()
 	if needErrorWrite(c) {
 		err := c.Errors.Last()
 		response := NewErrorResponse(err)
 
-		var oauth2Error *OAuth2Error
-		if errors.As(err, &oauth2Error) {
-			if oauth2Error.Redirect != nil {
-				redirect := oauth2Error.Redirect
-				response.QueryParamTo(redirect)
-				c.Redirect(http.StatusFound, redirect.String())
-				return
-			}
+		status, scheme := http.StatusBadRequest, "Bearer"
+		switch {
+		case errors.Is(err, oautherr.ErrUnauthorized):
+			c.Header("WWW-Authenticate", "Bearer")
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		case errors.Is(err, oautherr.ErrInvalidToken), errors.Is(err, oautherr.ErrInsufficientUserAuthentication):
+			status = http.StatusUnauthorized
+		case errors.Is(err, oautherr.ErrInsufficientScope):
+			status = http.StatusForbidden
+		case errors.Is(err, oautherr.ErrInvalidDPoPProof), errors.Is(err, oautherr.ErrUseDPoPNonce):
+			status, scheme = http.StatusUnauthorized, "DPoP"
+		case response.Code == oautherr.ErrCodeServerError:
+			c.JSON(http.StatusInternalServerError, response)
+			return
 		}
-		c.JSON(http.StatusBadRequest, response)
+		c.Header("WWW-Authenticate", fmt.Sprintf(`%s error="%s", error_description="%s"`, scheme, response.Code, response.Message))
+		c.JSON(status, response)
 	}
 }
 
@@ -12,6 +12,7 @@ import (
 	"oauth-server-go/internal/config/log"
 	"oauth-server-go/internal/oauth/authorization"
 	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/detail"
 	oautherr "oauth-server-go/internal/oauth/errors"
 	"oauth-server-go/internal/oauth/scope"
 	"oauth-server-go/internal/oauth/server/pkg/security"
@@ -19,6 +20,8 @@ import (
 	"oauth-server-go/internal/oauth/token"
 	"oauth-server-go/internal/pkg/web"
 	"oauth-server-go/pkg/array"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -28,6 +31,12 @@ import (
 // 저장된 세션은 실제로 토큰을 발행 할 때 사용되며 발급이 완료되면 세션에서 삭제한다.
 const sessionKeyOriginAuthRequest = "sessions/originAuthRequest"
 
+// sessionKeyPendingAuthRequest 자원 소유자의 로그인을 기다리는 인가 요청을 세션에 저장할 때 사용하는 키
+//
+// 인가 요청을 처리하기 위해 자원 소유자가 다시 로그인 해야 하는 경우 세션에 저장되었다가
+// 로그인이 완료된 후 인가 요청을 이어서 처리할 때 사용되며 처리가 시작되면 세션에서 삭제한다.
+const sessionKeyPendingAuthRequest = "sessions/pendingAuthRequest"
+
 type (
 	// GenerateAuthorizationCode 새 인가 코드를 생성한다.
 	GenerateAuthorizationCode func(ctx context.Context, c *client.Client, r *authorization.Request) (*authorization.Code, error)
@@ -41,10 +50,19 @@ type (
 	// TokenIssue 새 토큰을 발행하는 함수
 	TokenIssue func(ctx context.Context, c *client.Client, r *token.Request) (*token.AccessToken, *token.RefreshToken, error)
 
+	// FetchRequestObject 요청 URI에서 인가 요청 객체를 조회한다.
+	FetchRequestObject func(ctx context.Context, uri string) (string, error)
+
 	// TokenInspect 토큰의 상세 정보를 조회한다.
 	TokenInspect func(ctx context.Context, c *client.Client, r *token.InspectionRequest) (*token.Inspection, bool, error)
 )
 
+// responseTypeGrantTypes 인가 요청의 응답 방식에 대응하는 인가 방식
+var responseTypeGrantTypes = map[authorization.ResponseType]token.GrantType{
+	authorization.ResponseTypeCode:  token.GrantTypeAuthorizationCode,
+	authorization.ResponseTypeToken: token.GrantTypeImplicit,
+}
+
 // AuthorizationApproveHandler 인가 요청 처리에 대한 핸들러 함수를 제공하는 구조체
 type AuthorizationApproveHandler struct {
 	GenerateAuthorizationCode GenerateAuthorizationCode
@@ -56,27 +74,56 @@ type Handler struct {
 	TokenIssuer  *service.TokenIssuer
 	TokenService *service.TokenService
 
-	ClientService   *service.ClientService
-	AuthCodeService *service.AuthCodeService
-	ScopeService    *service.ScopeService
+	ClientService        *service.ClientService
+	AuthCodeService      *service.AuthCodeService
+	ScopeService         *service.ScopeService
+	PushedRequestService *service.PushedRequestService
+	DPoPService          *service.DPoPService
+
+	ResourceServerService *service.ResourceServerService
+	ConsentService        *service.ConsentService
+
+	AuthorizationDetailService *service.AuthorizationDetailService
+
+	RequestObjectVerifier *authorization.RequestObjectVerifier
+	FetchRequestObject    FetchRequestObject
 
 	ImplicitGranter *token.ImplicitGranter
+
+	IntrospectionEncoder *token.IntrospectionEncoder
+
+	// EncodeResponseJWT JWT 전달 방식(response_mode=jwt 등)의 인가 응답을 인코딩하는 함수
+	EncodeResponseJWT EncodeResponseJWT
+
+	// Issuer 인가 응답의 iss 파라미터로 전달할 인가 서버 식별자 [RFC 9207]
+	//
+	// [RFC 9207]: https://datatracker.ietf.org/doc/html/rfc9207
+	Issuer string
+
+	// LoginPage 자원 소유자가 다시 로그인 해야 할 때 리다이렉트 할 로그인 페이지 경로
+	LoginPage string
+
+	// ResumeAuthorizePath 로그인 완료 후 돌아와 인가 요청을 이어서 처리할 [Handler.ResumeAuthorize] 경로
+	ResumeAuthorizePath string
 }
 
 // Authorize OAuth2 인가 코드 부여와 암시적 승인 부여의 인가 단계를 구현한 헨들러
 // 인증에 사용되었던 요청 전문은 세션으로 저장되었다가 실제 토큰을 발행 할 때 유효성 검증으로 사용된다.
 //
 // 요청 정보를 검증하고 세션에 저장한 뒤 사용자에게 인가 승인 페이지를 반환한다.
+// 자원 소유자가 로그인하지 않았거나 prompt, max_age, login_hint 에 따라 다시 로그인 해야 하는 경우 로그인 페이지로 리다이렉트 하며,
+// prompt=none 인 경우 페이지를 보여주는 대신 에러를 리다이렉트 URL로 전송한다. 자세한 사항은 [Handler.authorize] 를 확인.
 // 사용자는 인가 승인 페이지에서 승인/거부를 할 수 있고 승인시 요청하였던 응답 방식(response_type)에 따라 인가 코드나 토큰을 발행한다.
 // 발생한 에러는 JSON 형태로 응답 되다가, 리다이렉트 URL 검증이 완료된 이후 부터는 검증된 리다이렉트 URL로 에러 정보를 전송한다.
 // 자세한 흐름은 [Authorization Code Grant] 와 [Implicit Grant] 를 확인.
 //
+// request_uri 나 request 가 입력된 경우 나머지 요청 파라미터 대신 푸시된 인가 요청이나 요청 객체를 사용한다.
+// 자세한 규칙은 [Handler.resolveAuthRequest] 를 확인.
+//
 // Parameters(application/form-data): authorization.Request
 //
 // Returns: 인가 승인 페이지
 //
-//	Note: 이 페이지는 사용자의 로그인이 완료 된 후 접근 해야 한다.
-//
 // [Authorization Code Grant]: https://datatracker.ietf.org/doc/html/rfc6749#section-4.1
 // [Implicit Grant]: https://datatracker.ietf.org/doc/html/rfc6749#section-4.2
 func (h *Handler) Authorize(ctx *gin.Context) error {
@@ -95,55 +142,159 @@ func (h *Handler) Authorize(ctx *gin.Context) error {
 		return NewOAuth2Error(oautherr.ErrInvalidClient, "invalid client")
 	}
 
-	redirect, err := clt.ValidateRedirectURI(request.Redirect)
+	resolved, err := h.resolveAuthRequest(requestContext, clt, &request)
 	if err != nil {
-		return NewOAuth2Error(oautherr.ErrInvalidRequest, "invalid redirect_uri")
+		log.Sugared().Infof("authorization request could not be resolved: %v", err)
+		return NewOAuth2Error(err, "invalid authorization request")
 	}
+	request = *resolved
+
 	// callback 변수 할당 이후 부터 발생한 에러는 이 주소로 리다이렉팅 된다.
-	callback, _ := url.Parse(redirect)
+	callback, oauth2Err := validateAuthRequest(clt, &request)
+	if oauth2Err != nil {
+		return oauth2Err
+	}
+	if err = h.ResourceServerService.Validate(requestContext, request.Resource); err != nil {
+		return WrapAuthRequest(err, "invalid resource", &request, callback)
+	}
+	details, err := h.AuthorizationDetailService.Validate(requestContext, request.AuthorizationDetails)
+	if err != nil {
+		return WrapAuthRequest(err, "invalid authorization_details", &request, callback)
+	}
 
-	if request.ResponseType == "" {
-		return WrapAuthRequest(oautherr.ErrInvalidRequest, "response_type is required", &request, callback)
+	return h.authorize(ctx, clt, &request, callback, details)
+}
+
+// ResumeAuthorize 다시 로그인을 요구했던 인가 요청을 자원 소유자의 로그인이 완료된 후 이어서 처리한다.
+//
+// 다시 로그인을 요구할 때 세션에 저장했던 인가 요청을 가져와 [Handler.Authorize] 와 같이 처리하며
+// 로그인 페이지는 로그인이 완료되면 이 페이지로 돌아온다.
+//
+// Returns: 인가 승인 페이지
+//
+//	Note: 이 페이지는 사용자의 로그인이 완료 된 후 접근 해야 한다.
+func (h *Handler) ResumeAuthorize(ctx *gin.Context) error {
+	session := sessions.Default(ctx)
+	request, ok, err := getAuthRequest(session, sessionKeyPendingAuthRequest)
+	if err != nil {
+		return NewOAuth2Error(err, "unknown error occurred during get pending request")
+	}
+	if !ok {
+		return NewOAuth2Error(oautherr.ErrInvalidRequest, "pending authorize request is not found")
+	}
+	if err = clearAuthRequest(session, sessionKeyPendingAuthRequest); err != nil {
+		return NewOAuth2Error(err, "unknown error occurred during clear pending request")
 	}
 
-	if request.ResponseType != authorization.ResponseTypeCode &&
-		request.ResponseType != authorization.ResponseTypeToken {
-		return WrapAuthRequest(oautherr.ErrInvalidRequest, "undefined response_type", &request, callback)
+	requestContext := ctx.Request.Context()
+	clt, ok := h.ClientService.Retrieve(requestContext, request.Client)
+	if !ok {
+		return NewOAuth2Error(oautherr.ErrInvalidClient, "invalid client")
+	}
+	callback, oauth2Err := validateAuthRequest(clt, request)
+	if oauth2Err != nil {
+		return oauth2Err
+	}
+	details, err := h.AuthorizationDetailService.Validate(requestContext, request.AuthorizationDetails)
+	if err != nil {
+		return WrapAuthRequest(err, "invalid authorization_details", request, callback)
 	}
+	return h.authorize(ctx, clt, request, callback, details)
+}
+
+// authorize 검증이 완료된 인가 요청을 처리할 자원 소유자의 로그인 정보를 확인하고 인가 승인 페이지를 반환한다.
+//
+// 자원 소유자가 다시 로그인 해야 하는 경우 인가 요청을 세션에 저장하고 로그인 페이지로 리다이렉트 하며,
+// 로그인이 완료되면 [Handler.ResumeAuthorize] 에서 이어서 처리한다. 자세한 조건은 [authorization.Request.Authenticate] 를 확인.
+//
+// 자원 소유자가 요청한 스코프에 모두 동의한 적이 있는 경우 인가 승인 페이지 없이 바로 인가 코드나 토큰을 전달하며,
+// 일부만 동의한 경우 새로 요청한 스코프만 인가 승인 페이지에 보여준다. prompt=consent 나 인가 상세 정보가 요청된 경우 항상 인가 승인 페이지를 보여준다.
+//
+// prompt=none 으로 요청한 경우 로그인 페이지나 인가 승인 페이지를 보여주지 않고 login_required, interaction_required 에러를 전달하며,
+// 동의하지 않은 스코프가 있는 경우 consent_required 에러를 전달한다.
+func (h *Handler) authorize(ctx *gin.Context, clt *client.Client, request *authorization.Request, callback *url.URL, details detail.Details) error {
+	requestContext := ctx.Request.Context()
+	session := sessions.Default(ctx)
 
-	requestScopes := scope.Split(request.Scopes)
-	if !array.ContainsAll(clt.Scopes(), requestScopes) {
-		return WrapAuthRequest(oautherr.ErrInvalidScope, "invalid scope", &request, callback)
+	authentication, ok := web.RetrieveAuthentication(ctx)
+	if !ok {
+		authentication = &web.Authentication{}
 	}
+	if err := request.Authenticate(authentication.Username, authentication.ACR, authentication.AuthTime, time.Now()); err != nil {
+		if request.PromptNone() {
+			return WrapAuthRequest(err, "resource owner interaction is required", request, callback)
+		}
+		if errors.Is(err, oautherr.ErrUnmetAuthenticationRequirements) {
+			return WrapAuthRequest(err, "requested authentication context class is not satisfied", request, callback)
+		}
 
-	scopes := h.ScopeService.Retrieve(requestContext, requestScopes...)
+		request.LoginRequestedAt = time.Now()
+		if err = storeAuthRequest(session, sessionKeyPendingAuthRequest, request); err != nil {
+			return WrapAuthRequest(err, "error occurred during store pending request", request, callback)
+		}
+		query := url.Values{"return_to": {h.ResumeAuthorizePath}}
+		if request.LoginHint != "" {
+			query.Set("login_hint", request.LoginHint)
+		}
+		ctx.Redirect(http.StatusFound, h.LoginPage+"?"+query.Encode())
+		return nil
+	}
 
-	authentication, _ := web.RetrieveAuthentication(ctx)
 	request.Username = authentication.Username
+	request.AuthTime = authentication.AuthTime
+	request.ACR = authentication.ACR
+	request.AMR = authentication.AMR
+
+	requested := scope.Split(request.Scopes)
+	var consented []string
+	if !slices.Contains(request.Prompts(), authorization.PromptConsent) {
+		consented = h.ConsentService.Consented(requestContext, request.Username, clt, requested)
+	}
+	if len(requested) > 0 && len(consented) == len(requested) && len(details) == 0 {
+		response, err := h.issueAuthorization(ctx, clt, request, nil)
+		if err != nil {
+			return WrapAuthRequest(err, "error occurred during issue authorization", request, callback)
+		}
+		respondAuthorization(ctx, http.StatusFound, request, response)
+		return nil
+	}
+	if request.PromptNone() {
+		return WrapAuthRequest(oautherr.ErrConsentRequired, "resource owner consent is required", request, callback)
+	}
+	request.ConsentedScopes = scope.Join(consented)
 
+	pending := array.FilterFunc(requested, func(s string) bool {
+		return !slices.Contains(consented, s)
+	})
 	ctx.HTML(http.StatusOK, "approval.html", gin.H{
-		"scopes": scopes,
-		"c":      clt.Name(),
+		"scopes":               h.ScopeService.Retrieve(requestContext, pending...),
+		"c":                    clt.Name(),
+		"authorizationDetails": newAuthorizationDetailViews(h.AuthorizationDetailService.Retriever(requestContext), details),
 	})
 
-	session := sessions.Default(ctx)
-	if err = storeOriginRequest(session, &request); err != nil {
-		return WrapAuthRequest(err, "error occurred during store origin request", &request, callback)
+	if err := storeAuthRequest(session, sessionKeyOriginAuthRequest, request); err != nil {
+		return WrapAuthRequest(err, "error occurred during store origin request", request, callback)
 	} else {
 		return nil
 	}
 }
 
 // Approve 리소스 소유자가 인가를 승인하여 인가 코드나 토큰을 생성하고 인가 요청에 사용하였던 리다이렉트 URL로 생성된 코드나 토큰을 전송한다.
-// 스코프의 경우 기존에 요청했던 스코프 중 자원 소유자가 승인한 스코프만 부여한다.
+// 스코프와 인가 상세 정보의 경우 기존에 요청했던 것 중 자원 소유자가 승인한 것만 부여하며 승인 페이지에서 묻지 않은 스코프는 무시한다.
+// 인가 승인 페이지에서 묻지 않은 이미 동의한 스코프는 승인한 스코프와 함께 부여하며,
+// 승인한 스코프는 코드나 토큰이 정상적으로 생성된 후 동의 저장소에 저장한다.
+// 코드나 토큰은 인가 요청의 전달 방식(response_mode)에 따라 쿼리 파라미터, 프래그먼트, 자동 제출되는 HTML 폼이나 서명된 JWT로 전달한다.
 //
 // Parameters(application/form-data):
-//   - scope: 사용자가 승인 한 스코프 만약 이 값이 비어 있을 경우 사용자가 승인을 하지 않은 것으로 간주하여 클라이언트에 승인 거부 메시지를 전달한다.
+//   - scope: 사용자가 승인 한 스코프
+//   - authorization_detail: 사용자가 승인 한 인가 상세 정보의 인덱스
+//
+// 승인한 스코프와 인가 상세 정보가 모두 비어 있을 경우 사용자가 승인을 하지 않은 것으로 간주하여 클라이언트에 승인 거부 메시지를 전달한다.
 //
 // Note: 이 페이지는 사용자의 로그인이 완료 된 후 접근 해야 한다.
 func (h *Handler) Approve(ctx *gin.Context) error {
 	session := sessions.Default(ctx)
-	request, ok, err := getOriginRequest(session)
+	request, ok, err := getAuthRequest(session, sessionKeyOriginAuthRequest)
 	if err != nil {
 		return NewOAuth2Error(err, "unknown error occurred during get origin request")
 	}
@@ -161,13 +312,45 @@ func (h *Handler) Approve(ctx *gin.Context) error {
 		return WrapAuthRequest(oautherr.ErrUnauthorized, "user is not allowed to approve the request", request, callback)
 	}
 
-	approvedScopes := ctx.PostFormArray("scope")
-	if len(approvedScopes) == 0 {
+	consentedScopes := scope.Split(request.ConsentedScopes)
+	postedScopes := ctx.PostFormArray("scope")
+	approvedScopes := array.FilterFunc(scope.Split(request.Scopes), func(s string) bool {
+		return !slices.Contains(consentedScopes, s) && slices.Contains(postedScopes, s)
+	})
+	approvedDetails, err := selectAuthorizationDetails(request.AuthorizationDetails, ctx.PostFormArray("authorization_detail"))
+	if err != nil {
+		return WrapAuthRequest(err, "invalid authorization_details", request, callback)
+	}
+	if len(approvedScopes) == 0 && len(approvedDetails) == 0 {
 		return WrapAuthRequest(oautherr.ErrInvalidScope, "resource owner denied access", request, callback)
 	}
-	request.Scopes = scope.Join(approvedScopes)
+	request.Scopes = scope.Join(append(consentedScopes, approvedScopes...))
+	request.AuthorizationDetails = approvedDetails.String()
+
+	response, err := h.issueAuthorization(ctx, clt, request, approvedDetails)
+	if err != nil {
+		return WrapAuthRequest(err, "error occurred during enhance request", request, callback)
+	}
+	if err = h.ConsentService.Grant(requestContext, request.Username, clt, approvedScopes); err != nil {
+		log.Sugared().Errorf("error occurred during save consent(%s, %s): %v", request.Username, clt.Id(), err)
+	}
+
+	if err = clearAuthRequest(session, sessionKeyOriginAuthRequest); err != nil {
+		return WrapAuthRequest(err, "error occurred during clear origin request", request, callback)
+	} else {
+		respondAuthorization(ctx, http.StatusMovedPermanently, request, response)
+		return nil
+	}
+}
+
+// issueAuthorization 자원 소유자가 승인한 인가 요청의 응답 방식(response_type)에 따라 인가 코드나 토큰을 생성하고
+// 인가 응답 파라미터를 추가한 리다이렉트 URL을 반환한다.
+func (h *Handler) issueAuthorization(ctx *gin.Context, clt *client.Client, request *authorization.Request, approvedDetails detail.Details) (*url.URL, error) {
+	requestContext := ctx.Request.Context()
+	callback, _ := url.Parse(request.Redirect)
 
 	var src any = nil
+	var err error
 	switch request.ResponseType {
 	case authorization.ResponseTypeCode:
 		src, err = h.AuthCodeService.NewCode(requestContext, clt, request)
@@ -177,33 +360,118 @@ func (h *Handler) Approve(ctx *gin.Context) error {
 			Username: request.Username,
 			Scope:    request.Scopes,
 		}
-		src, err = h.ImplicitGranter.GenerateToken(clt, tokenRequest)
+		var accessToken *token.AccessToken
+		if accessToken, err = h.ImplicitGranter.GenerateToken(clt, tokenRequest); err == nil {
+			err = accessToken.RestrictResources(h.ResourceServerService.Retriever(requestContext), request.Resource, nil)
+		}
+		if err == nil {
+			accessToken.SetAuthorizationDetails(approvedDetails)
+			accessToken.ApplyAuthentication(request.AuthTime, request.ACR, request.AMR)
+			err = h.TokenIssuer.Encode(clt, accessToken)
+		}
+		src = accessToken
 	default:
 		err = fmt.Errorf("%w: invalid response type: %s", oautherr.ErrInvalidRequest, request.ResponseType)
 	}
+	if err != nil {
+		return nil, err
+	}
 
-	enhancer := ChainEnhancer(EnhanceAuthorizationCode, EnhanceImplicit)
-	if err = enhancer(request, src, callback); err != nil {
-		return WrapAuthRequest(err, "error occurred during enhance request", request, callback)
+	response := *callback
+	enhancer := ChainEnhancer(EnhanceAuthorizationCode, EnhanceImplicit, EnhanceIssuer(h.Issuer), EnhanceJWTResponse(h.EncodeResponseJWT))
+	if err = enhancer(request, src, &response); err != nil {
+		return nil, err
 	}
+	return &response, nil
+}
 
-	if err = clearOriginRequest(session); err != nil {
-		return WrapAuthRequest(err, "error occurred during clear origin request", request, callback)
-	} else {
-		ctx.Redirect(http.StatusMovedPermanently, callback.String())
-		return nil
+// PushAuthorization [RFC 9126] 인가 요청을 미리 검증하고 저장하여 인가 엔드포인트에서 사용할 요청 URI를 발급한다.
+// 인증된 클라이언트의 인가 요청만 받으며 에러는 리다이렉트 없이 JSON 형태로 응답한다.
+//
+// 인가 요청 파라미터 대신 요청 객체(request)를 받을 수 있으며, 이 경우 요청 객체를 검증하고 클레임으로 만든 인가 요청을 저장한다.
+//
+// Parameters(application/form-data): authorization.Request
+//
+// Returns: [PushedAuthorizationResponse]
+//
+// [RFC 9126]: https://datatracker.ietf.org/doc/html/rfc9126#section-2
+func (h *Handler) PushAuthorization(ctx *gin.Context) error {
+	var request authorization.Request
+	if err := ctx.ShouldBind(&request); err != nil {
+		return NewOAuth2Error(oautherr.ErrInvalidRequest, "invalid request")
+	}
+
+	clt, exists := security.RetrieveClientAuthentication(ctx)
+	if !exists {
+		return NewOAuth2Error(oautherr.ErrInvalidClient, "invalid client")
+	}
+
+	if request.RequestURI != "" {
+		return NewOAuth2Error(oautherr.ErrInvalidRequest, "request_uri is not allowed")
+	}
+	if request.Client != "" && request.Client != clt.Id() {
+		return NewOAuth2Error(oautherr.ErrInvalidRequest, "client_id is not matched")
+	}
+	request.Client = clt.Id()
+
+	if request.RequestObject != "" {
+		resolved, err := h.RequestObjectVerifier.Verify(ctx.Request.Context(), clt, request.RequestObject)
+		if err != nil {
+			log.Sugared().Infof("request object could not be verified: %v", err)
+			return NewOAuth2Error(err, "invalid request object")
+		}
+		request = *resolved
+	}
+	request.Username = ""
+
+	if _, oauth2Err := validateAuthRequest(clt, &request); oauth2Err != nil {
+		oauth2Err.Redirect = nil
+		return oauth2Err
 	}
+	if err := h.ResourceServerService.Validate(ctx.Request.Context(), request.Resource); err != nil {
+		return NewOAuth2Error(err, "invalid resource")
+	}
+	if _, err := h.AuthorizationDetailService.Validate(ctx.Request.Context(), request.AuthorizationDetails); err != nil {
+		return NewOAuth2Error(err, "invalid authorization_details")
+	}
+
+	pushed, err := h.PushedRequestService.Push(ctx.Request.Context(), &request)
+	if err != nil {
+		log.Sugared().Errorf("error occurred during push authorization request: %v", err)
+		return NewOAuth2Error(err, "error occurred during push authorization request")
+	}
+
+	ctx.JSON(http.StatusCreated, PushedAuthorizationResponse{
+		RequestURI: pushed.RequestURI(),
+		ExpiresIn:  pushed.ExpiresIn(),
+	})
+	return nil
+}
+
+// PushedAuthorizationResponse [RFC 9126] 푸시된 인가 요청 응답
+//
+// [RFC 9126]: https://datatracker.ietf.org/doc/html/rfc9126#section-2.2
+type PushedAuthorizationResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  uint   `json:"expires_in"`
 }
 
 // IssueToken 새 토큰을 발행한다.
 //
 // 정해진 부여 방식에 따라 새 토큰을 발행한다. 발행 흐름에 대한 정보는 [RFC 6749] 문서를 확인
 //
+// DPoP 요청 헤더로 DPoP 증명이 전달된 경우 증명을 검증하고 발행되는 토큰을 증명의 공개키에 바인딩하며 토큰 유형(token_type)은 DPoP 가 된다.
+// 자세한 사항은 [RFC 9449] 문서를 확인
+//
+// 클라이언트가 인증서 바인딩을 사용하도록 등록된 경우 TLS 연결에서 받은 클라이언트 인증서에 엑세스 토큰을 바인딩한다. [RFC 8705] 문서를 확인
+//
 // Parameter(application/form-data): [token.Request]
 //
 // Returns: [token.Response]
 //
 // [RFC 6749]: https://datatracker.ietf.org/doc/html/rfc6749#section-5
+// [RFC 9449]: https://datatracker.ietf.org/doc/html/rfc9449#section-5
+// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-3
 func (h *Handler) IssueToken(ctx *gin.Context) error {
 	var request token.Request
 	if err := ctx.ShouldBind(&request); err != nil {
@@ -215,6 +483,27 @@ func (h *Handler) IssueToken(ctx *gin.Context) error {
 		return NewOAuth2Error(oautherr.ErrInvalidClient, "invalid client")
 	}
 
+	if chain := security.RetrieveClientCertificates(ctx); len(chain) > 0 {
+		request.CertificateThumbprint = token.CertificateThumbprint(chain[0])
+	}
+	if proofs := ctx.Request.Header.Values(security.HeaderDPoP); len(proofs) > 0 {
+		proof, err := verifyDPoPProof(ctx, h.DPoPService, proofs)
+		if err != nil {
+			return WrapTokenRequest(err, "invalid dpop proof", &request)
+		}
+		request.DPoPJKT = proof.JKT()
+	}
+
+	// 토큰 교환 방식은 발급할 토큰 타입을 토큰을 발급하기 전에 확인한다.
+	var issuedTokenType token.TokenTypeIdentifier
+	if request.Type == token.GrantTypeTokenExchange {
+		t, err := token.IssuedTokenType(clt, &request)
+		if err != nil {
+			return WrapTokenRequest(err, "unsupported requested token type", &request)
+		}
+		issuedTokenType = t
+	}
+
 	accessToken, refreshToken, err := h.TokenIssuer.Issue(ctx.Request.Context(), clt, &request)
 	if err != nil {
 		return WrapTokenRequest(err, "error occurred during generate token", &request)
@@ -222,13 +511,18 @@ func (h *Handler) IssueToken(ctx *gin.Context) error {
 
 	res := token.Response{
 		Token:     accessToken.Value(),
-		T:         token.TypeBearer,
+		T:         accessToken.Type(),
 		ExpiresIn: accessToken.ExpiresIn(),
 		Scope:     scope.Join(accessToken.Scopes()),
 	}
 	if refreshToken != nil {
 		res.Refresh = refreshToken.Value()
 	}
+	if idToken := accessToken.IDToken(); idToken != nil {
+		res.IDToken = idToken.Value()
+	}
+	res.IssuedTokenType = issuedTokenType
+	res.AuthorizationDetails = accessToken.AuthorizationDetails()
 
 	ctx.JSON(http.StatusOK, res)
 	return nil
@@ -237,9 +531,13 @@ func (h *Handler) IssueToken(ctx *gin.Context) error {
 // InspectToken 토큰의 상세 정보를 조회한다.
 // 토큰 조회에 대한 자세한 사항은 [RFC 7662] 문서를 참고
 //
+// Accept 헤더로 application/token-introspection+jwt 를 요청한 경우 [RFC 9701] 에 따라
+// 조회 결과를 서명(클라이언트 설정에 따라 암호화)된 JWT로 응답한다.
+//
 // Parameter: [token.InspectionRequest]
 //
 // [RFC 7662] https://datatracker.ietf.org/doc/html/rfc7662#section-2
+// [RFC 9701] https://datatracker.ietf.org/doc/html/rfc9701#section-4
 func (h *Handler) InspectToken(ctx *gin.Context) error {
 	var request token.InspectionRequest
 	if err := ctx.ShouldBind(&request); err != nil {
@@ -270,10 +568,50 @@ func (h *Handler) InspectToken(ctx *gin.Context) error {
 		return NewOAuth2Error(oautherr.ErrInvalidRequest, "invalid token")
 	}
 
+	if ctx.NegotiateFormat(gin.MIMEJSON, token.MediaTypeTokenIntrospectionJWT) == token.MediaTypeTokenIntrospectionJWT {
+		signed, err := h.IntrospectionEncoder.Encode(requestContext, clt, inspection)
+		if err != nil {
+			log.Sugared().Errorf("error occurred during encode token introspection response: %v", err)
+			return NewOAuth2Error(err, "error occurred during encode token introspection response")
+		}
+		ctx.Data(http.StatusOK, token.MediaTypeTokenIntrospectionJWT, []byte(signed))
+		return nil
+	}
+
 	ctx.JSON(http.StatusOK, inspection)
 	return nil
 }
 
+// RevokeToken 토큰을 폐기한다.
+// 토큰 폐기에 대한 자세한 사항은 [RFC 7009] 문서를 참고
+//
+// Parameter: [token.RevocationRequest]
+//
+// [RFC 7009] https://datatracker.ietf.org/doc/html/rfc7009#section-2
+func (h *Handler) RevokeToken(ctx *gin.Context) error {
+	var request token.RevocationRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		return NewOAuth2Error(oautherr.ErrInvalidRequest, "invalid request")
+	}
+
+	if request.Token == "" {
+		return NewOAuth2Error(oautherr.ErrInvalidRequest, "token is required")
+	}
+
+	clt, exists := security.RetrieveClientAuthentication(ctx)
+	if !exists {
+		return NewOAuth2Error(oautherr.ErrInvalidClient, "invalid client")
+	}
+
+	if err := h.TokenService.Revoke(ctx.Request.Context(), clt, &request); err != nil {
+		log.Sugared().Errorf("error occurred during token revocation: %v", err)
+		return NewOAuth2Error(err, "error occurred during token revocation")
+	}
+
+	ctx.Status(http.StatusOK)
+	return nil
+}
+
 // TokenView API을 이용한 토큰 조회시 반환할 엑세스 토큰 구조체
 type TokenView struct {
 	Value      string    `json:"value"`
@@ -295,10 +633,47 @@ func NewTokenView(t *token.AccessToken) TokenView {
 	}
 }
 
+// ConnectedAppView API을 이용한 연결된 앱 조회시 반환할 구조체
+type ConnectedAppView struct {
+	ClientID      string     `json:"clientId"`
+	ClientName    string     `json:"clientName"`
+	Active        bool       `json:"active"`
+	Scopes        []string   `json:"scopes"`
+	Consented     bool       `json:"consented"`
+	AccessTokens  int        `json:"accessTokens"`
+	RefreshTokens int        `json:"refreshTokens"`
+	AuthorizedAt  time.Time  `json:"authorizedAt"`
+	LastIssuedAt  *time.Time `json:"lastIssuedAt,omitempty"`
+}
+
+func NewConnectedAppView(app *token.ConnectedApp) ConnectedAppView {
+	scopes := app.Scopes()
+	if scopes == nil {
+		scopes = make([]string, 0)
+	}
+	view := ConnectedAppView{
+		ClientID:      app.Client().Id(),
+		ClientName:    app.Client().Name(),
+		Active:        app.Active(),
+		Scopes:        scopes,
+		Consented:     app.Consent() != nil && app.Consent().Available(),
+		AccessTokens:  len(app.AccessTokens()),
+		RefreshTokens: len(app.RefreshTokens()),
+		AuthorizedAt:  app.AuthorizedAt(),
+	}
+	if lastIssuedAt := app.LastIssuedAt(); !lastIssuedAt.IsZero() {
+		view.LastIssuedAt = &lastIssuedAt
+	}
+	return view
+}
+
 // ManagementHandler 액세스 토큰을 관리하는 핸들러 구조체
 // 현재까지 발급된 자신의 토큰을 조회하거나 삭제하는등의 핸들링 함수가 포함된다.
 type ManagementHandler struct {
 	TokenService *service.TokenService
+
+	// ConnectedAppService 자원 소유자가 인가한 클라이언트(앱) 별로 권한을 조회하고 취소하는 서비스
+	ConnectedAppService *service.ConnectedAppService
 }
 
 func (h *ManagementHandler) TokenManagement(ctx *gin.Context) error {
@@ -338,25 +713,151 @@ func (h *ManagementHandler) DeleteToken(ctx *gin.Context) error {
 	return nil
 }
 
-// storeOriginRequest 인가 요청 전문을 세션에 저장한다.
-func storeOriginRequest(s sessions.Session, request *authorization.Request) error {
+// ConnectedApps 자원 소유자가 인가한 클라이언트(앱) 목록을 조회한다.
+// Accept 헤더가 application/json 인 경우 JSON으로 응답하며 그 외에는 연결된 앱 관리 페이지를 응답한다.
+func (h *ManagementHandler) ConnectedApps(ctx *gin.Context) error {
+	switch ctx.GetHeader("Accept") {
+	case "application/json":
+		authentication, _ := web.RetrieveAuthentication(ctx)
+
+		apps := h.ConnectedAppService.GetConnectedApps(ctx.Request.Context(), authentication.Username)
+
+		view := make([]ConnectedAppView, 0, len(apps))
+		for _, app := range apps {
+			view = append(view, NewConnectedAppView(app))
+		}
+		ctx.JSON(http.StatusOK, web.NewSuccess(view))
+	default:
+		ctx.HTML(http.StatusOK, "manage-apps.html", gin.H{})
+	}
+	return nil
+}
+
+// RevokeApp 자원 소유자가 클라이언트(앱)에게 부여한 동의와 발급된 모든 토큰을 삭제한다.
+func (h *ManagementHandler) RevokeApp(ctx *gin.Context) error {
+	clientID := ctx.Param("clientID")
+	authentication, _ := web.RetrieveAuthentication(ctx)
+
+	if err := h.ConnectedAppService.Revoke(ctx.Request.Context(), authentication, clientID); err != nil {
+		if errors.Is(err, oautherr.ErrInvalidRequest) {
+			return web.Wrap(err, web.ErrCodeBadRequest, "찾을 수 없는 앱")
+		} else {
+			return web.Wrap(err, web.ErrCodeUnknown, "알 수 없는 에러")
+		}
+	}
+
+	ctx.JSON(http.StatusOK, web.NewSuccess("ok"))
+	return nil
+}
+
+// resolveAuthRequest 인가 요청에 요청 URI(request_uri)나 요청 객체(request)가 있는 경우 이를 실제 인가 요청으로 변환한다.
+//
+// 다음과 같은 규칙을 가지고 동작한다.
+//
+//	1.request_uri 와 request 는 함께 사용 할 수 없다.
+//	2.request_uri 가 푸시된 인가 요청 URI인 경우 저장된 인가 요청을 반환한다.
+//	3.푸시된 인가 요청이 강제된 클라이언트는 푸시된 인가 요청 URI 없이 인가를 요청 할 수 없다.
+//	4.그 외의 request_uri 는 클라이언트에 미리 등록된 요청 URI인 경우에만 해당 주소에서 요청 객체를 조회하여 request 와 같이 처리한다.
+//	5.요청 객체는 검증 후 요청 객체의 클레임으로 만든 인가 요청을 반환한다.
+//	6.둘 다 없는 경우 입력 받은 인가 요청을 그대로 반환한다.
+func (h *Handler) resolveAuthRequest(ctx context.Context, clt *client.Client, request *authorization.Request) (*authorization.Request, error) {
+	if request.RequestURI != "" && request.RequestObject != "" {
+		return nil, fmt.Errorf("%w: request and request_uri must not both be present", oautherr.ErrInvalidRequest)
+	}
+
+	if strings.HasPrefix(request.RequestURI, authorization.RequestURIPrefix) {
+		return h.PushedRequestService.Consume(ctx, clt, request.RequestURI)
+	}
+	if clt.RequirePushedAuthorizationRequests() {
+		return nil, fmt.Errorf("%w: pushed authorization request is required", oautherr.ErrInvalidRequest)
+	}
+
+	raw := request.RequestObject
+	if request.RequestURI != "" {
+		if !clt.AllowRequestURI(request.RequestURI) {
+			return nil, fmt.Errorf("%w: request_uri is not registered", oautherr.ErrInvalidRequestURI)
+		}
+		fetched, err := h.FetchRequestObject(ctx, request.RequestURI)
+		if err != nil {
+			return nil, err
+		}
+		raw = fetched
+	}
+	if raw == "" {
+		return request, nil
+	}
+	return h.RequestObjectVerifier.Verify(ctx, clt, raw)
+}
+
+// verifyDPoPProof 요청 헤더로 전달된 DPoP 증명을 검증한다.
+// 서버 nonce 를 사용하는 경우 클라이언트가 다음 증명에 사용할 nonce 를 DPoP-Nonce 응답 헤더로 전달한다.
+func verifyDPoPProof(ctx *gin.Context, srv *service.DPoPService, proofs []string) (*token.DPoPProof, error) {
+	if srv == nil {
+		return nil, fmt.Errorf("%w: dpop is not supported", oautherr.ErrInvalidDPoPProof)
+	}
+	if nonce := srv.Nonce(); nonce != "" {
+		ctx.Header(security.HeaderDPoPNonce, nonce)
+	}
+	if len(proofs) != 1 {
+		return nil, fmt.Errorf("%w: exactly one dpop proof is required", oautherr.ErrInvalidDPoPProof)
+	}
+	return srv.Verify(ctx.Request.Context(), proofs[0], ctx.Request.Method, ctx.Request.URL.Path)
+}
+
+// validateAuthRequest 클라이언트의 인가 요청을 검증하고 검증된 리다이렉트 URL을 반환한다.
+// 리다이렉트 URL 검증이 완료된 이후 발생한 에러에는 검증된 리다이렉트 URL이 설정된다.
+func validateAuthRequest(clt *client.Client, request *authorization.Request) (*url.URL, *OAuth2Error) {
+	redirect, err := clt.ValidateRedirectURI(request.Redirect)
+	if err != nil {
+		return nil, NewOAuth2Error(oautherr.ErrInvalidRequest, "invalid redirect_uri")
+	}
+	callback, _ := url.Parse(redirect)
+
+	if request.ResponseType == "" {
+		return nil, WrapAuthRequest(oautherr.ErrInvalidRequest, "response_type is required", request, callback)
+	}
+
+	if !slices.Contains(authorization.ResponseTypes, request.ResponseType) {
+		return nil, WrapAuthRequest(oautherr.ErrInvalidRequest, "undefined response_type", request, callback)
+	}
+
+	if !clt.AllowGrantType(string(responseTypeGrantTypes[request.ResponseType])) {
+		return nil, WrapAuthRequest(oautherr.ErrUnauthorizedClient, "unauthorized response_type", request, callback)
+	}
+
+	if err := request.ResponseMode.Validate(request.ResponseType); err != nil {
+		return nil, WrapAuthRequest(err, "invalid response_mode", request, callback)
+	}
+
+	if err := request.ValidatePrompt(); err != nil {
+		return nil, WrapAuthRequest(err, "invalid prompt", request, callback)
+	}
+
+	if !array.ContainsAll(clt.Scopes(), scope.Split(request.Scopes)) {
+		return nil, WrapAuthRequest(oautherr.ErrInvalidScope, "invalid scope", request, callback)
+	}
+	return callback, nil
+}
+
+// storeAuthRequest 인가 요청 전문을 세션의 지정된 키로 저장한다.
+func storeAuthRequest(s sessions.Session, key string, request *authorization.Request) error {
 	serial, err := json.Marshal(request)
 	if err != nil {
 		log.Sugared().Errorf("error occurred during marshal request: %v", err)
 		return oautherr.ErrUnknown
 	}
-	s.Set(sessionKeyOriginAuthRequest, serial)
+	s.Set(key, serial)
 	if err = s.Save(); err != nil {
-		log.Sugared().Errorf("error occurred during save origin request: %v", err)
+		log.Sugared().Errorf("error occurred during save auth request(%s): %v", key, err)
 		return oautherr.ErrUnknown
 	} else {
 		return nil
 	}
 }
 
-// getOriginRequest 세션에 저장된 인가 요청을 가져온다.
-func getOriginRequest(s sessions.Session) (*authorization.Request, bool, error) {
-	if v, ok := s.Get(sessionKeyOriginAuthRequest).([]byte); ok {
+// getAuthRequest 세션의 지정된 키로 저장된 인가 요청을 가져온다.
+func getAuthRequest(s sessions.Session, key string) (*authorization.Request, bool, error) {
+	if v, ok := s.Get(key).([]byte); ok {
 		var request authorization.Request
 		if err := json.Unmarshal(v, &request); err != nil {
 			log.Sugared().Errorf("error occurred during unmarshal request: %v", err)
@@ -368,12 +869,12 @@ func getOriginRequest(s sessions.Session) (*authorization.Request, bool, error)
 	}
 }
 
-// clearOriginRequest 세션에서 저장된 인가 요청을 삭제한다.
-func clearOriginRequest(s sessions.Session) error {
-	s.Delete(sessionKeyOriginAuthRequest)
+// clearAuthRequest 세션의 지정된 키로 저장된 인가 요청을 삭제한다.
+func clearAuthRequest(s sessions.Session, key string) error {
+	s.Delete(key)
 
 	if err := s.Save(); err != nil {
-		log.Sugared().Errorf("error occurred during save origin request: %v", err)
+		log.Sugared().Errorf("error occurred during clear auth request(%s): %v", key, err)
 		return oautherr.ErrUnknown
 	} else {
 		return nil
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"oauth-server-go/internal/oauth/server/service"
+)
+
+// jwksCacheControl 공개키 목록 응답의 캐시 설정
+// 다음 서명키가 교체 주기 동안 미리 게시되므로 리소스 서버는 공개키 목록을 캐싱 할 수 있다.
+const jwksCacheControl = "public, max-age=300"
+
+// KeyHandler 토큰 서명 검증을 위한 공개키 목록을 제공하는 핸들러 구조체
+type KeyHandler struct {
+	KeyService *service.KeyService
+}
+
+// JWKS 토큰 서명 검증에 사용할 공개키 목록을 [RFC 7517] 에 정의된 JWK Set 형식으로 반환한다.
+//
+// [RFC 7517]: https://datatracker.ietf.org/doc/html/rfc7517#section-5
+func (h *KeyHandler) JWKS(ctx *gin.Context) error {
+	ctx.Header("Cache-Control", jwksCacheControl)
+	ctx.JSON(http.StatusOK, h.KeyService.JWKS(ctx))
+	return nil
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-jose/go-jose/v4"
+	"net/http"
+	"oauth-server-go/internal/oauth/authorization"
+	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/key"
+	"oauth-server-go/internal/oauth/token"
+)
+
+// Metadata [RFC 8414] 에 정의된 인가 서버 메타데이터
+//
+// [RFC 8414]: https://datatracker.ietf.org/doc/html/rfc8414#section-2
+type Metadata struct {
+	Issuer                                     string                          `json:"issuer"`
+	AuthorizationEndpoint                      string                          `json:"authorization_endpoint"`
+	TokenEndpoint                              string                          `json:"token_endpoint"`
+	JWKSURI                                    string                          `json:"jwks_uri"`
+	IntrospectionEndpoint                      string                          `json:"introspection_endpoint,omitempty"`
+	ResponseTypesSupported                     []authorization.ResponseType    `json:"response_types_supported"`
+	ResponseModesSupported                     []authorization.ResponseMode    `json:"response_modes_supported,omitempty"`
+	PromptValuesSupported                      []authorization.Prompt          `json:"prompt_values_supported,omitempty"`
+	ACRValuesSupported                         []string                        `json:"acr_values_supported,omitempty"`
+	GrantTypesSupported                        []token.GrantType               `json:"grant_types_supported"`
+	CodeChallengeMethodsSupported              []authorization.ChallengeMethod `json:"code_challenge_methods_supported"`
+	TokenEndpointAuthMethodsSupported          []client.AuthMethod             `json:"token_endpoint_auth_methods_supported"`
+	TokenEndpointAuthSigningAlgValuesSupported []string                        `json:"token_endpoint_auth_signing_alg_values_supported,omitempty"`
+	IntrospectionEndpointAuthMethodsSupported  []client.AuthMethod             `json:"introspection_endpoint_auth_methods_supported,omitempty"`
+	RevocationEndpoint                         string                          `json:"revocation_endpoint,omitempty"`
+	RevocationEndpointAuthMethodsSupported     []client.AuthMethod             `json:"revocation_endpoint_auth_methods_supported,omitempty"`
+	RegistrationEndpoint                       string                          `json:"registration_endpoint,omitempty"`
+	DeviceAuthorizationEndpoint                string                          `json:"device_authorization_endpoint,omitempty"`
+	PushedAuthorizationRequestEndpoint         string                          `json:"pushed_authorization_request_endpoint,omitempty"`
+	RequirePushedAuthorizationRequests         bool                            `json:"require_pushed_authorization_requests"`
+	RequestParameterSupported                  bool                            `json:"request_parameter_supported"`
+	RequestURIParameterSupported               bool                            `json:"request_uri_parameter_supported"`
+	RequireRequestURIRegistration              bool                            `json:"require_request_uri_registration"`
+	RequestObjectSigningAlgValuesSupported     []string                        `json:"request_object_signing_alg_values_supported,omitempty"`
+	DPoPSigningAlgValuesSupported              []string                        `json:"dpop_signing_alg_values_supported,omitempty"`
+	TLSClientCertificateBoundAccessTokens      bool                            `json:"tls_client_certificate_bound_access_tokens"`
+
+	// AuthorizationDetailsTypesSupported [RFC 9396] 인가 서버에 등록된 인가 상세 정보 타입 목록
+	//
+	// [RFC 9396]: https://datatracker.ietf.org/doc/html/rfc9396#section-10
+	AuthorizationDetailsTypesSupported []string `json:"authorization_details_types_supported,omitempty"`
+
+	// IntrospectionSigningAlgValuesSupported, IntrospectionEncryptionAlgValuesSupported, IntrospectionEncryptionEncValuesSupported
+	// [RFC 9701] JWT 토큰 질의 응답에 사용할 수 있는 서명 알고리즘과 암호화 알고리즘 목록
+	//
+	// [RFC 9701]: https://datatracker.ietf.org/doc/html/rfc9701#section-7
+	IntrospectionSigningAlgValuesSupported    []key.Algorithm          `json:"introspection_signing_alg_values_supported,omitempty"`
+	IntrospectionEncryptionAlgValuesSupported []jose.KeyAlgorithm      `json:"introspection_encryption_alg_values_supported,omitempty"`
+	IntrospectionEncryptionEncValuesSupported []jose.ContentEncryption `json:"introspection_encryption_enc_values_supported,omitempty"`
+
+	// AuthorizationSigningAlgValuesSupported [JARM] JWT 인가 응답의 서명 알고리즘 목록
+	//
+	// [JARM]: https://openid.net/specs/oauth-v2-jarm.html#section-4
+	AuthorizationSigningAlgValuesSupported []key.Algorithm `json:"authorization_signing_alg_values_supported,omitempty"`
+
+	// AuthorizationResponseIssParameterSupported [RFC 9207] 인가 응답에 iss 파라미터를 추가하는지 여부
+	//
+	// [RFC 9207]: https://datatracker.ietf.org/doc/html/rfc9207#section-3
+	AuthorizationResponseIssParameterSupported bool `json:"authorization_response_iss_parameter_supported"`
+}
+
+// OpenIDMetadata [OpenID Connect Discovery] 에 정의된 OpenID 제공자 메타데이터
+// 인가 서버 메타데이터에 OpenID Connect 에서 사용되는 항목들이 추가된다.
+//
+// [OpenID Connect Discovery]: https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type OpenIDMetadata struct {
+	Metadata
+	UserInfoEndpoint                 string          `json:"userinfo_endpoint"`
+	ScopesSupported                  []string        `json:"scopes_supported"`
+	SubjectTypesSupported            []string        `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []key.Algorithm `json:"id_token_signing_alg_values_supported"`
+	ClaimsSupported                  []string        `json:"claims_supported"`
+
+	// [OpenID CIBA] 백채널 인증 관련 항목
+	//
+	// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.4
+	BackchannelAuthenticationEndpoint      string                                `json:"backchannel_authentication_endpoint,omitempty"`
+	BackchannelTokenDeliveryModesSupported []client.BackchannelTokenDeliveryMode `json:"backchannel_token_delivery_modes_supported,omitempty"`
+	BackchannelUserCodeParameterSupported  bool                                  `json:"backchannel_user_code_parameter_supported"`
+}
+
+// MetadataHandler 인가 서버 메타데이터를 제공하는 핸들러 구조체
+type MetadataHandler struct {
+	Metadata *OpenIDMetadata
+}
+
+// AuthorizationServer [RFC 8414] 인가 서버 메타데이터를 반환한다.
+//
+// [RFC 8414]: https://datatracker.ietf.org/doc/html/rfc8414#section-3
+func (h *MetadataHandler) AuthorizationServer(ctx *gin.Context) error {
+	ctx.JSON(http.StatusOK, h.Metadata.Metadata)
+	return nil
+}
+
+// OpenIDConfiguration [OpenID Connect Discovery] OpenID 제공자 메타데이터를 반환한다.
+//
+// [OpenID Connect Discovery]: https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfig
+func (h *MetadataHandler) OpenIDConfiguration(ctx *gin.Context) error {
+	ctx.JSON(http.StatusOK, h.Metadata)
+	return nil
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"oauth-server-go/internal/config/log"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/server/pkg/security"
+	"oauth-server-go/internal/oauth/server/service"
+)
+
+// RegistrationResponse [RFC 7591] 클라이언트 등록 응답
+//
+// 클라이언트 시크릿과 등록 엑세스 토큰은 새로 생성된 경우에만 응답에 포함된다.
+//
+// [RFC 7591]: https://datatracker.ietf.org/doc/html/rfc7591#section-3.2.1
+type RegistrationResponse struct {
+	ClientID                string `json:"client_id"`
+	ClientSecret            string `json:"client_secret,omitempty"`
+	ClientIDIssuedAt        int64  `json:"client_id_issued_at"`
+	ClientSecretExpiresAt   int64  `json:"client_secret_expires_at"`
+	RegistrationAccessToken string `json:"registration_access_token,omitempty"`
+	RegistrationClientURI   string `json:"registration_client_uri"`
+	*client.Metadata
+}
+
+// RegistrationHandler [RFC 7591] 동적 클라이언트 등록과 [RFC 7592] 클라이언트 관리 엔드포인트를 처리하는 핸들러 구조체
+//
+// [RFC 7591]: https://datatracker.ietf.org/doc/html/rfc7591
+// [RFC 7592]: https://datatracker.ietf.org/doc/html/rfc7592
+type RegistrationHandler struct {
+	ClientRegistrar *service.ClientRegistrar
+
+	// RegistrationEndpoint 클라이언트 등록 엔드포인트의 URL
+	// 클라이언트 설정 엔드포인트(registration_client_uri)는 이 URL 뒤에 클라이언트 아이디를 붙여 생성한다.
+	RegistrationEndpoint string
+}
+
+// Register 새 클라이언트를 등록한다.
+// Authorization 헤더에 Bearer 스킴으로 초기 엑세스 토큰을 입력하지 않은 경우 익명 등록으로 처리한다.
+//
+// Parameter(application/json): [client.Metadata]
+//
+// Returns: [RegistrationResponse]
+func (h *RegistrationHandler) Register(ctx *gin.Context) error {
+	var m client.Metadata
+	if err := ctx.ShouldBindJSON(&m); err != nil {
+		return NewOAuth2Error(oautherr.ErrInvalidClientMetadata, "invalid client metadata")
+	}
+
+	initialAccessToken, _ := security.RetrieveBearerToken(ctx)
+	registration, err := h.ClientRegistrar.Register(ctx.Request.Context(), &m, initialAccessToken)
+	if err != nil {
+		log.Sugared().Errorf("error occurred during client registration: %v", err)
+		return NewOAuth2Error(err, "error occurred during client registration")
+	}
+
+	ctx.JSON(http.StatusCreated, h.newResponse(registration))
+	return nil
+}
+
+// Configuration 등록된 클라이언트의 정보를 조회한다.
+//
+// Returns: [RegistrationResponse]
+func (h *RegistrationHandler) Configuration(ctx *gin.Context) error {
+	registrationToken, ok := security.RetrieveBearerToken(ctx)
+	if !ok {
+		return NewOAuth2Error(oautherr.ErrUnauthorized, "registration access token is required")
+	}
+
+	c, err := h.ClientRegistrar.Retrieve(ctx.Request.Context(), ctx.Param("clientId"), registrationToken)
+	if err != nil {
+		return NewOAuth2Error(err, "registration access token is invalid")
+	}
+
+	ctx.JSON(http.StatusOK, h.newResponse(&service.ClientRegistration{Client: c}))
+	return nil
+}
+
+// UpdateConfiguration 등록된 클라이언트의 메타데이터를 요청한 메타데이터로 교체한다.
+// 요청 바디의 client_id는 경로의 클라이언트 아이디와 일치해야 한다.
+//
+// Parameter(application/json): [client.Metadata]
+//
+// Returns: [RegistrationResponse]
+func (h *RegistrationHandler) UpdateConfiguration(ctx *gin.Context) error {
+	registrationToken, ok := security.RetrieveBearerToken(ctx)
+	if !ok {
+		return NewOAuth2Error(oautherr.ErrUnauthorized, "registration access token is required")
+	}
+
+	var request struct {
+		ClientID string `json:"client_id"`
+		client.Metadata
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		return NewOAuth2Error(oautherr.ErrInvalidClientMetadata, "invalid client metadata")
+	}
+
+	clientID := ctx.Param("clientId")
+	if request.ClientID != clientID {
+		return NewOAuth2Error(oautherr.ErrInvalidRequest, "client_id is not matched")
+	}
+
+	registration, err := h.ClientRegistrar.Update(ctx.Request.Context(), clientID, registrationToken, &request.Metadata)
+	if err != nil {
+		return NewOAuth2Error(err, "error occurred during client update")
+	}
+
+	ctx.JSON(http.StatusOK, h.newResponse(registration))
+	return nil
+}
+
+// DeleteConfiguration 등록된 클라이언트를 삭제한다.
+// 클라이언트에게 발급된 인가 코드와 토큰도 함께 삭제된다.
+func (h *RegistrationHandler) DeleteConfiguration(ctx *gin.Context) error {
+	registrationToken, ok := security.RetrieveBearerToken(ctx)
+	if !ok {
+		return NewOAuth2Error(oautherr.ErrUnauthorized, "registration access token is required")
+	}
+
+	if err := h.ClientRegistrar.Delete(ctx.Request.Context(), ctx.Param("clientId"), registrationToken); err != nil {
+		return NewOAuth2Error(err, "error occurred during client delete")
+	}
+
+	ctx.Status(http.StatusNoContent)
+	return nil
+}
+
+func (h *RegistrationHandler) newResponse(registration *service.ClientRegistration) *RegistrationResponse {
+	c := registration.Client
+	return &RegistrationResponse{
+		ClientID:                c.Id(),
+		ClientSecret:            registration.Secret,
+		ClientIDIssuedAt:        c.RegisteredAt().Unix(),
+		RegistrationAccessToken: registration.RegistrationToken,
+		RegistrationClientURI:   h.RegistrationEndpoint + "/" + c.Id(),
+		Metadata:                c.Metadata(),
+	}
+}
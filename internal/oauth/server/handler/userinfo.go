@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"oauth-server-go/internal/config/log"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/scope"
+	"oauth-server-go/internal/oauth/server/pkg/security"
+	"oauth-server-go/internal/oauth/server/service"
+	"oauth-server-go/internal/oauth/token"
+	"oauth-server-go/internal/oauth/userinfo"
+	"oauth-server-go/internal/pkg/auth"
+	"slices"
+)
+
+// UserInfoHandler [OpenID Connect UserInfo] 엔드포인트를 처리하는 핸들러 구조체
+//
+// [OpenID Connect UserInfo]: https://openid.net/specs/openid-connect-core-1_0.html#UserInfo
+type UserInfoHandler struct {
+	TokenService    *service.TokenService
+	DPoPService     *service.DPoPService
+	RetrieveProfile auth.RetrieveProfile
+}
+
+// UserInfo Bearer 엑세스 토큰의 자원 소유자 정보를 토큰에 부여된 스코프에 따라 반환한다.
+// 엑세스 토큰에는 openid 스코프가 부여되어 있어야 한다.
+//
+// DPoP 공개키에 바인딩된 엑세스 토큰은 Bearer 대신 DPoP 인증 스킴과 DPoP 증명을 함께 전달해야 한다.
+// 클라이언트 인증서에 바인딩된 엑세스 토큰은 [RFC 8705] 에 따라 바인딩된 인증서로 맺은 TLS 연결로 전달해야 한다.
+//
+// Returns: [userinfo.Claims]
+//
+// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-3
+func (h *UserInfoHandler) UserInfo(ctx *gin.Context) error {
+	value, dpop := security.RetrieveDPoPToken(ctx)
+	if !dpop {
+		var ok bool
+		if value, ok = security.RetrieveBearerToken(ctx); !ok {
+			return NewOAuth2Error(oautherr.ErrUnauthorized, "bearer token is required")
+		}
+	}
+
+	accessToken, ok := h.TokenService.Retrieve(ctx.Request.Context(), value)
+	if !ok || !accessToken.Available() {
+		return NewOAuth2Error(oautherr.ErrInvalidToken, "access token is invalid or expired")
+	}
+	if dpop {
+		proof, err := verifyDPoPProof(ctx, h.DPoPService, ctx.Request.Header.Values(security.HeaderDPoP))
+		if err == nil {
+			err = proof.VerifyAccessToken(accessToken)
+		}
+		if err != nil {
+			return NewOAuth2Error(err, "invalid dpop proof")
+		}
+	} else if accessToken.Type() == token.TypeDPoP {
+		return NewOAuth2Error(oautherr.ErrInvalidToken, "dpop bound access token must be sent with dpop scheme")
+	}
+	if cnf := accessToken.Confirmation(); cnf != nil && cnf.X5TS256 != "" {
+		chain := security.RetrieveClientCertificates(ctx)
+		if len(chain) == 0 || token.CertificateThumbprint(chain[0]) != cnf.X5TS256 {
+			return NewOAuth2Error(oautherr.ErrInvalidToken, "access token is bound to a different client certificate")
+		}
+	}
+	if !slices.Contains(accessToken.Scopes(), scope.OpenID) {
+		return NewOAuth2Error(oautherr.ErrInsufficientScope, "openid scope is required")
+	}
+	if accessToken.Username() == "" {
+		return NewOAuth2Error(oautherr.ErrInvalidToken, "access token has no resource owner")
+	}
+
+	profile, err := h.RetrieveProfile(accessToken.Username())
+	if err != nil {
+		log.Sugared().Errorf("error occurred during retrieve profile(%s): %v", accessToken.Username(), err)
+		return NewOAuth2Error(fmt.Errorf("%w: %v", oautherr.ErrUnknown, err), "error occurred during retrieve profile")
+	}
+
+	ctx.JSON(http.StatusOK, userinfo.New(profile, accessToken.Scopes()))
+	return nil
+}
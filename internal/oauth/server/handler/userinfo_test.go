@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net/http"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/scope"
+	"oauth-server-go/internal/oauth/server/repository"
+	"oauth-server-go/internal/oauth/server/service"
+	"oauth-server-go/internal/oauth/token"
+	"oauth-server-go/internal/pkg/auth"
+	"oauth-server-go/internal/testutils"
+	"testing"
+	"time"
+)
+
+// testTokenRepository 엑세스 토큰 조회만 지원하는 테스트용 토큰 저장소
+type testTokenRepository struct {
+	repository.TokenRepository
+	accessToken *token.AccessToken
+}
+
+func (r *testTokenRepository) FindAccessTokenByValue(_ context.Context, value string) (*token.AccessToken, bool) {
+	if r.accessToken == nil || r.accessToken.Value() != value {
+		return nil, false
+	}
+	return r.accessToken, true
+}
+
+// newTestCertificate 테스트용 자체 서명 클라이언트 인증서를 생성한다.
+func newTestCertificate(t *testing.T) *x509.Certificate {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, private.Public(), private)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert
+}
+
+func TestUserInfoHandler_UserInfo(t *testing.T) {
+	bound := newTestCertificate(t)
+	other := newTestCertificate(t)
+
+	c := client.New("client_id", "", "client", client.TypeConfidential)
+	accessToken := token.New(c, func() string { return "access_token" })
+	accessToken.ApplyResourceOwnerInfo("username", []string{scope.OpenID})
+	token.BindCertificate(token.CertificateThumbprint(bound), accessToken, nil)
+
+	h := &UserInfoHandler{
+		TokenService: service.NewTokenService(&testTokenRepository{accessToken: accessToken}),
+		RetrieveProfile: func(username string) (*auth.Profile, error) {
+			return &auth.Profile{Username: username}, nil
+		},
+	}
+
+	tests := []struct {
+		name  string
+		tls   *tls.ConnectionState
+		valid bool
+	}{
+		{name: "TLS 연결이 아님", tls: nil},
+		{name: "클라이언트 인증서가 제출되지 않음", tls: &tls.ConnectionState{}},
+		{name: "바인딩된 인증서와 다른 인증서", tls: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{other}}},
+		{name: "바인딩된 인증서", tls: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{bound}}, valid: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, w, _ := testutils.MockGin(nil, nil)
+			ctx.Request.Header.Set("Authorization", "Bearer access_token")
+			ctx.Request.TLS = tc.tls
+
+			err := h.UserInfo(ctx)
+			if tc.valid {
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusOK, w.Code)
+			} else {
+				assert.ErrorIs(t, err, oautherr.ErrInvalidToken)
+			}
+		})
+	}
+}
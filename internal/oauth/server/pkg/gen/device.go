@@ -0,0 +1,18 @@
+package gen
+
+import (
+	"crypto/rand"
+	"math/big"
+	"oauth-server-go/internal/oauth/authorization"
+)
+
+// GenerateUserCode 디바이스 인가에 사용할 새 사용자 코드를 생성한다.
+func GenerateUserCode() string {
+	return authorization.GenerateUserCode(func(n int) int {
+		i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+		if err != nil {
+			panic(err)
+		}
+		return int(i.Int64())
+	})
+}
@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"context"
+	"oauth-server-go/internal/oauth/authorization"
+	"slices"
+	"sync"
+)
+
+// InProcessChannel 실제 인증 기기 대신 프로세스 메모리에 백채널 인증 요청을 보관하는 알림 채널
+//
+// 푸시 알림 등 실제 인증 기기와의 연동이 없는 개발 및 테스트 환경에서 사용하며, 알림 받은 요청을 자원 소유자별 수신함에 보관한다.
+// 만료된 요청은 새 요청이 알림 될 때 수신함에서 제거된다.
+type InProcessChannel struct {
+	mu    sync.Mutex
+	inbox map[string][]*authorization.BackchannelAuthentication
+}
+
+func NewInProcessChannel() *InProcessChannel {
+	return &InProcessChannel{inbox: make(map[string][]*authorization.BackchannelAuthentication)}
+}
+
+// Notify 백채널 인증 요청을 자원 소유자의 수신함에 보관한다.
+func (ch *InProcessChannel) Notify(_ context.Context, b *authorization.BackchannelAuthentication) error {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	for username, requests := range ch.inbox {
+		requests = slices.DeleteFunc(requests, func(r *authorization.BackchannelAuthentication) bool {
+			return !r.Available()
+		})
+		if len(requests) == 0 {
+			delete(ch.inbox, username)
+		} else {
+			ch.inbox[username] = requests
+		}
+	}
+	ch.inbox[b.Username()] = append(ch.inbox[b.Username()], b)
+	return nil
+}
+
+// Pending 자원 소유자의 수신함에 보관된 만료되지 않은 백채널 인증 요청 목록을 반환한다.
+func (ch *InProcessChannel) Pending(username string) []*authorization.BackchannelAuthentication {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	var pending []*authorization.BackchannelAuthentication
+	for _, r := range ch.inbox[username] {
+		if r.Available() {
+			pending = append(pending, r)
+		}
+	}
+	return pending
+}
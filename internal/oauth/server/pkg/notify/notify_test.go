@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/authorization"
+	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/pkg/period"
+	"testing"
+	"time"
+)
+
+func newTestBackchannelAuthentication(authReqID, username string, r period.Range) *authorization.BackchannelAuthentication {
+	c := client.New("test_client", "", "test", client.TypeConfidential)
+	return authorization.NewBackchannelAuthenticationWithState(authReqID, c, username, []string{"openid"}, "", "",
+		authorization.BackchannelStatusPending, authorization.BackchannelPollingInterval, time.Time{}, time.Time{}, "", nil, r)
+}
+
+func TestInProcessChannel(t *testing.T) {
+	now := time.Now()
+	expired := period.NewWithStartEnd(now.Add(-time.Hour), now.Add(-time.Minute))
+
+	t.Run("자원 소유자별로 알림 받은 요청 보관", func(t *testing.T) {
+		ch := NewInProcessChannel()
+		first := newTestBackchannelAuthentication("first", "user", period.New(time.Minute))
+		other := newTestBackchannelAuthentication("other", "other", period.New(time.Minute))
+
+		assert.NoError(t, ch.Notify(context.Background(), first))
+		assert.NoError(t, ch.Notify(context.Background(), other))
+
+		assert.Equal(t, []*authorization.BackchannelAuthentication{first}, ch.Pending("user"))
+		assert.Empty(t, ch.Pending("unknown"))
+	})
+
+	t.Run("만료된 요청은 반환하지 않고 새 알림시 제거", func(t *testing.T) {
+		ch := NewInProcessChannel()
+		assert.NoError(t, ch.Notify(context.Background(), newTestBackchannelAuthentication("expired", "user", expired)))
+		assert.Empty(t, ch.Pending("user"))
+
+		assert.NoError(t, ch.Notify(context.Background(), newTestBackchannelAuthentication("other", "other", period.New(time.Minute))))
+		assert.NotContains(t, ch.inbox, "user")
+	})
+}
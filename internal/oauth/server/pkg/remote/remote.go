@@ -0,0 +1,217 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// defaultTimeout 원격 자원 조회시 기본 타임아웃
+	defaultTimeout = time.Second * 5
+
+	// maxBodySize 원격 자원 응답의 최대 크기 (64KB)
+	maxBodySize = 64 * 1024
+
+	// maxRedirects 원격 자원 조회시 따라갈 최대 리다이렉트 횟수
+	maxRedirects = 3
+
+	// maxIdleConns, idleConnTimeout 재사용을 위해 유지할 유휴 연결의 최대 개수와 유지 시간
+	// 클라이언트가 입력한 임의의 호스트로 연결하므로 유휴 연결이 계속 쌓이지 않도록 제한한다.
+	maxIdleConns    = 100
+	idleConnTimeout = time.Second * 90
+)
+
+// defaultClient [Fetcher], [Notifier] 에 HTTP 클라이언트가 설정되지 않은 경우 사용할 클라이언트
+// 호출 할 때마다 생성하면 연결을 재사용 할 수 없으므로 처음 사용할 때 한번만 생성하여 공유한다.
+var defaultClient = sync.OnceValue(NewClient)
+
+// Fetcher 클라이언트가 호스팅 하는 원격 자원(jwks_uri, request_uri 등)을 HTTP로 조회한다.
+//
+// 클라이언트가 입력한 임의의 주소를 조회하므로 타임아웃과 응답 크기를 제한하며,
+// https 스킴 이외의 주소는 조회하지 않는다.
+type Fetcher struct {
+	// Client 조회에 사용할 HTTP 클라이언트. nil 인 경우 내부 네트워크 주소로 연결하지 않는 공유 클라이언트([NewClient])를 사용한다.
+	Client *http.Client
+}
+
+// NewClient 클라이언트가 입력한 주소를 호출하기 위한 HTTP 클라이언트를 생성한다.
+//
+// 인가 서버 내부의 자원에 접근하지 못하도록 루프백, 사설, 링크 로컬 등 공인 유니캐스트가 아닌 주소로는 연결하지 않으며([CheckAddress]),
+// 이 검사는 DNS 조회 이후 실제 연결할 주소에 대해 수행하므로 DNS 리바인딩으로 우회 할 수 없다.
+// 프록시를 사용하지 않으며 https 이외의 주소로는 리다이렉트 하지 않는다.
+//
+// 생성된 클라이언트는 연결을 재사용 할 수 있도록 요청마다 생성하지 않고 공유하여 사용해야 한다.
+func NewClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: defaultTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return CheckAddress(address)
+		},
+	}
+	return &http.Client{
+		Timeout: defaultTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: defaultTimeout,
+			MaxIdleConns:        maxIdleConns,
+			IdleConnTimeout:     idleConnTimeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to non https url(%s)", req.URL)
+			}
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return nil
+		},
+	}
+}
+
+// sharedAddressSpace [RFC 6598] 통신 사업자 NAT 에서 사용하는 공유 주소 대역
+//
+// [RFC 6598]: https://datatracker.ietf.org/doc/html/rfc6598
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// CheckAddress 연결하려는 주소(host:port)가 공인 유니캐스트 주소인지 확인한다.
+// 루프백, 사설, 링크 로컬, 멀티캐스트, 미지정 주소와 공유 주소 대역인 경우 에러를 반환한다.
+func CheckAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("invalid ip address(%s)", host)
+	}
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("address(%s) is not allowed", ip)
+	}
+	return nil
+}
+
+// JWKS uri 에서 [RFC 7517] 에 정의된 공개키 목록을 조회한다.
+// [client.FetchJWKS] 의 구현체로 사용된다.
+//
+// [RFC 7517]: https://datatracker.ietf.org/doc/html/rfc7517#section-5
+func (f *Fetcher) JWKS(ctx context.Context, uri string) (*jose.JSONWebKeySet, error) {
+	body, err := f.get(ctx, uri, "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("%w: error occurred during fetch jwks(%s): %v", oautherr.ErrInvalidClient, uri, err)
+	}
+
+	var jwks jose.JSONWebKeySet
+	if err = json.Unmarshal(body, &jwks); err != nil {
+		return nil, fmt.Errorf("%w: jwks(%s) is malformed: %v", oautherr.ErrInvalidClient, uri, err)
+	}
+	return &jwks, nil
+}
+
+// RequestObject uri 에서 [RFC 9101] 에 정의된 인가 요청 객체를 조회한다.
+//
+// [RFC 9101]: https://datatracker.ietf.org/doc/html/rfc9101#section-5.2.3
+func (f *Fetcher) RequestObject(ctx context.Context, uri string) (string, error) {
+	body, err := f.get(ctx, uri, "application/oauth-authz-req+jwt")
+	if err != nil {
+		return "", fmt.Errorf("%w: error occurred during fetch request object(%s): %v", oautherr.ErrInvalidRequestURI, uri, err)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func (f *Fetcher) get(ctx context.Context, uri, accept string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("unsupported uri")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+
+	res, err := f.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxBodySize {
+		return nil, fmt.Errorf("response body is too large")
+	}
+	return body, nil
+}
+
+func (f *Fetcher) client() *http.Client {
+	if f.Client != nil {
+		return f.Client
+	}
+	return defaultClient()
+}
+
+// Notifier 클라이언트가 호스팅 하는 알림 엔드포인트(backchannel_client_notification_endpoint 등)를 HTTP로 호출한다.
+//
+// [Fetcher] 와 같이 클라이언트가 입력한 임의의 주소를 호출하므로 타임아웃을 제한하며 https 스킴 이외의 주소는 호출하지 않는다.
+type Notifier struct {
+	// Client 호출에 사용할 HTTP 클라이언트. nil 인 경우 내부 네트워크 주소로 연결하지 않는 공유 클라이언트([NewClient])를 사용한다.
+	Client *http.Client
+}
+
+// Ping [OpenID CIBA] ping 방식에서 자원 소유자의 인증이 끝났음을 클라이언트의 알림 엔드포인트로 알린다.
+// 클라이언트가 인증 요청시 전달한 client_notification_token 을 Bearer 토큰으로 사용한다.
+//
+// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.10.2
+func (n *Notifier) Ping(ctx context.Context, endpoint, notificationToken, authReqID string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" {
+		return fmt.Errorf("unsupported client notification endpoint(%s)", endpoint)
+	}
+
+	body, err := json.Marshal(map[string]string{"auth_req_id": authReqID})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+notificationToken)
+
+	client := n.Client
+	if client == nil {
+		client = defaultClient()
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("client notification endpoint(%s) responded with status code %d", endpoint, res.StatusCode)
+	}
+	return nil
+}
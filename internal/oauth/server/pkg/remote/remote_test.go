@@ -0,0 +1,155 @@
+package remote
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"strings"
+	"testing"
+)
+
+func TestFetcher_JWKS(t *testing.T) {
+	private, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: private.Public(), KeyID: "client-key", Algorithm: "ES256", Use: "sig"}}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(jwks)
+	})
+	mux.HandleFunc("/malformed", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("not json"))
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("a", maxBodySize+1)))
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	fetcher := &Fetcher{Client: server.Client()}
+
+	t.Run("공개키 목록 조회", func(t *testing.T) {
+		result, err := fetcher.JWKS(context.Background(), server.URL+"/jwks.json")
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Key("client-key"), 1)
+	})
+
+	t.Run("응답이 200이 아닌 경우 ErrInvalidClient", func(t *testing.T) {
+		_, err := fetcher.JWKS(context.Background(), server.URL+"/not-found")
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("공개키 목록 형식이 아닌 경우 ErrInvalidClient", func(t *testing.T) {
+		_, err := fetcher.JWKS(context.Background(), server.URL+"/malformed")
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("응답 크기가 제한을 넘는 경우 ErrInvalidClient", func(t *testing.T) {
+		_, err := fetcher.JWKS(context.Background(), server.URL+"/large")
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("https 이외의 스킴은 조회하지 않음", func(t *testing.T) {
+		for _, uri := range []string{"file:///etc/passwd", "http://client.example.com/jwks.json"} {
+			_, err := fetcher.JWKS(context.Background(), uri)
+			assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+		}
+	})
+
+	t.Run("기본 클라이언트는 내부 네트워크 주소를 조회하지 않음", func(t *testing.T) {
+		_, err := (&Fetcher{}).JWKS(context.Background(), server.URL+"/jwks.json")
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+}
+
+func TestCheckAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		allowed bool
+	}{
+		{name: "공인 IPv4", address: "93.184.216.34:443", allowed: true},
+		{name: "공인 IPv6", address: "[2606:2800:220:1::]:443", allowed: true},
+		{name: "루프백", address: "127.0.0.1:443"},
+		{name: "IPv6 루프백", address: "[::1]:443"},
+		{name: "사설 대역", address: "10.0.0.1:443"},
+		{name: "사설 대역(192.168)", address: "192.168.0.1:443"},
+		{name: "링크 로컬(클라우드 메타데이터)", address: "169.254.169.254:80"},
+		{name: "미지정 주소", address: "0.0.0.0:443"},
+		{name: "공유 주소 대역", address: "100.64.0.1:443"},
+		{name: "IPv4 매핑 IPv6 루프백", address: "[::ffff:127.0.0.1]:443"},
+		{name: "IPv6 고유 로컬", address: "[fd00::1]:443"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckAddress(tc.address)
+			if tc.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestFetcher_RequestObject(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/oauth-authz-req+jwt", r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "application/oauth-authz-req+jwt")
+		_, _ = w.Write([]byte("header.payload.signature\n"))
+	}))
+	defer server.Close()
+
+	fetcher := &Fetcher{Client: server.Client()}
+	result, err := fetcher.RequestObject(context.Background(), server.URL)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "header.payload.signature", result)
+}
+
+func TestNotifier_Ping(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer notification-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := &Notifier{Client: server.Client()}
+
+	t.Run("알림 토큰과 인증 요청 식별자로 알림", func(t *testing.T) {
+		err := notifier.Ping(context.Background(), server.URL, "notification-token", "auth-req-id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "auth-req-id", received["auth_req_id"])
+	})
+
+	t.Run("성공 응답이 아닌 경우 에러", func(t *testing.T) {
+		err := notifier.Ping(context.Background(), server.URL, "wrong-token", "auth-req-id")
+		assert.Error(t, err)
+	})
+
+	t.Run("https 이외의 스킴은 호출하지 않음", func(t *testing.T) {
+		err := notifier.Ping(context.Background(), "http://client.example.com/cb", "notification-token", "auth-req-id")
+		assert.Error(t, err)
+	})
+}
+
+func TestFetcher_client(t *testing.T) {
+	t.Run("HTTP 클라이언트가 설정되지 않은 경우 같은 클라이언트를 재사용함", func(t *testing.T) {
+		assert.Same(t, (&Fetcher{}).client(), (&Fetcher{}).client())
+	})
+}
@@ -2,6 +2,8 @@ package security
 
 import (
 	"context"
+	"crypto/x509"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"oauth-server-go/internal/config/log"
 	"oauth-server-go/internal/oauth/client"
@@ -67,6 +69,92 @@ func ClientFormAuthenticationHandler(authenticate ClientAuthenticate) gin.Handle
 	}
 }
 
+// ClientCertificateAuthenticate 클라이언트 아이디와 TLS 연결에서 받은 클라이언트 인증서 체인으로 상호 TLS 인증을 진행한다.
+// 클라이언트의 인증 방식이 상호 TLS 인증이 아닌 경우 false 를 반환한다.
+type ClientCertificateAuthenticate func(ctx context.Context, id string, chain []*x509.Certificate) (*client.Client, bool, error)
+
+// ClientCertificateAuthenticationHandler [RFC 8705] 에 정의된 상호 TLS 인증으로 OAuth2 클라이언트를 인증하는 Gin 미들웨어 함수를 생성한다.
+// 클라이언트 인증서가 있고 요청 파라미터로 client_id 가 전달된 경우에만 인증을 시도하며,
+// 상호 TLS 인증을 사용하는 클라이언트가 시크릿으로 인증되지 않도록 다른 클라이언트 인증 핸들러보다 먼저 등록해야 한다.
+//
+// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-2
+func ClientCertificateAuthenticationHandler(authenticate ClientCertificateAuthenticate) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		_, exists := ctx.Get(oauth2ShareKeyAuthClient)
+		chain := RetrieveClientCertificates(ctx)
+		if !exists && len(chain) > 0 {
+			var r ClientAuthRequest
+			if err := ctx.Bind(&r); err != nil {
+				_ = ctx.Error(err)
+				ctx.Abort()
+				return
+			}
+			c, ok, err := authenticate(ctx.Request.Context(), r.ID, chain)
+			if err != nil {
+				_ = ctx.Error(err)
+				ctx.Abort()
+				return
+			}
+			if ok {
+				ctx.Set(oauth2ShareKeyAuthClient, c)
+			}
+		}
+		ctx.Next()
+	}
+}
+
+// ClientAssertionAuthenticate 클라이언트 어설션 타입과 어설션으로 클라이언트의 인증을 진행한다.
+// 인증 완료시 어설션으로 식별된 클라이언트를 반환하며 실패시 에러를 반환한다.
+type ClientAssertionAuthenticate func(ctx context.Context, assertionType, assertion string) (*client.Client, error)
+
+// ClientAssertionRequest 클라이언트 어설션 인증요청 폼
+type ClientAssertionRequest struct {
+	ID            string `form:"client_id"`
+	AssertionType string `form:"client_assertion_type"`
+	Assertion     string `form:"client_assertion"`
+}
+
+// ClientAssertionAuthenticationHandler [RFC 7523] 에 정의된 JWT 클라이언트 어설션으로 OAuth2 클라이언트를 인증하는 Gin 미들웨어 함수를 생성한다.
+// 요청 파라미터로 client_assertion_type 이나 client_assertion 이 전달된 경우에만 인증을 시도하며,
+// client_id 가 함께 전달된 경우 어설션으로 식별된 클라이언트와 같아야 한다.
+//
+// [RFC 7523]: https://datatracker.ietf.org/doc/html/rfc7523#section-2.2
+func ClientAssertionAuthenticationHandler(authenticate ClientAssertionAuthenticate) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		_, exists := ctx.Get(oauth2ShareKeyAuthClient)
+		if !exists {
+			var r ClientAssertionRequest
+			if err := ctx.Bind(&r); err != nil {
+				_ = ctx.Error(err)
+				ctx.Abort()
+				return
+			}
+			if r.AssertionType != "" || r.Assertion != "" {
+				c, err := authenticate(ctx.Request.Context(), r.AssertionType, r.Assertion)
+				if err == nil && r.ID != "" && r.ID != c.Id() {
+					err = fmt.Errorf("%w: client_id(%s) is not matched with client_assertion", oautherr.ErrInvalidClient, r.ID)
+				}
+				if err != nil {
+					_ = ctx.Error(err)
+					ctx.Abort()
+					return
+				}
+				ctx.Set(oauth2ShareKeyAuthClient, c)
+			}
+		}
+		ctx.Next()
+	}
+}
+
+// RetrieveClientCertificates TLS 연결에서 받은 클라이언트 인증서 체인을 반환한다.
+// TLS 연결이 아니거나 클라이언트가 인증서를 제출하지 않은 경우 nil 을 반환한다.
+func RetrieveClientCertificates(c *gin.Context) []*x509.Certificate {
+	if c.Request.TLS == nil {
+		return nil
+	}
+	return c.Request.TLS.PeerCertificates
+}
+
 // RetrieveClientAuthentication Gin 컨텍스트에서 인증된 클라이언트의 정보를 조회한다.
 //
 // 이전 미들웨어에서 성공적으로 인증되어 저장된 클라이언트 정보를 조회하여 반환한다.
@@ -0,0 +1,32 @@
+package security
+
+import (
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+// bearerPrefix Authorization 헤더의 Bearer 인증 스킴
+const bearerPrefix = "bearer "
+
+// RetrieveBearerToken [RFC 6750] 에 정의된 방식으로 요청에서 엑세스 토큰을 추출한다.
+// Authorization 요청 헤더를 우선 확인하고 없을 경우 application/x-www-form-urlencoded 바디의 access_token 파라미터를 확인한다.
+//
+// Returns:
+//   - string: 추출된 엑세스 토큰
+//   - bool: 추출 성공 여부
+//
+// [RFC 6750]: https://datatracker.ietf.org/doc/html/rfc6750#section-2
+func RetrieveBearerToken(c *gin.Context) (string, bool) {
+	if header := c.GetHeader("Authorization"); header != "" {
+		if len(header) > len(bearerPrefix) && strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+			return strings.TrimSpace(header[len(bearerPrefix):]), true
+		}
+		return "", false
+	}
+	if c.Request.Method == "POST" && c.ContentType() == "application/x-www-form-urlencoded" {
+		if t := c.PostForm("access_token"); t != "" {
+			return t, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,32 @@
+package security
+
+import (
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+const (
+	// HeaderDPoP DPoP 증명을 전달하는 요청 헤더
+	HeaderDPoP = "DPoP"
+
+	// HeaderDPoPNonce 서버 nonce 를 전달하는 응답 헤더
+	HeaderDPoPNonce = "DPoP-Nonce"
+
+	// dpopPrefix Authorization 헤더의 DPoP 인증 스킴
+	dpopPrefix = "dpop "
+)
+
+// RetrieveDPoPToken [RFC 9449] 에 정의된 방식으로 Authorization 요청 헤더의 DPoP 인증 스킴에서 엑세스 토큰을 추출한다.
+//
+// Returns:
+//   - string: 추출된 엑세스 토큰
+//   - bool: 추출 성공 여부
+//
+// [RFC 9449]: https://datatracker.ietf.org/doc/html/rfc9449#section-7.1
+func RetrieveDPoPToken(c *gin.Context) (string, bool) {
+	header := c.GetHeader("Authorization")
+	if len(header) > len(dpopPrefix) && strings.EqualFold(header[:len(dpopPrefix)], dpopPrefix) {
+		return strings.TrimSpace(header[len(dpopPrefix):]), true
+	}
+	return "", false
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"oauth-server-go/internal/oauth/client"
+	"time"
+)
+
+// ClientAssertionGormBridge 사용된 클라이언트 어설션을 Gorm을 이용해 데이터베이스에 저장 할 수 있도록 변환 및 연결 작업을 하는 객체
+type ClientAssertionGormBridge struct {
+	db *gorm.DB
+}
+
+func NewClientAssertionGormBridge(db *gorm.DB) *ClientAssertionGormBridge {
+	return &ClientAssertionGormBridge{db: db}
+}
+
+// SaveIfAbsent Gorm을 이용해 사용된 클라이언트 어설션을 저장한다.
+// 클라이언트 아이디와 어설션 식별자(client_id, jti)의 유니크 제약 조건으로 이미 사용된 어설션인지 확인하며 만료된 어설션은 함께 삭제한다.
+func (b *ClientAssertionGormBridge) SaveIfAbsent(ctx context.Context, a *client.Assertion) (bool, error) {
+	db := b.db.WithContext(ctx)
+	if err := db.Where("expired_at < ?", time.Now()).Delete(&ClientAssertion{}).Error; err != nil {
+		return false, err
+	}
+
+	assertionModel := &ClientAssertion{
+		ClientID:  a.ClientID(),
+		JTI:       a.JTI(),
+		ExpiredAt: a.ExpiresAt(),
+	}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(assertionModel)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"oauth-server-go/internal/config/log"
+	"oauth-server-go/internal/oauth/authorization"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/pkg/sql"
+	"time"
+)
+
+// FindBackchannelAuthentication Gorm을 이용하여 데이터베이스에서 조건에 맞는 백채널 인증 요청을 조회한다.
+//
+// Returns:
+//   - *BackchannelAuthentication: 조회된 백채널 인증 요청 모델
+//   - bool: 조회 성공 여부
+func FindBackchannelAuthentication(ctx context.Context, db *gorm.DB, cond *BackchannelAuthentication) (*BackchannelAuthentication, bool) {
+	var b BackchannelAuthentication
+	if err := db.WithContext(ctx).Joins("Client").Preload("Client.Scopes").Preload("Scopes").Where(cond).First(&b).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Sugared().Errorf("error occurred during select backchannel authentication(%v): %v", cond, err)
+		}
+		return nil, false
+	}
+	return &b, true
+}
+
+// BackchannelAuthenticationGormBridge OpenID CIBA 백채널 인증 요청 도메인을 Gorm을 이용해 데이터베이스에 CRUD 할 수 있도록 변환 및 연결 작업을 하는 객체
+type BackchannelAuthenticationGormBridge struct {
+	db *gorm.DB
+}
+
+func NewBackchannelAuthenticationGormBridge(db *gorm.DB) *BackchannelAuthenticationGormBridge {
+	return &BackchannelAuthenticationGormBridge{db: db}
+}
+
+// FindByAuthReqID Gorm을 이용해 데이터베이스에서 백채널 인증 요청을 조회하고 이를 도메인 모델로 변환하여 반환한다.
+func (b *BackchannelAuthenticationGormBridge) FindByAuthReqID(ctx context.Context, authReqID string) (*authorization.BackchannelAuthentication, bool) {
+	if model, ok := FindBackchannelAuthentication(ctx, b.db, &BackchannelAuthentication{AuthReqID: authReqID}); ok {
+		return model.Domain(), true
+	} else {
+		return nil, false
+	}
+}
+
+// FindPendingByUsername Gorm을 이용해 데이터베이스에서 자원 소유자의 승인을 기다리는 만료되지 않은 백채널 인증 요청 목록을 조회한다.
+func (b *BackchannelAuthenticationGormBridge) FindPendingByUsername(ctx context.Context, username string) []*authorization.BackchannelAuthentication {
+	var models []BackchannelAuthentication
+	cond := &BackchannelAuthentication{Username: username, Status: authorization.BackchannelStatusPending}
+	err := b.db.WithContext(ctx).Joins("Client").Preload("Client.Scopes").Preload("Scopes").
+		Where(cond).Where("expired_at > ?", time.Now()).Order("issued_at").Find(&models).Error
+	if err != nil {
+		log.Sugared().Errorf("error occurred during select pending backchannel authentication(%s): %v", username, err)
+		return nil
+	}
+
+	results := make([]*authorization.BackchannelAuthentication, 0, len(models))
+	for _, m := range models {
+		results = append(results, m.Domain())
+	}
+	return results
+}
+
+// Save Gorm을 이용해 데이터베이스에 백채널 인증 요청을 저장한다.
+func (b *BackchannelAuthenticationGormBridge) Save(ctx context.Context, a *authorization.BackchannelAuthentication) error {
+	clientModel, ok := FindClientByClientID(ctx, b.db, a.Client().Id())
+	if !ok {
+		return fmt.Errorf("%w: client(%s) not found", oautherr.ErrInvalidClient, a.Client().Id())
+	}
+
+	model := &BackchannelAuthentication{
+		AuthReqID:         a.AuthReqID(),
+		ClientID:          clientModel.ID,
+		Username:          a.Username(),
+		Scopes:            filterScopes(clientModel.Scopes, a.Scopes()),
+		BindingMessage:    a.BindingMessage(),
+		NotificationToken: a.NotificationToken(),
+		Status:            a.Status(),
+		PollingInterval:   int(a.Interval() / time.Second),
+		LastPolledAt:      toNullTime(a.LastPolledAt()),
+		AuthTime:          toNullTime(a.AuthTime()),
+		ACR:               a.ACR(),
+		AMR:               a.AMR(),
+		IssuedAt:          a.Start(),
+		ExpiredAt:         a.End(),
+	}
+	return b.db.WithContext(ctx).Omit("Client", "Scopes.*").Create(model).Error
+}
+
+// Update Gorm을 이용해 데이터베이스에 저장된 백채널 인증 요청의 상태와 폴링 정보, 승인된 스코프와 자원 소유자의 인증 정보를 갱신한다.
+//
+// 승인 된 스코프는 요청한 스코프의 일부이므로 스코프 수가 달라진 경우에만 스코프 연관 관계를 교체한다.
+func (b *BackchannelAuthenticationGormBridge) Update(ctx context.Context, a *authorization.BackchannelAuthentication) error {
+	model, ok := FindBackchannelAuthentication(ctx, b.db, &BackchannelAuthentication{AuthReqID: a.AuthReqID()})
+	if !ok {
+		return fmt.Errorf("%w: backchannel authentication(%s) not found", oautherr.ErrUnknown, a.AuthReqID())
+	}
+
+	updates := map[string]any{
+		"backchannel_status": a.Status(),
+		"polling_interval":   int(a.Interval() / time.Second),
+		"last_polled_at":     toNullTime(a.LastPolledAt()),
+		"auth_time":          toNullTime(a.AuthTime()),
+		"acr":                a.ACR(),
+		"amr":                sql.Strings(a.AMR()),
+	}
+	scopes := filterScopes(model.Scopes, a.Scopes())
+
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(model).Omit("Client", "Scopes").Updates(updates).Error; err != nil {
+			return err
+		}
+		if len(scopes) == len(model.Scopes) {
+			return nil
+		}
+		return tx.Model(model).Omit("Scopes.*").Association("Scopes").Replace(scopes)
+	})
+}
+
+// Delete Gorm을 이용해 데이터베이스에서 백채널 인증 요청을 삭제한다.
+func (b *BackchannelAuthenticationGormBridge) Delete(ctx context.Context, a *authorization.BackchannelAuthentication) error {
+	model, ok := FindBackchannelAuthentication(ctx, b.db, &BackchannelAuthentication{AuthReqID: a.AuthReqID()})
+	if !ok {
+		return fmt.Errorf("%w: backchannel authentication(%s) not found", oautherr.ErrUnknown, a.AuthReqID())
+	}
+
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(model).Association("Scopes").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(model).Error
+	})
+}
@@ -3,9 +3,12 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"gorm.io/gorm"
 	"oauth-server-go/internal/config/log"
 	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/pkg/crypt"
 )
 
 const clientCacheName = "oauth/server/repository/client_gorm/client"
@@ -47,13 +50,23 @@ func FindClientByClientID(ctx context.Context, db *gorm.DB, id string) (*Client,
 	return &c, true
 }
 
+// clientSecretSalt client_secret_jwt 시크릿 암호화 키를 유도할 때 사용할 솔트
+const clientSecretSalt = "oauth2_client.jwt_secret"
+
 // ClientGormBridge OAuth2 클라이언트 도메인을 Gorm을 이용해 데이터베이스에 CRUD 할 수 있도록 변환 및 연결 작업을 하는 객체
+// client_secret_jwt 클라이언트의 시크릿 원문은 데이터베이스에 저장되기 전 secret으로 암호화 된다.
+// 암호화 키는 객체 생성시 한번만 유도되며 클라이언트 조회시에는 키 유도 없이 복호화만 수행한다.
 type ClientGormBridge struct {
-	db *gorm.DB
+	db     *gorm.DB
+	cipher *crypt.Cipher
 }
 
-func NewClientGormBridge(db *gorm.DB) *ClientGormBridge {
-	return &ClientGormBridge{db: db}
+func NewClientGormBridge(db *gorm.DB, secret string) (*ClientGormBridge, error) {
+	c, err := crypt.NewCipher(secret, []byte(clientSecretSalt))
+	if err != nil {
+		return nil, err
+	}
+	return &ClientGormBridge{db: db, cipher: c}, nil
 }
 
 // FindByClientID Gorm을 이용해 데이터베이스에서 클라이언트를 조회하고 이를 도메인 객체로 변경하여 반환한다.
@@ -62,9 +75,136 @@ func NewClientGormBridge(db *gorm.DB) *ClientGormBridge {
 //   - *client.Client: 조회된 클라이언트 도메인 모델
 //   - bool: 조회 성공 여부
 func (b *ClientGormBridge) FindByClientID(ctx context.Context, id string) (*client.Client, bool) {
-	if clientModel, ok := FindClientByClientID(ctx, b.db, id); ok {
-		return clientModel.Domain(), true
-	} else {
+	clientModel, ok := FindClientByClientID(ctx, b.db, id)
+	if !ok {
 		return nil, false
 	}
+	c := clientModel.Domain()
+	if len(clientModel.JWTSecret) > 0 {
+		plain, err := b.cipher.Decrypt(clientModel.JWTSecret)
+		if err != nil {
+			log.Sugared().Errorf("error occurred during decrypt client(%s) secret: %v", id, err)
+			return nil, false
+		}
+		c.SetJWTSecret(string(plain))
+	}
+	return c, true
+}
+
+// encryptJWTSecret 클라이언트의 client_secret_jwt 시크릿 원문을 암호화 한다. 원문이 없는 경우 nil 을 반환한다.
+func (b *ClientGormBridge) encryptJWTSecret(c *client.Client) ([]byte, error) {
+	if c.JWTSecret() == "" {
+		return nil, nil
+	}
+	encrypted, err := b.cipher.Encrypt([]byte(c.JWTSecret()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: error occurred during encrypt client secret: %v", oautherr.ErrUnknown, err)
+	}
+	return encrypted, nil
+}
+
+// Save Gorm을 이용해 클라이언트 도메인을 데이터 모델로 변환하여 데이터베이스에 저장한다.
+func (b *ClientGormBridge) Save(ctx context.Context, c *client.Client) error {
+	var clientModel Client
+	clientModel.apply(c)
+	jwtSecret, err := b.encryptJWTSecret(c)
+	if err != nil {
+		return err
+	}
+	clientModel.JWTSecret = jwtSecret
+	if len(c.Scopes()) > 0 {
+		clientModel.Scopes = FindScopeByValue(ctx, b.db, c.Scopes()...)
+	}
+	return b.db.WithContext(ctx).Omit("Scopes.*").Create(&clientModel).Error
+}
+
+// Update Gorm을 이용해 데이터베이스에 저장된 클라이언트의 정보와 스코프를 갱신한다.
+func (b *ClientGormBridge) Update(ctx context.Context, c *client.Client) error {
+	clientModel, ok := FindClientByClientID(ctx, b.db, c.Id())
+	if !ok {
+		return fmt.Errorf("%w: client(%s) not found", oautherr.ErrUnknown, c.Id())
+	}
+	clientModel.apply(c)
+	jwtSecret, err := b.encryptJWTSecret(c)
+	if err != nil {
+		return err
+	}
+	clientModel.JWTSecret = jwtSecret
+
+	var scopes []Scope
+	if len(c.Scopes()) > 0 {
+		scopes = FindScopeByValue(ctx, b.db, c.Scopes()...)
+	}
+
+	err = b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Scopes").Save(clientModel).Error; err != nil {
+			return err
+		}
+		return tx.Model(clientModel).Omit("Scopes.*").Association("Scopes").Replace(scopes)
+	})
+	if cache, caching := ctx.Value(clientCacheName).(*clientCache); caching {
+		delete(cache.client, c.Id())
+	}
+	return err
+}
+
+// Delete Gorm을 이용해 데이터베이스에서 클라이언트와 클라이언트에게 발급된 인가 코드, 푸시된 인가 요청, 디바이스 코드, 백채널 인증 요청 및 토큰을 삭제한다.
+func (b *ClientGormBridge) Delete(ctx context.Context, c *client.Client) error {
+	clientModel, ok := FindClientByClientID(ctx, b.db, c.Id())
+	if !ok {
+		return fmt.Errorf("%w: client(%s) not found", oautherr.ErrUnknown, c.Id())
+	}
+
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tokens := tx.Model(&AccessToken{}).Select("id").Where("client_id = ?", clientModel.ID)
+		codes := tx.Model(&AuthorizationCode{}).Select("id").Where("client_id = ?", clientModel.ID)
+		deviceCodes := tx.Model(&DeviceCode{}).Select("id").Where("client_id = ?", clientModel.ID)
+		backchannels := tx.Model(&BackchannelAuthentication{}).Select("id").Where("client_id = ?", clientModel.ID)
+		consents := tx.Model(&Consent{}).Select("id").Where("client_id = ?", clientModel.ID)
+
+		if err := tx.Where("access_token_id IN (?)", tokens).Delete(&RefreshToken{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM users.oauth2_token_scope WHERE token_id IN (?)", tokens).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("client_id = ?", clientModel.ID).Delete(&AccessToken{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM users.oauth2_code_scope WHERE code_id IN (?)", codes).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("client_id = ?", clientModel.ID).Delete(&AuthorizationCode{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("client_id = ?", clientModel.ID).Delete(&PushedRequest{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM users.oauth2_device_code_scope WHERE device_code_id IN (?)", deviceCodes).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("client_id = ?", clientModel.ID).Delete(&DeviceCode{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM users.oauth2_backchannel_authentication_scope WHERE backchannel_authentication_id IN (?)", backchannels).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("client_id = ?", clientModel.ID).Delete(&BackchannelAuthentication{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM users.oauth2_consent_scope WHERE consent_id IN (?)", consents).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("client_id = ?", clientModel.ID).Delete(&Consent{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(clientModel).Association("Scopes").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(clientModel).Error
+	})
+	if cache, caching := ctx.Value(clientCacheName).(*clientCache); caching {
+		delete(cache.client, c.Id())
+	}
+	return err
 }
@@ -82,16 +82,22 @@ func (b *AuthCodeGormBride) Save(ctx context.Context, cd *authorization.Code) er
 	})
 
 	authCodeModel := &AuthorizationCode{
-		Value:               cd.Value(),
-		ClientID:            clientModel.ID,
-		Username:            cd.Username(),
-		State:               cd.State(),
-		Redirect:            cd.Redirect(),
-		Scopes:              scopes,
-		CodeChallenge:       cd.CodeChallenge(),
-		CodeChallengeMethod: cd.CodeChallengeMethod(),
-		IssuedAt:            cd.Start(),
-		ExpiredAt:           cd.End(),
+		Value:                cd.Value(),
+		ClientID:             clientModel.ID,
+		Username:             cd.Username(),
+		State:                cd.State(),
+		Redirect:             cd.Redirect(),
+		Scopes:               scopes,
+		CodeChallenge:        cd.CodeChallenge(),
+		CodeChallengeMethod:  cd.CodeChallengeMethod(),
+		Nonce:                cd.Nonce(),
+		AuthTime:             toNullTime(cd.AuthTime()),
+		ACR:                  cd.ACR(),
+		AMR:                  cd.AMR(),
+		Resources:            cd.Resources(),
+		AuthorizationDetails: toDetailsJSON(cd.AuthorizationDetails()),
+		IssuedAt:             cd.Start(),
+		ExpiredAt:            cd.End(),
 	}
 
 	return SaveAuthCode(ctx, b.db, authCodeModel)
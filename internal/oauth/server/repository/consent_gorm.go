@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"oauth-server-go/internal/config/log"
+	"oauth-server-go/internal/oauth/authorization"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/pkg/array"
+)
+
+// FindConsent Gorm을 이용하여 데이터베이스에서 자원 소유자가 클라이언트에게 한 동의를 조회한다.
+//
+// Returns:
+//   - *Consent: 조회된 동의 모델
+//   - bool: 조회 성공 여부
+func FindConsent(ctx context.Context, db *gorm.DB, username string, clientID uint) (*Consent, bool) {
+	var c Consent
+	if err := db.WithContext(ctx).Joins("Client").Preload("Client.Scopes").Preload("Scopes").Where(&Consent{Username: username, ClientID: clientID}).First(&c).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Sugared().Errorf("error occurred during select consent(%s, %d): %v", username, clientID, err)
+		}
+		return nil, false
+	}
+	return &c, true
+}
+
+// FindConsentByUsername Gorm을 이용하여 데이터베이스에서 자원 소유자가 한 동의를 모두 조회한다.
+func FindConsentByUsername(ctx context.Context, db *gorm.DB, username string) []Consent {
+	var consents []Consent
+	if err := db.WithContext(ctx).Joins("Client").Preload("Client.Scopes").Preload("Scopes").Where(&Consent{Username: username}).Find(&consents).Error; err != nil {
+		log.Sugared().Errorf("error occurred during select consent(%s): %v", username, err)
+	}
+	return consents
+}
+
+// deleteConsent Gorm을 이용하여 데이터베이스에서 동의와 동의한 스코프 연관 관계를 삭제한다.
+func deleteConsent(tx *gorm.DB, model *Consent) error {
+	if err := tx.Model(model).Association("Scopes").Clear(); err != nil {
+		return err
+	}
+	return tx.Delete(model).Error
+}
+
+// ConsentGormBridge 자원 소유자의 동의 도메인을 Gorm을 이용해 데이터베이스에 CRUD 할 수 있도록 변환 및 연결 작업을 하는 객체
+type ConsentGormBridge struct {
+	db *gorm.DB
+}
+
+func NewConsentGormBridge(db *gorm.DB) *ConsentGormBridge {
+	return &ConsentGormBridge{db: db}
+}
+
+// FindByUsernameAndClientID Gorm을 이용해 데이터베이스에서 동의를 조회하고 이를 도메인 모델로 변환하여 반환한다.
+func (b *ConsentGormBridge) FindByUsernameAndClientID(ctx context.Context, username, clientID string) (*authorization.Consent, bool) {
+	clientModel, ok := FindClientByClientID(ctx, b.db, clientID)
+	if !ok {
+		return nil, false
+	}
+	if model, ok := FindConsent(ctx, b.db, username, clientModel.ID); ok {
+		return model.Domain(), true
+	} else {
+		return nil, false
+	}
+}
+
+// FindByUsername Gorm을 이용해 데이터베이스에서 자원 소유자가 한 동의를 모두 조회하고 이를 도메인 모델로 변환하여 반환한다.
+func (b *ConsentGormBridge) FindByUsername(ctx context.Context, username string) []*authorization.Consent {
+	consents := FindConsentByUsername(ctx, b.db, username)
+	return array.Map(consents, func(e Consent) *authorization.Consent {
+		return e.Domain()
+	})
+}
+
+// Save Gorm을 이용해 데이터베이스에 동의를 저장한다.
+// 자원 소유자와 클라이언트의 유니크 제약 조건이 있으므로 이미 저장된 동의는 삭제 후 새로 저장한다.
+func (b *ConsentGormBridge) Save(ctx context.Context, c *authorization.Consent) error {
+	clientModel, ok := FindClientByClientID(ctx, b.db, c.Client().Id())
+	if !ok {
+		return fmt.Errorf("%w: client(%s) not found", oautherr.ErrInvalidClient, c.Client().Id())
+	}
+
+	model := &Consent{
+		Username:  c.Username(),
+		ClientID:  clientModel.ID,
+		Scopes:    filterScopes(clientModel.Scopes, c.Scopes()),
+		GrantedAt: c.Start(),
+		ExpiresAt: c.End(),
+	}
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if saved, ok := FindConsent(ctx, tx, c.Username(), clientModel.ID); ok {
+			if err := deleteConsent(tx, saved); err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Client", "Scopes.*").Create(model).Error
+	})
+}
+
+// Delete Gorm을 이용해 데이터베이스에서 동의를 삭제한다.
+func (b *ConsentGormBridge) Delete(ctx context.Context, c *authorization.Consent) error {
+	clientModel, ok := FindClientByClientID(ctx, b.db, c.Client().Id())
+	if !ok {
+		return fmt.Errorf("%w: client(%s) not found", oautherr.ErrUnknown, c.Client().Id())
+	}
+	model, ok := FindConsent(ctx, b.db, c.Username(), clientModel.ID)
+	if !ok {
+		return fmt.Errorf("%w: consent(%s, %s) not found", oautherr.ErrUnknown, c.Username(), c.Client().Id())
+	}
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deleteConsent(tx, model)
+	})
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"oauth-server-go/internal/config/log"
+	"oauth-server-go/internal/oauth/detail"
+)
+
+// AuthorizationDetailTypeGormBridge Gorm을 이용해 인가 상세 정보 타입을 데이터베이스에서 조회 할 수 있도록 변환 및 연결 작업을 하는 객체
+type AuthorizationDetailTypeGormBridge struct {
+	db *gorm.DB
+}
+
+func NewAuthorizationDetailTypeGormBridge(db *gorm.DB) *AuthorizationDetailTypeGormBridge {
+	return &AuthorizationDetailTypeGormBridge{db: db}
+}
+
+// FindByType Gorm을 이용해 데이터베이스에서 타입 이름으로 인가 상세 정보 타입을 조회한다.
+// 저장된 스키마를 변환 할 수 없는 타입은 조회되지 않은 것으로 처리한다.
+func (b *AuthorizationDetailTypeGormBridge) FindByType(ctx context.Context, name string) (*detail.Type, bool) {
+	var t AuthorizationDetailType
+	if err := b.db.WithContext(ctx).Where(&AuthorizationDetailType{Name: name}).First(&t).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Sugared().Errorf("error occurred during select authorization detail type(%s): %v", name, err)
+		}
+		return nil, false
+	}
+
+	domain, err := t.Domain()
+	if err != nil {
+		log.Sugared().Errorf("authorization detail type(%s) has invalid schema: %v", name, err)
+		return nil, false
+	}
+	return domain, true
+}
+
+// FindAll Gorm을 이용해 데이터베이스에 등록된 모든 인가 상세 정보 타입을 조회한다.
+// 저장된 스키마를 변환 할 수 없는 타입은 제외한다.
+func (b *AuthorizationDetailTypeGormBridge) FindAll(ctx context.Context) []*detail.Type {
+	var models []AuthorizationDetailType
+	if err := b.db.WithContext(ctx).Order("detail_type").Find(&models).Error; err != nil {
+		log.Sugared().Errorf("error occurred during select authorization detail types: %v", err)
+		return nil
+	}
+
+	results := make([]*detail.Type, 0, len(models))
+	for _, m := range models {
+		domain, err := m.Domain()
+		if err != nil {
+			log.Sugared().Errorf("authorization detail type(%s) has invalid schema: %v", m.Name, err)
+			continue
+		}
+		results = append(results, domain)
+	}
+	return results
+}
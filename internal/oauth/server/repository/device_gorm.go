@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"oauth-server-go/internal/config/log"
+	"oauth-server-go/internal/oauth/authorization"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/pkg/array"
+	"oauth-server-go/pkg/sql"
+	"slices"
+	"time"
+)
+
+// FindDeviceCode Gorm을 이용하여 데이터베이스에서 조건에 맞는 디바이스 인가 요청을 조회한다.
+//
+// Returns:
+//   - *DeviceCode: 조회된 디바이스 인가 요청 모델
+//   - bool: 조회 성공 여부
+func FindDeviceCode(ctx context.Context, db *gorm.DB, cond *DeviceCode) (*DeviceCode, bool) {
+	var d DeviceCode
+	if err := db.WithContext(ctx).Joins("Client").Preload("Client.Scopes").Preload("Scopes").Where(cond).First(&d).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Sugared().Errorf("error occurred during select device code(%v): %v", cond, err)
+		}
+		return nil, false
+	}
+	return &d, true
+}
+
+// DeviceCodeGormBridge OAuth2 디바이스 인가 요청 도메인을 Gorm을 이용해 데이터베이스에 CRUD 할 수 있도록 변환 및 연결 작업을 하는 객체
+type DeviceCodeGormBridge struct {
+	db *gorm.DB
+}
+
+func NewDeviceCodeGormBridge(db *gorm.DB) *DeviceCodeGormBridge {
+	return &DeviceCodeGormBridge{db: db}
+}
+
+// FindByDeviceCode Gorm을 이용해 데이터베이스에서 디바이스 인가 요청을 조회하고 이를 도메인 모델로 변환하여 반환한다.
+func (b *DeviceCodeGormBridge) FindByDeviceCode(ctx context.Context, deviceCode string) (*authorization.DeviceCode, bool) {
+	if deviceCodeModel, ok := FindDeviceCode(ctx, b.db, &DeviceCode{DeviceCode: deviceCode}); ok {
+		return deviceCodeModel.Domain(), true
+	} else {
+		return nil, false
+	}
+}
+
+// FindByUserCode Gorm을 이용해 데이터베이스에서 디바이스 인가 요청을 조회하고 이를 도메인 모델로 변환하여 반환한다.
+func (b *DeviceCodeGormBridge) FindByUserCode(ctx context.Context, userCode string) (*authorization.DeviceCode, bool) {
+	if deviceCodeModel, ok := FindDeviceCode(ctx, b.db, &DeviceCode{UserCode: userCode}); ok {
+		return deviceCodeModel.Domain(), true
+	} else {
+		return nil, false
+	}
+}
+
+// Save Gorm을 이용해 데이터베이스에 디바이스 인가 요청을 저장한다.
+func (b *DeviceCodeGormBridge) Save(ctx context.Context, d *authorization.DeviceCode) error {
+	clientModel, ok := FindClientByClientID(ctx, b.db, d.Client().Id())
+	if !ok {
+		return fmt.Errorf("%w: client(%s) not found", oautherr.ErrInvalidClient, d.Client().Id())
+	}
+
+	deviceCodeModel := &DeviceCode{
+		DeviceCode:      d.DeviceCode(),
+		UserCode:        d.UserCode(),
+		ClientID:        clientModel.ID,
+		Username:        d.Username(),
+		Scopes:          filterScopes(clientModel.Scopes, d.Scopes()),
+		Status:          d.Status(),
+		PollingInterval: int(d.Interval() / time.Second),
+		LastPolledAt:    toNullTime(d.LastPolledAt()),
+		AuthTime:        toNullTime(d.AuthTime()),
+		ACR:             d.ACR(),
+		AMR:             d.AMR(),
+		IssuedAt:        d.Start(),
+		ExpiredAt:       d.End(),
+	}
+	return b.db.WithContext(ctx).Omit("Client", "Scopes.*").Create(deviceCodeModel).Error
+}
+
+// Update Gorm을 이용해 데이터베이스에 저장된 디바이스 인가 요청의 상태와 폴링 정보, 승인된 스코프와 자원 소유자의 인증 정보를 갱신한다.
+//
+// 승인 된 스코프는 요청한 스코프의 일부이므로 스코프 수가 달라진 경우에만 스코프 연관 관계를 교체한다.
+func (b *DeviceCodeGormBridge) Update(ctx context.Context, d *authorization.DeviceCode) error {
+	deviceCodeModel, ok := FindDeviceCode(ctx, b.db, &DeviceCode{DeviceCode: d.DeviceCode()})
+	if !ok {
+		return fmt.Errorf("%w: device code(%s) not found", oautherr.ErrUnknown, d.DeviceCode())
+	}
+
+	updates := map[string]any{
+		"username":         d.Username(),
+		"device_status":    d.Status(),
+		"polling_interval": int(d.Interval() / time.Second),
+		"last_polled_at":   toNullTime(d.LastPolledAt()),
+		"auth_time":        toNullTime(d.AuthTime()),
+		"acr":              d.ACR(),
+		"amr":              sql.Strings(d.AMR()),
+	}
+	scopes := filterScopes(deviceCodeModel.Scopes, d.Scopes())
+
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(deviceCodeModel).Omit("Client", "Scopes").Updates(updates).Error; err != nil {
+			return err
+		}
+		if len(scopes) == len(deviceCodeModel.Scopes) {
+			return nil
+		}
+		return tx.Model(deviceCodeModel).Omit("Scopes.*").Association("Scopes").Replace(scopes)
+	})
+}
+
+// Delete Gorm을 이용해 데이터베이스에서 디바이스 인가 요청을 삭제한다.
+func (b *DeviceCodeGormBridge) Delete(ctx context.Context, d *authorization.DeviceCode) error {
+	deviceCodeModel, ok := FindDeviceCode(ctx, b.db, &DeviceCode{DeviceCode: d.DeviceCode()})
+	if !ok {
+		return fmt.Errorf("%w: device code(%s) not found", oautherr.ErrUnknown, d.DeviceCode())
+	}
+
+	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(deviceCodeModel).Association("Scopes").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(deviceCodeModel).Error
+	})
+}
+
+// filterScopes 스코프 데이터 모델 중 코드가 codes 에 포함된 스코프만 반환한다.
+func filterScopes(scopes ScopeArray, codes []string) ScopeArray {
+	return array.FilterFunc(scopes, func(s Scope) bool {
+		return slices.Contains(codes, s.Code)
+	})
+}
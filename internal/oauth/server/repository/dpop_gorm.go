@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"oauth-server-go/internal/oauth/token"
+	"time"
+)
+
+// DPoPProofGormBridge 사용된 DPoP 증명을 Gorm을 이용해 데이터베이스에 저장 할 수 있도록 변환 및 연결 작업을 하는 객체
+type DPoPProofGormBridge struct {
+	db *gorm.DB
+}
+
+func NewDPoPProofGormBridge(db *gorm.DB) *DPoPProofGormBridge {
+	return &DPoPProofGormBridge{db: db}
+}
+
+// SaveIfAbsent Gorm을 이용해 사용된 DPoP 증명을 저장한다.
+// 공개키와 증명 식별자(jkt, jti)의 유니크 제약 조건으로 이미 사용된 증명인지 확인하며 만료된 증명은 함께 삭제한다.
+func (b *DPoPProofGormBridge) SaveIfAbsent(ctx context.Context, proof *token.DPoPProof, expiredAt time.Time) (bool, error) {
+	db := b.db.WithContext(ctx)
+	if err := db.Where("expired_at < ?", time.Now()).Delete(&DPoPProof{}).Error; err != nil {
+		return false, err
+	}
+
+	proofModel := &DPoPProof{
+		JKT:       proof.JKT(),
+		JTI:       proof.JTI(),
+		ExpiredAt: expiredAt,
+	}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(proofModel)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"oauth-server-go/internal/oauth/authorization"
 	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/detail"
+	"oauth-server-go/internal/oauth/key"
+	"oauth-server-go/internal/oauth/resource"
 	"oauth-server-go/internal/oauth/scope"
 	"oauth-server-go/internal/oauth/token"
+	"time"
 )
 
 type cacheKey string
@@ -32,6 +36,12 @@ type TokenRepository interface {
 	//		- []*token.AccessToken: 유저 아이디로 발급된 엑세스 토큰 리스트
 	FindAccessTokenByUsername(ctx context.Context, username string) []token.AccessToken
 
+	// FindRefreshTokenByUsername 인자로 받은 유저 아이디로 발급된 리플레시 토큰을 조회한다.
+	//
+	// Returns:
+	//		- []token.RefreshToken: 유저 아이디로 발급된 리플레시 토큰 리스트
+	FindRefreshTokenByUsername(ctx context.Context, username string) []token.RefreshToken
+
 	// SaveAccessToken 저장소에 엑세스 토큰을 저장한다.
 	SaveAccessToken(ctx context.Context, accessToken *token.AccessToken) error
 
@@ -44,6 +54,10 @@ type TokenRepository interface {
 	// DeleteRefreshToken 저장소에서 리플레시 토큰을 삭제한다.
 	DeleteRefreshToken(ctx context.Context, refreshToken *token.RefreshToken) error
 
+	// DeleteConsent 저장소에서 자원 소유자가 클라이언트에게 한 동의를 삭제한다. 저장된 동의가 없는 경우 아무런 처리도 하지 않는다.
+	// 자원 소유자가 클라이언트의 접근 권한을 취소 할 때 동의와 토큰을 하나의 트랜잭션에서 삭제 할 수 있도록 토큰 저장소에서도 제공한다.
+	DeleteConsent(ctx context.Context, username, clientID string) error
+
 	// Transaction 트랜잭션을 수행한다.
 	// 트랜잭션을 생성하고 인자로 받은 함수를 실행시킨다.
 	// 함수가 모두 에러 없이 성공한 경우 커밋을 하며 하나라도 실패한 경우 롤백을 한다.
@@ -67,6 +81,117 @@ type AuthCodeRepository interface {
 	Delete(context.Context, *authorization.Code) error
 }
 
+// BackchannelAuthenticationRepository 백채널 인증 요청 저장소
+type BackchannelAuthenticationRepository interface {
+
+	// FindByAuthReqID 저장소에서 인증 요청 식별자로 백채널 인증 요청을 조회한다.
+	//
+	// Returns:
+	//	 - *authorization.BackchannelAuthentication: 조회된 백채널 인증 요청
+	//	 - bool: 조회 성공 여부
+	FindByAuthReqID(ctx context.Context, authReqID string) (*authorization.BackchannelAuthentication, bool)
+
+	// FindPendingByUsername 저장소에서 자원 소유자의 승인을 기다리는 만료되지 않은 백채널 인증 요청 목록을 조회한다.
+	FindPendingByUsername(ctx context.Context, username string) []*authorization.BackchannelAuthentication
+
+	// Save 백채널 인증 요청을 저장소에 저장한다.
+	Save(ctx context.Context, b *authorization.BackchannelAuthentication) error
+
+	// Update 저장소에 저장된 백채널 인증 요청의 상태와 폴링 정보, 승인된 스코프를 갱신한다.
+	Update(ctx context.Context, b *authorization.BackchannelAuthentication) error
+
+	// Delete 백채널 인증 요청을 저장소에서 삭제한다.
+	Delete(ctx context.Context, b *authorization.BackchannelAuthentication) error
+}
+
+// PushedRequestRepository 푸시된 인가 요청 저장소
+type PushedRequestRepository interface {
+
+	// FindByRequestURI 저장소에서 요청 URI로 푸시된 인가 요청을 조회한다.
+	//
+	// Returns:
+	//	 - *authorization.PushedRequest: 조회된 푸시된 인가 요청
+	//	 - bool: 조회 성공 여부
+	FindByRequestURI(ctx context.Context, requestURI string) (*authorization.PushedRequest, bool)
+
+	// Save 푸시된 인가 요청을 저장소에 저장한다.
+	Save(ctx context.Context, r *authorization.PushedRequest) error
+
+	// Delete 푸시된 인가 요청을 저장소에서 삭제한다.
+	Delete(ctx context.Context, r *authorization.PushedRequest) error
+}
+
+// ConsentRepository 자원 소유자의 동의 저장소
+type ConsentRepository interface {
+
+	// FindByUsernameAndClientID 저장소에서 자원 소유자가 클라이언트에게 한 동의를 조회한다.
+	//
+	// Returns:
+	//	 - *authorization.Consent: 조회된 동의
+	//	 - bool: 조회 성공 여부
+	FindByUsernameAndClientID(ctx context.Context, username, clientID string) (*authorization.Consent, bool)
+
+	// FindByUsername 저장소에서 자원 소유자가 한 모든 동의를 조회한다.
+	FindByUsername(ctx context.Context, username string) []*authorization.Consent
+
+	// Save 동의를 저장소에 저장한다. 자원 소유자가 클라이언트에게 한 동의가 이미 저장되어 있는 경우 새 동의로 교체한다.
+	Save(ctx context.Context, c *authorization.Consent) error
+
+	// Delete 동의를 저장소에서 삭제한다.
+	Delete(ctx context.Context, c *authorization.Consent) error
+}
+
+// DPoPProofRepository 사용된 DPoP 증명 저장소
+type DPoPProofRepository interface {
+
+	// SaveIfAbsent 사용된 DPoP 증명을 저장소에 저장한다. 같은 공개키로 같은 식별자(jti)를 가진 증명이 이미 저장되어 있는 경우 저장하지 않는다.
+	// 만료 시간이 지난 증명은 저장시 함께 삭제된다.
+	//
+	// Returns:
+	//	 - bool: 저장 여부. 이미 사용된 증명인 경우 false 를 반환한다.
+	//	 - error: 저장 중 발생한 에러
+	SaveIfAbsent(ctx context.Context, proof *token.DPoPProof, expiredAt time.Time) (bool, error)
+}
+
+// ClientAssertionRepository 사용된 클라이언트 어설션 저장소
+type ClientAssertionRepository interface {
+
+	// SaveIfAbsent 사용된 클라이언트 어설션을 저장소에 저장한다. 같은 클라이언트의 같은 식별자(jti)를 가진 어설션이 이미 저장되어 있는 경우 저장하지 않는다.
+	// 만료 시간이 지난 어설션은 저장시 함께 삭제된다.
+	//
+	// Returns:
+	//	 - bool: 저장 여부. 이미 사용된 어설션인 경우 false 를 반환한다.
+	//	 - error: 저장 중 발생한 에러
+	SaveIfAbsent(ctx context.Context, a *client.Assertion) (bool, error)
+}
+
+// DeviceCodeRepository 디바이스 인가 요청 저장소
+type DeviceCodeRepository interface {
+
+	// FindByDeviceCode 저장소에서 디바이스 코드로 디바이스 인가 요청을 조회한다.
+	//
+	// Returns:
+	//	 - *authorization.DeviceCode: 조회된 디바이스 인가 요청
+	//	 - bool: 조회 성공 여부
+	FindByDeviceCode(ctx context.Context, deviceCode string) (*authorization.DeviceCode, bool)
+
+	// FindByUserCode 저장소에서 사용자 코드로 디바이스 인가 요청을 조회한다.
+	//
+	// Returns:
+	//	 - *authorization.DeviceCode: 조회된 디바이스 인가 요청
+	//	 - bool: 조회 성공 여부
+	FindByUserCode(ctx context.Context, userCode string) (*authorization.DeviceCode, bool)
+
+	// Save 디바이스 인가 요청을 저장소에 저장한다.
+	Save(ctx context.Context, d *authorization.DeviceCode) error
+
+	// Update 저장소에 저장된 디바이스 인가 요청의 상태와 폴링 정보, 승인된 스코프를 갱신한다.
+	Update(ctx context.Context, d *authorization.DeviceCode) error
+
+	// Delete 디바이스 인가 요청을 저장소에서 삭제한다.
+	Delete(ctx context.Context, d *authorization.DeviceCode) error
+}
+
 // ScopeRepository 스코프 저장소
 type ScopeRepository interface {
 
@@ -74,6 +199,34 @@ type ScopeRepository interface {
 	FindByValue(ctx context.Context, value ...string) []scope.Scope
 }
 
+// ResourceServerRepository 자원 서버 저장소
+type ResourceServerRepository interface {
+
+	// FindByURI 저장소에서 자원 지시자로 자원 서버를 조회한다.
+	//
+	// Returns:
+	//	 - *resource.Server: 조회된 자원 서버
+	//	 - bool: 조회 성공 여부
+	FindByURI(ctx context.Context, uri string) (*resource.Server, bool)
+
+	// FindByClientID 저장소에서 클라이언트 아이디로 클라이언트가 사용하는 자원 서버 목록을 조회한다.
+	FindByClientID(ctx context.Context, clientID string) []*resource.Server
+}
+
+// AuthorizationDetailTypeRepository 인가 상세 정보 타입 저장소
+type AuthorizationDetailTypeRepository interface {
+
+	// FindByType 저장소에서 타입 이름으로 인가 상세 정보 타입을 조회한다.
+	//
+	// Returns:
+	//	 - *detail.Type: 조회된 인가 상세 정보 타입
+	//	 - bool: 조회 성공 여부
+	FindByType(ctx context.Context, name string) (*detail.Type, bool)
+
+	// FindAll 저장소에 등록된 모든 인가 상세 정보 타입을 조회한다.
+	FindAll(ctx context.Context) []*detail.Type
+}
+
 // ClientRepository 클라이언트 저장소
 type ClientRepository interface {
 
@@ -83,4 +236,29 @@ type ClientRepository interface {
 	//	 - *client.Client: 조회된 클라이언트
 	//	 - bool: 조회 성공 여부
 	FindByClientID(ctx context.Context, clientID string) (*client.Client, bool)
+
+	// Save 클라이언트를 저장소에 저장한다.
+	Save(ctx context.Context, c *client.Client) error
+
+	// Update 저장소에 저장된 클라이언트의 정보를 갱신한다.
+	Update(ctx context.Context, c *client.Client) error
+
+	// Delete 클라이언트를 저장소에서 삭제한다.
+	// 클라이언트에게 발급된 인가 코드, 엑세스 토큰, 리플레시 토큰과 자원 소유자의 동의도 함께 삭제한다.
+	Delete(ctx context.Context, c *client.Client) error
+}
+
+// KeyRepository 토큰 서명키 저장소
+type KeyRepository interface {
+
+	// FindAll 저장소에 저장된 모든 서명키를 조회한다.
+	FindAll(ctx context.Context) (key.Set, error)
+
+	// FindKids 저장소에 저장된 모든 서명키의 식별자(kid)를 조회한다.
+	// 개인키를 복호화 하지 않으므로 저장소의 서명키가 변경 되었는지 확인 할 때 사용한다.
+	FindKids(ctx context.Context) ([]string, error)
+
+	// Rotate 다른 서버 인스턴스가 동시에 교체 할 수 없도록 저장소를 잠근 후 저장된 서명키로 교체 계획(plan)을 세우고
+	// 변경 사항을 한번에 반영한다. 변경 사항이 반영된 서명키 목록과 반영된 변경 사항을 반환한다.
+	Rotate(ctx context.Context, plan func(keys key.Set) (*key.Plan, error)) (key.Set, *key.Plan, error)
 }
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"oauth-server-go/internal/oauth/key"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// keyFileExt 서명키 파일의 확장자
+const keyFileExt = ".json"
+
+// KeyFileBridge 토큰 서명키를 디스크에 저장하는 객체
+//
+// 서명키는 디렉토리 아래에 "<kid>.json" 파일로 하나씩 저장되며 개인키는 저장되기 전 secret으로 암호화 된다.
+// 데이터베이스와 달리 여러 서버 인스턴스간 공유가 되지 않으므로 단일 인스턴스로 운영 할 때 사용한다.
+type KeyFileBridge struct {
+	dir    string
+	secret string
+	mu     sync.Mutex
+}
+
+func NewKeyFileBridge(dir, secret string) (*KeyFileBridge, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &KeyFileBridge{dir: dir, secret: secret}, nil
+}
+
+// FindAll 디렉토리에 저장된 모든 서명키 파일을 읽어 도메인 모델로 변환하여 반환한다.
+func (b *KeyFileBridge) FindAll(_ context.Context) (key.Set, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.findAll()
+}
+
+// FindKids 디렉토리에 저장된 서명키 파일의 이름으로 모든 서명키의 식별자(kid)를 반환한다.
+func (b *KeyFileBridge) FindKids(_ context.Context) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entries, err := os.ReadDir(b.dir)
+	if err != nil {
+		return nil, err
+	}
+	var kids []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), keyFileExt) {
+			continue
+		}
+		kids = append(kids, strings.TrimSuffix(entry.Name(), keyFileExt))
+	}
+	return kids, nil
+}
+
+// Rotate 다른 요청이 서명키 파일에 접근하지 못하도록 잠근 상태에서 서명키를 읽어 교체 계획을 세우고 변경 사항을 반영한다.
+// 파일은 하나씩 반영 되므로 반영 도중 실패한 경우 일부 변경 사항만 남을 수 있지만 다음 교체시 저장된 서명키를 다시 읽어 교체 계획을 세운다.
+func (b *KeyFileBridge) Rotate(_ context.Context, plan func(keys key.Set) (*key.Plan, error)) (key.Set, *key.Plan, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	found, err := b.findAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err := plan(found)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, k := range p.Created {
+		if err := b.save(k); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, k := range p.Updated {
+		if err := b.update(k); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, k := range p.Deleted {
+		if err := b.delete(k); err != nil {
+			return nil, nil, err
+		}
+	}
+	return p.Apply(found), p, nil
+}
+
+// Save 서명키를 파일로 저장한다.
+func (b *KeyFileBridge) Save(_ context.Context, k *key.Key) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.save(k)
+}
+
+// Update 파일에 저장된 서명키의 상태, 활성화 및 퇴역 시간을 갱신한다.
+func (b *KeyFileBridge) Update(_ context.Context, k *key.Key) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.update(k)
+}
+
+// Delete 서명키 파일을 삭제한다.
+func (b *KeyFileBridge) Delete(_ context.Context, k *key.Key) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.delete(k)
+}
+
+func (b *KeyFileBridge) findAll() (key.Set, error) {
+	entries, err := os.ReadDir(b.dir)
+	if err != nil {
+		return nil, err
+	}
+	var entities []SigningKey
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), keyFileExt) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(b.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var entity SigningKey
+		if err := json.Unmarshal(data, &entity); err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].CreatedAt.Before(entities[j].CreatedAt)
+	})
+
+	keys := make(key.Set, 0, len(entities))
+	for _, entity := range entities {
+		k, err := entity.Domain(b.secret)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func (b *KeyFileBridge) save(k *key.Key) error {
+	entity, err := NewSigningKey(b.secret, k)
+	if err != nil {
+		return err
+	}
+	return b.write(entity)
+}
+
+func (b *KeyFileBridge) update(k *key.Key) error {
+	data, err := os.ReadFile(b.path(k.Id()))
+	if err != nil {
+		return err
+	}
+	var entity SigningKey
+	if err := json.Unmarshal(data, &entity); err != nil {
+		return err
+	}
+	entity.State = k.State()
+	entity.ActivatedAt = toNullTime(k.ActivatedAt())
+	entity.RetiredAt = toNullTime(k.RetiredAt())
+	return b.write(&entity)
+}
+
+func (b *KeyFileBridge) delete(k *key.Key) error {
+	if err := os.Remove(b.path(k.Id())); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// write 임시 파일에 먼저 기록한 후 이름을 변경하여 쓰는 도중 파일이 손상되지 않도록 한다.
+func (b *KeyFileBridge) write(entity *SigningKey) error {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(b.dir, entity.Kid+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), b.path(entity.Kid))
+}
+
+func (b *KeyFileBridge) path(kid string) string {
+	return filepath.Join(b.dir, filepath.Base(kid)+keyFileExt)
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/key"
+	"testing"
+	"time"
+)
+
+// testKeySecret 테스트용으로 사용할 개인키 암호화 비밀키
+const testKeySecret = "test_key_secret"
+
+func TestKeyFileBridge(t *testing.T) {
+	tests := []struct {
+		name string
+		alg  key.Algorithm
+	}{
+		{name: "RS256 서명키 저장 후 조회", alg: key.AlgorithmRS256},
+		{name: "PS256 서명키 저장 후 조회", alg: key.AlgorithmPS256},
+		{name: "ES256 서명키 저장 후 조회", alg: key.AlgorithmES256},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			b, err := NewKeyFileBridge(t.TempDir(), testKeySecret)
+			assert.Nil(t, err)
+
+			k, err := key.Generate(tc.alg, func() string { return "test_kid" })
+			assert.Nil(t, err)
+			k.Activate(time.Now().Truncate(time.Second))
+			assert.Nil(t, b.Save(ctx, k))
+
+			keys, err := b.FindAll(ctx)
+			assert.Nil(t, err)
+			assert.Len(t, keys, 1)
+			assert.Equal(t, k.Id(), keys[0].Id())
+			assert.Equal(t, k.Algorithm(), keys[0].Algorithm())
+			assert.Equal(t, k.State(), keys[0].State())
+			assert.True(t, k.ActivatedAt().Equal(keys[0].ActivatedAt()))
+			assert.Equal(t, k.Public(), keys[0].Public())
+
+			signed, err := keys[0].Sign("at+jwt", map[string]string{"sub": "test_username"})
+			assert.Nil(t, err)
+			assert.NotEmpty(t, signed)
+		})
+	}
+
+	t.Run("다른 비밀키로 조회하는 경우 에러 발생", func(t *testing.T) {
+		ctx := context.Background()
+		dir := t.TempDir()
+		b, err := NewKeyFileBridge(dir, testKeySecret)
+		assert.Nil(t, err)
+
+		k, err := key.Generate(key.AlgorithmES256, func() string { return "test_kid" })
+		assert.Nil(t, err)
+		assert.Nil(t, b.Save(ctx, k))
+
+		other, err := NewKeyFileBridge(dir, "wrong_secret")
+		assert.Nil(t, err)
+		keys, err := other.FindAll(ctx)
+		assert.NotNil(t, err)
+		assert.Nil(t, keys)
+	})
+
+	t.Run("서명키 상태 갱신 후 삭제", func(t *testing.T) {
+		ctx := context.Background()
+		b, err := NewKeyFileBridge(t.TempDir(), testKeySecret)
+		assert.Nil(t, err)
+
+		k, err := key.Generate(key.AlgorithmES256, func() string { return "test_kid" })
+		assert.Nil(t, err)
+		assert.Nil(t, b.Save(ctx, k))
+
+		k.Activate(time.Now())
+		k.Retire(time.Now())
+		assert.Nil(t, b.Update(ctx, k))
+
+		keys, err := b.FindAll(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, k.State(), keys[0].State())
+		assert.False(t, keys[0].RetiredAt().IsZero())
+
+		assert.Nil(t, b.Delete(ctx, k))
+		keys, err = b.FindAll(ctx)
+		assert.Nil(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("교체 계획을 반영하고 반영된 서명키 목록을 반환", func(t *testing.T) {
+		ctx := context.Background()
+		b, err := NewKeyFileBridge(t.TempDir(), testKeySecret)
+		assert.Nil(t, err)
+
+		retired, err := key.Generate(key.AlgorithmES256, func() string { return "retired_kid" })
+		assert.Nil(t, err)
+		retired.Retire(time.Now())
+		assert.Nil(t, b.Save(ctx, retired))
+
+		seq := 0
+		generate := func() string {
+			seq++
+			return fmt.Sprintf("kid_%d", seq)
+		}
+		rotation := &key.Rotation{Period: time.Hour, Retention: 0}
+		keys, plan, err := b.Rotate(ctx, func(keys key.Set) (*key.Plan, error) {
+			return rotation.Rotate(keys, []key.Algorithm{key.AlgorithmES256}, time.Now(), generate)
+		})
+		assert.Nil(t, err)
+		assert.Len(t, plan.Created, 2)
+		assert.Len(t, plan.Deleted, 1)
+		assert.Len(t, keys, 2)
+		assert.NotNil(t, keys.Active(key.AlgorithmES256))
+
+		kids, err := b.FindKids(ctx)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, keys.Kids(), kids)
+	})
+}
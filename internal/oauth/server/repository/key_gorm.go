@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"gorm.io/gorm"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/key"
+	"oauth-server-go/pkg/crypt"
+)
+
+// encryptPrivateKey 개인키를 PKCS #8 형식으로 직렬화 하고 인자로 받은 비밀키로 암호화 한다.
+func encryptPrivateKey(secret string, private crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error occurred during marshal private key: %v", oautherr.ErrUnknown, err)
+	}
+	encrypted, err := crypt.Encrypt(secret, der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error occurred during encrypt private key: %v", oautherr.ErrUnknown, err)
+	}
+	return encrypted, nil
+}
+
+// decryptPrivateKey [encryptPrivateKey] 로 암호화된 개인키를 복호화 한다.
+func decryptPrivateKey(secret string, encrypted []byte) (crypto.Signer, error) {
+	der, err := crypt.Decrypt(secret, encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error occurred during decrypt private key: %v", oautherr.ErrUnknown, err)
+	}
+	private, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error occurred during parse private key: %v", oautherr.ErrUnknown, err)
+	}
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported private key type(%T)", oautherr.ErrUnknown, private)
+	}
+	return signer, nil
+}
+
+// KeyGormBridge 토큰 서명키를 Gorm을 이용해 데이터베이스에 CRUD 할 수 있도록 변환 및 연결 작업을 하는 객체
+// 개인키는 데이터베이스에 저장되기 전 secret으로 암호화 된다.
+type KeyGormBridge struct {
+	db     *gorm.DB
+	secret string
+}
+
+func NewKeyGormBridge(db *gorm.DB, secret string) *KeyGormBridge {
+	return &KeyGormBridge{db: db, secret: secret}
+}
+
+// FindAll 데이터베이스에 저장된 모든 서명키를 조회하고 도메인 모델로 변환하여 반환한다.
+func (b *KeyGormBridge) FindAll(ctx context.Context) (key.Set, error) {
+	return b.findAll(b.db.WithContext(ctx))
+}
+
+// FindKids 데이터베이스에 저장된 모든 서명키의 식별자(kid)를 조회한다.
+func (b *KeyGormBridge) FindKids(ctx context.Context) ([]string, error) {
+	var kids []string
+	if err := b.db.WithContext(ctx).Model(&SigningKey{}).Order("id").Pluck("kid", &kids).Error; err != nil {
+		return nil, err
+	}
+	return kids, nil
+}
+
+// Rotate 하나의 트랜잭션 안에서 서명키 테이블에 대한 Postgres advisory lock 을 획득한 후 서명키를 조회하여 교체 계획을 세우고 변경 사항을 반영한다.
+// 잠금은 트랜잭션이 종료될 때 해제 되므로 여러 서버 인스턴스가 동시에 교체를 시도하더라도 한 인스턴스의 교체가 끝난 후에 다음 인스턴스가
+// 변경된 서명키를 다시 읽어 교체 계획을 세운다. 변경 사항 중 하나라도 반영에 실패하면 모든 변경 사항이 롤백된다.
+func (b *KeyGormBridge) Rotate(ctx context.Context, plan func(keys key.Set) (*key.Plan, error)) (key.Set, *key.Plan, error) {
+	var keys key.Set
+	var p *key.Plan
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("select pg_advisory_xact_lock(hashtext(?))", (&SigningKey{}).TableName()).Error; err != nil {
+			return err
+		}
+		found, err := b.findAll(tx)
+		if err != nil {
+			return err
+		}
+		if p, err = plan(found); err != nil {
+			return err
+		}
+		for _, k := range p.Created {
+			if err := b.save(tx, k); err != nil {
+				return err
+			}
+		}
+		for _, k := range p.Updated {
+			if err := b.update(tx, k); err != nil {
+				return err
+			}
+		}
+		for _, k := range p.Deleted {
+			if err := b.delete(tx, k); err != nil {
+				return err
+			}
+		}
+		keys = p.Apply(found)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys, p, nil
+}
+
+// Save 서명키를 데이터베이스에 저장한다.
+func (b *KeyGormBridge) Save(ctx context.Context, k *key.Key) error {
+	return b.save(b.db.WithContext(ctx), k)
+}
+
+// Update 데이터베이스에 저장된 서명키의 상태, 활성화 및 퇴역 시간을 갱신한다.
+func (b *KeyGormBridge) Update(ctx context.Context, k *key.Key) error {
+	return b.update(b.db.WithContext(ctx), k)
+}
+
+// Delete 데이터베이스에서 서명키를 삭제한다.
+func (b *KeyGormBridge) Delete(ctx context.Context, k *key.Key) error {
+	return b.delete(b.db.WithContext(ctx), k)
+}
+
+func (b *KeyGormBridge) findAll(db *gorm.DB) (key.Set, error) {
+	var entities []SigningKey
+	if err := db.Order("id").Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	keys := make(key.Set, 0, len(entities))
+	for _, entity := range entities {
+		k, err := entity.Domain(b.secret)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func (b *KeyGormBridge) save(db *gorm.DB, k *key.Key) error {
+	entity, err := NewSigningKey(b.secret, k)
+	if err != nil {
+		return err
+	}
+	return db.Create(entity).Error
+}
+
+func (b *KeyGormBridge) update(db *gorm.DB, k *key.Key) error {
+	return db.Model(&SigningKey{}).
+		Where(&SigningKey{Kid: k.Id()}).
+		Updates(map[string]any{
+			"key_state":    k.State(),
+			"activated_at": toNullTime(k.ActivatedAt()),
+			"retired_at":   toNullTime(k.RetiredAt()),
+		}).Error
+}
+
+func (b *KeyGormBridge) delete(db *gorm.DB, k *key.Key) error {
+	return db.Where(&SigningKey{Kid: k.Id()}).Delete(&SigningKey{}).Error
+}
@@ -1,8 +1,13 @@
 package repository
 
 import (
+	"encoding/json"
+	"github.com/go-jose/go-jose/v4"
 	"oauth-server-go/internal/oauth/authorization"
 	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/detail"
+	"oauth-server-go/internal/oauth/key"
+	"oauth-server-go/internal/oauth/resource"
 	"oauth-server-go/internal/oauth/scope"
 	"oauth-server-go/internal/oauth/token"
 	"oauth-server-go/pkg/period"
@@ -36,6 +41,55 @@ func (s ScopeArray) Array() []string {
 	return scopes
 }
 
+// ResourceServer 자원 서버 데이터 모델
+type ResourceServer struct {
+	ID           uint
+	URI          string     `gorm:"column:resource_uri"`
+	Name         string     `gorm:"column:resource_name"`
+	Scopes       ScopeArray `gorm:"many2many:users.oauth2_resource_server_scope;joinForeignKey:resource_server_id;joinReferences:scope_id"`
+	ClientID     *string    `gorm:"column:client_id"`
+	RegisteredAt time.Time  `gorm:"column:reg_at"`
+}
+
+func (entity *ResourceServer) TableName() string {
+	return "users.oauth2_resource_server"
+}
+
+// Domain 데이터 모델을 도메인 모델로 변경 한다.
+func (entity *ResourceServer) Domain() *resource.Server {
+	s := resource.New(entity.URI, entity.Name, entity.Scopes.Array())
+	if entity.ClientID != nil {
+		s.SetClientID(*entity.ClientID)
+	}
+	return s
+}
+
+// AuthorizationDetailType 인가 상세 정보 타입 데이터 모델
+// 스키마는 JSON 문자열로 저장된다.
+type AuthorizationDetailType struct {
+	ID           uint
+	Name         string    `gorm:"column:detail_type"`
+	Description  string    `gorm:"column:description"`
+	Schema       *string   `gorm:"column:detail_schema"`
+	RegisteredAt time.Time `gorm:"column:reg_at"`
+}
+
+func (entity *AuthorizationDetailType) TableName() string {
+	return "users.oauth2_authorization_detail_type"
+}
+
+// Domain 데이터 모델을 도메인 모델로 변경 한다. 저장된 스키마를 변환 할 수 없는 경우 에러를 반환한다.
+func (entity *AuthorizationDetailType) Domain() (*detail.Type, error) {
+	if entity.Schema == nil {
+		return detail.NewType(entity.Name, entity.Description, nil), nil
+	}
+	schema, err := detail.ParseSchema(*entity.Schema)
+	if err != nil {
+		return nil, err
+	}
+	return detail.NewType(entity.Name, entity.Description, schema), nil
+}
+
 // Client OAuth2 클라이언트 데이터 모델
 type Client struct {
 	ID           uint
@@ -46,7 +100,32 @@ type Client struct {
 	OwnerID      string
 	Redirects    sql.Strings `gorm:"column:redirect_uris"`
 	Scopes       ScopeArray  `gorm:"many2many:users.oauth2_client_scope;joinForeignKey:client_id;joinReferences:scope_id"`
-	RegisteredAt time.Time   `gorm:"column:reg_at"`
+	TokenFormat  client.TokenFormat
+	RegisteredAt time.Time `gorm:"column:reg_at"`
+
+	GrantTypes        sql.Strings       `gorm:"column:grant_types"`
+	AuthMethod        client.AuthMethod `gorm:"column:token_endpoint_auth_method"`
+	LogoURI           string            `gorm:"column:logo_uri"`
+	Contacts          sql.Strings       `gorm:"column:contacts"`
+	RegistrationToken string            `gorm:"column:registration_token"`
+	JWTSecret         []byte            `gorm:"column:jwt_secret"`
+	RequirePAR        bool              `gorm:"column:require_pushed_authorization_requests"`
+	JWKSURI           string            `gorm:"column:jwks_uri"`
+	JWKS              *string           `gorm:"column:jwks"`
+	RequireSignedJAR  bool              `gorm:"column:require_signed_request_object"`
+	RequestURIs       sql.Strings       `gorm:"column:request_uris"`
+	TLSSubjectDN      string            `gorm:"column:tls_client_auth_subject_dn"`
+	TLSSANDNS         string            `gorm:"column:tls_client_auth_san_dns"`
+	CertificateBound  bool              `gorm:"column:tls_client_certificate_bound_access_tokens"`
+
+	BackchannelDeliveryMode    client.BackchannelTokenDeliveryMode `gorm:"column:backchannel_token_delivery_mode"`
+	BackchannelNotificationURI string                              `gorm:"column:backchannel_client_notification_endpoint"`
+
+	IntrospectionSignedAlg    jose.SignatureAlgorithm `gorm:"column:introspection_signed_response_alg"`
+	IntrospectionEncryptedAlg jose.KeyAlgorithm       `gorm:"column:introspection_encrypted_response_alg"`
+	IntrospectionEncryptedEnc jose.ContentEncryption  `gorm:"column:introspection_encrypted_response_enc"`
+
+	ConsentTTL int `gorm:"column:consent_ttl_sec"`
 }
 
 func (entity *Client) TableName() string {
@@ -65,24 +144,106 @@ func (entity *Client) Domain() *client.Client {
 		c.AddScope(s.Code)
 	}
 
+	c.SetOwner(entity.OwnerID)
+	c.SetTokenFormat(entity.TokenFormat)
 	c.SetRegisteredAt(entity.RegisteredAt)
+	c.SetGrantTypes(entity.GrantTypes)
+	c.SetAuthMethod(entity.AuthMethod)
+	c.SetLogoURI(entity.LogoURI)
+	c.SetContacts(entity.Contacts)
+	c.SetRegistrationToken(entity.RegistrationToken)
+	c.SetRequirePushedAuthorizationRequests(entity.RequirePAR)
+	c.SetJWKSURI(entity.JWKSURI)
+	c.SetJWKS(fromJWKSJSON(entity.JWKS))
+	c.SetRequireSignedRequestObject(entity.RequireSignedJAR)
+	c.SetRequestURIs(entity.RequestURIs)
+	c.SetTLSClientAuthSubjectDN(entity.TLSSubjectDN)
+	c.SetTLSClientAuthSANDNS(entity.TLSSANDNS)
+	c.SetCertificateBoundAccessTokens(entity.CertificateBound)
+	c.SetBackchannelTokenDeliveryMode(entity.BackchannelDeliveryMode)
+	c.SetBackchannelClientNotificationEndpoint(entity.BackchannelNotificationURI)
+	c.SetIntrospectionSignedResponseAlg(entity.IntrospectionSignedAlg)
+	c.SetIntrospectionEncryptedResponseAlg(entity.IntrospectionEncryptedAlg)
+	c.SetIntrospectionEncryptedResponseEnc(entity.IntrospectionEncryptedEnc)
+	c.SetConsentTTL(time.Duration(entity.ConsentTTL) * time.Second)
 
 	return c
 }
 
+// apply 도메인 모델의 값을 데이터 모델에 적용한다.
+// 스코프는 스코프 데이터 모델의 조회가 필요 하므로 따로 설정해야 한다.
+func (entity *Client) apply(c *client.Client) {
+	entity.ClientID = c.Id()
+	entity.Name = c.Name()
+	entity.Type = c.T()
+	entity.Secret = c.Secret()
+	entity.OwnerID = c.Owner()
+	entity.Redirects = c.Redirects()
+	entity.TokenFormat = c.TokenFormat()
+	entity.RegisteredAt = c.RegisteredAt()
+	entity.GrantTypes = c.GrantTypes()
+	entity.AuthMethod = c.AuthMethod()
+	entity.LogoURI = c.LogoURI()
+	entity.Contacts = c.Contacts()
+	entity.RegistrationToken = c.RegistrationToken()
+	entity.RequirePAR = c.RequirePushedAuthorizationRequests()
+	entity.JWKSURI = c.JWKSURI()
+	entity.JWKS = toJWKSJSON(c.JWKS())
+	entity.RequireSignedJAR = c.RequireSignedRequestObject()
+	entity.RequestURIs = c.RequestURIs()
+	entity.TLSSubjectDN = c.TLSClientAuthSubjectDN()
+	entity.TLSSANDNS = c.TLSClientAuthSANDNS()
+	entity.CertificateBound = c.CertificateBoundAccessTokens()
+	entity.BackchannelDeliveryMode = c.BackchannelTokenDeliveryMode()
+	entity.BackchannelNotificationURI = c.BackchannelClientNotificationEndpoint()
+	entity.IntrospectionSignedAlg = c.IntrospectionSignedResponseAlg()
+	entity.IntrospectionEncryptedAlg = c.IntrospectionEncryptedResponseAlg()
+	entity.IntrospectionEncryptedEnc = c.IntrospectionEncryptedResponseEnc()
+	entity.ConsentTTL = int(c.ConsentTTL() / time.Second)
+}
+
+// toJWKSJSON 클라이언트의 공개키 목록을 JSON 문자열로 변환한다. 공개키 목록이 없는 경우 nil 을 반환한다.
+// 공개키 목록은 클라이언트 등록시 JSON 으로 입력 받은 값이므로 변환에 실패하지 않는다.
+func toJWKSJSON(jwks *jose.JSONWebKeySet) *string {
+	if jwks == nil {
+		return nil
+	}
+	serial, _ := json.Marshal(jwks)
+	v := string(serial)
+	return &v
+}
+
+// fromJWKSJSON JSON 문자열을 공개키 목록으로 변환한다. 문자열이 nil 이거나 변환 할 수 없는 경우 nil 을 반환한다.
+func fromJWKSJSON(v *string) *jose.JSONWebKeySet {
+	if v == nil {
+		return nil
+	}
+	var jwks jose.JSONWebKeySet
+	if err := json.Unmarshal([]byte(*v), &jwks); err != nil {
+		return nil
+	}
+	return &jwks
+}
+
 // AuthorizationCode OAuth2 인가코드 데이터 모델
 type AuthorizationCode struct {
-	ID                  uint
-	Value               string `gorm:"column:code"`
-	ClientID            uint
-	Client              Client
-	Username            string
-	State               string
-	Redirect            string
-	Scopes              ScopeArray `gorm:"many2many:users.oauth2_code_scope;joinForeignKey:code_id;joinReferences:scope_id"`
-	CodeChallenge       authorization.Challenge
-	CodeChallengeMethod authorization.ChallengeMethod
-	IssuedAt, ExpiredAt time.Time
+	ID                   uint
+	Value                string `gorm:"column:code"`
+	ClientID             uint
+	Client               Client
+	Username             string
+	State                string
+	Redirect             string
+	Scopes               ScopeArray `gorm:"many2many:users.oauth2_code_scope;joinForeignKey:code_id;joinReferences:scope_id"`
+	CodeChallenge        authorization.Challenge
+	CodeChallengeMethod  authorization.ChallengeMethod
+	Nonce                string
+	AuthTime             *time.Time
+	ACR                  string      `gorm:"column:acr"`
+	AMR                  sql.Strings `gorm:"column:amr"`
+	Resources            sql.Strings `gorm:"column:resources"`
+	AuthorizationDetails *string     `gorm:"column:authorization_details"`
+	IssuedAt, ExpiredAt  time.Time
 }
 
 func (entity *AuthorizationCode) TableName() string {
@@ -106,23 +267,170 @@ func (entity *AuthorizationCode) Domain() *authorization.Code {
 		Redirect:            entity.Redirect,
 		CodeChallenge:       entity.CodeChallenge,
 		CodeChallengeMethod: entity.CodeChallengeMethod,
+		Nonce:               entity.Nonce,
+		AuthTime:            fromNullTime(entity.AuthTime),
+		ACR:                 entity.ACR,
+		AMR:                 entity.AMR,
+		Resource:            entity.Resources,
+
+		AuthorizationDetails: fromDetailsJSON(entity.AuthorizationDetails).String(),
 	}
 	_ = cd.CopyFrom(&request)
 
 	return cd
 }
 
-// AccessToken OAuth2 엑세스 토큰 데이터 모델
-type AccessToken struct {
+// Consent 자원 소유자의 동의 데이터 모델
+type Consent struct {
+	ID                   uint
+	Username             string
+	ClientID             uint
+	Client               Client
+	Scopes               ScopeArray `gorm:"many2many:users.oauth2_consent_scope;joinForeignKey:consent_id;joinReferences:scope_id"`
+	GrantedAt, ExpiresAt time.Time
+}
+
+func (entity *Consent) TableName() string {
+	return "users.oauth2_consent"
+}
+
+// Domain 데이터 모델을 도메인 모델로 변경 한다.
+func (entity *Consent) Domain() *authorization.Consent {
+	return authorization.NewConsentWithState(
+		entity.Username,
+		entity.Client.Domain(),
+		entity.Scopes.Array(),
+		period.NewWithStartEnd(entity.GrantedAt, entity.ExpiresAt),
+	)
+}
+
+// PushedRequest 푸시된 인가 요청 데이터 모델
+// 인가 요청은 JSON으로 직렬화 되어 저장된다.
+type PushedRequest struct {
 	ID                  uint
-	Value               string `gorm:"column:token"`
+	RequestURI          string `gorm:"column:request_uri"`
+	ClientID            uint
+	Request             string `gorm:"column:request"`
+	IssuedAt, ExpiredAt time.Time
+}
+
+func (entity *PushedRequest) TableName() string {
+	return "users.oauth2_pushed_request"
+}
+
+// Domain 데이터 모델을 도메인 모델로 변경 한다.
+func (entity *PushedRequest) Domain() (*authorization.PushedRequest, error) {
+	var request authorization.Request
+	if err := json.Unmarshal([]byte(entity.Request), &request); err != nil {
+		return nil, err
+	}
+	return authorization.NewPushedRequestWithState(entity.RequestURI, &request, period.NewWithStartEnd(entity.IssuedAt, entity.ExpiredAt)), nil
+}
+
+// DeviceCode OAuth2 디바이스 인가 요청 데이터 모델
+type DeviceCode struct {
+	ID                  uint
+	DeviceCode          string `gorm:"column:device_code"`
+	UserCode            string `gorm:"column:user_code"`
 	ClientID            uint
 	Client              Client
 	Username            string
-	Scopes              ScopeArray `gorm:"many2many:users.oauth2_token_scope;joinForeignKey:token_id;joinReferences:scope_id"`
+	Scopes              ScopeArray                 `gorm:"many2many:users.oauth2_device_code_scope;joinForeignKey:device_code_id;joinReferences:scope_id"`
+	Status              authorization.DeviceStatus `gorm:"column:device_status"`
+	PollingInterval     int                        `gorm:"column:polling_interval"`
+	LastPolledAt        *time.Time
+	AuthTime            *time.Time
+	ACR                 string      `gorm:"column:acr"`
+	AMR                 sql.Strings `gorm:"column:amr"`
 	IssuedAt, ExpiredAt time.Time
 }
 
+func (entity *DeviceCode) TableName() string {
+	return "users.oauth2_device_code"
+}
+
+// Domain 데이터 모델을 도메인 모델로 변경 한다.
+func (entity *DeviceCode) Domain() *authorization.DeviceCode {
+	return authorization.NewDeviceCodeWithState(
+		entity.DeviceCode,
+		entity.UserCode,
+		entity.Client.Domain(),
+		entity.Scopes.Array(),
+		entity.Username,
+		entity.Status,
+		time.Duration(entity.PollingInterval)*time.Second,
+		fromNullTime(entity.LastPolledAt),
+		fromNullTime(entity.AuthTime),
+		entity.ACR,
+		entity.AMR,
+		period.NewWithStartEnd(entity.IssuedAt, entity.ExpiredAt),
+	)
+}
+
+// BackchannelAuthentication OpenID CIBA 백채널 인증 요청 데이터 모델
+type BackchannelAuthentication struct {
+	ID                  uint
+	AuthReqID           string `gorm:"column:auth_req_id"`
+	ClientID            uint
+	Client              Client
+	Username            string
+	Scopes              ScopeArray                      `gorm:"many2many:users.oauth2_backchannel_authentication_scope;joinForeignKey:backchannel_authentication_id;joinReferences:scope_id"`
+	BindingMessage      string                          `gorm:"column:binding_message"`
+	NotificationToken   string                          `gorm:"column:client_notification_token"`
+	Status              authorization.BackchannelStatus `gorm:"column:backchannel_status"`
+	PollingInterval     int                             `gorm:"column:polling_interval"`
+	LastPolledAt        *time.Time
+	AuthTime            *time.Time
+	ACR                 string      `gorm:"column:acr"`
+	AMR                 sql.Strings `gorm:"column:amr"`
+	IssuedAt, ExpiredAt time.Time
+}
+
+func (entity *BackchannelAuthentication) TableName() string {
+	return "users.oauth2_backchannel_authentication"
+}
+
+// Domain 데이터 모델을 도메인 모델로 변경 한다.
+func (entity *BackchannelAuthentication) Domain() *authorization.BackchannelAuthentication {
+	return authorization.NewBackchannelAuthenticationWithState(
+		entity.AuthReqID,
+		entity.Client.Domain(),
+		entity.Username,
+		entity.Scopes.Array(),
+		entity.BindingMessage,
+		entity.NotificationToken,
+		entity.Status,
+		time.Duration(entity.PollingInterval)*time.Second,
+		fromNullTime(entity.LastPolledAt),
+		fromNullTime(entity.AuthTime),
+		entity.ACR,
+		entity.AMR,
+		period.NewWithStartEnd(entity.IssuedAt, entity.ExpiredAt),
+	)
+}
+
+// AccessToken OAuth2 엑세스 토큰 데이터 모델
+//
+// JWT 형식의 토큰은 길이가 길어 토큰 값 대신 SHA-256 해시(Hash)로 유일성을 보장하고 조회한다.
+type AccessToken struct {
+	ID                   uint
+	Value                string `gorm:"column:token"`
+	Hash                 string `gorm:"column:token_hash"`
+	JTI                  string `gorm:"column:jti"`
+	ClientID             uint
+	Client               Client
+	Username             string
+	Scopes               ScopeArray   `gorm:"many2many:users.oauth2_token_scope;joinForeignKey:token_id;joinReferences:scope_id"`
+	Audience             sql.Strings  `gorm:"column:audience"`
+	Actor                *string      `gorm:"column:act"`
+	Confirmation         Confirmation `gorm:"embedded"`
+	AuthorizationDetails *string      `gorm:"column:authorization_details"`
+	AuthTime             *time.Time
+	ACR                  string      `gorm:"column:acr"`
+	AMR                  sql.Strings `gorm:"column:amr"`
+	IssuedAt, ExpiredAt  time.Time
+}
+
 func (entity *AccessToken) TableName() string {
 	return "users.oauth2_access_token"
 }
@@ -136,17 +444,74 @@ func (entity *AccessToken) Domain() *token.AccessToken {
 	}
 	accessToken := token.NewWithRange(c, id, period.NewWithStartEnd(entity.IssuedAt, entity.ExpiredAt))
 	accessToken.ApplyResourceOwnerInfo(entity.Username, entity.Scopes.Array())
+	accessToken.SetJTI(entity.JTI)
+	accessToken.SetAudience(entity.Audience)
+	accessToken.SetActor(fromActorJSON(entity.Actor))
+	accessToken.SetConfirmation(entity.Confirmation.Domain())
+	accessToken.SetAuthorizationDetails(fromDetailsJSON(entity.AuthorizationDetails))
+	accessToken.ApplyAuthentication(fromNullTime(entity.AuthTime), entity.ACR, entity.AMR)
 
 	return accessToken
 }
 
+// toActorJSON 위임 받은 주체를 JSON 문자열로 변환한다. 위임 받은 주체가 없는 경우 nil 을 반환한다.
+func toActorJSON(actor *token.Actor) (*string, error) {
+	if actor == nil {
+		return nil, nil
+	}
+	serial, err := json.Marshal(actor)
+	if err != nil {
+		return nil, err
+	}
+	v := string(serial)
+	return &v, nil
+}
+
+// fromActorJSON JSON 문자열을 위임 받은 주체로 변환한다. 문자열이 nil 이거나 변환 할 수 없는 경우 nil 을 반환한다.
+func fromActorJSON(v *string) *token.Actor {
+	if v == nil {
+		return nil
+	}
+	var actor token.Actor
+	if err := json.Unmarshal([]byte(*v), &actor); err != nil {
+		return nil
+	}
+	return &actor
+}
+
+// toDetailsJSON 인가 상세 정보 목록을 JSON 문자열로 변환한다. 인가 상세 정보가 없는 경우 nil 을 반환한다.
+func toDetailsJSON(details detail.Details) *string {
+	if len(details) == 0 {
+		return nil
+	}
+	v := details.String()
+	return &v
+}
+
+// fromDetailsJSON JSON 문자열을 인가 상세 정보 목록으로 변환한다. 문자열이 nil 이거나 변환 할 수 없는 경우 nil 을 반환한다.
+func fromDetailsJSON(v *string) detail.Details {
+	if v == nil {
+		return nil
+	}
+	details, err := detail.Parse(*v)
+	if err != nil {
+		return nil
+	}
+	return details
+}
+
 // RefreshToken OAuth2 리플레시 토큰 데이터 모델
 type RefreshToken struct {
 	ID                  uint
 	Value               string `gorm:"column:token"`
 	AccessTokenID       uint   `gorm:"column:access_token_id"`
 	AccessToken         *AccessToken
+	Confirmation        Confirmation `gorm:"embedded"`
+	Scopes              sql.Strings  `gorm:"column:scopes"`
+	Resources           sql.Strings  `gorm:"column:resources"`
 	IssuedAt, ExpiredAt time.Time
+
+	AuthorizationDetails *string `gorm:"column:authorization_details"`
 }
 
 func (entity *RefreshToken) TableName() string {
@@ -160,5 +525,138 @@ func (entity *RefreshToken) Domain() *token.RefreshToken {
 	id := func() string {
 		return entity.Value
 	}
-	return token.NewRefreshTokenWithRange(accessToken, id, period.NewWithStartEnd(entity.IssuedAt, entity.ExpiredAt))
+	refreshToken := token.NewRefreshTokenWithRange(accessToken, id, period.NewWithStartEnd(entity.IssuedAt, entity.ExpiredAt))
+	refreshToken.SetConfirmation(entity.Confirmation.Domain())
+	// 부여된 범위가 저장되지 않은 리플레시 토큰은 엑세스 토큰의 스코프와 대상을 그대로 사용한다.
+	if entity.Scopes != nil {
+		refreshToken.SetScopes(entity.Scopes)
+		refreshToken.SetResources(entity.Resources)
+	}
+	if entity.AuthorizationDetails != nil {
+		refreshToken.SetAuthorizationDetails(fromDetailsJSON(entity.AuthorizationDetails))
+	}
+	return refreshToken
+}
+
+// Confirmation 토큰이 바인딩된 소유 증명 키 정보 데이터 모델
+// 엑세스 토큰과 리플레시 토큰 데이터 모델에 포함되며 바인딩 되지 않은 값은 null 로 저장된다.
+type Confirmation struct {
+	JKT     *string `gorm:"column:jkt"`
+	X5TS256 *string `gorm:"column:x5t_s256"`
+}
+
+// newConfirmation 도메인 모델의 소유 증명 키 정보로 데이터 모델을 생성한다.
+func newConfirmation(cnf *token.Confirmation) Confirmation {
+	var entity Confirmation
+	if cnf == nil {
+		return entity
+	}
+	if cnf.JKT != "" {
+		entity.JKT = &cnf.JKT
+	}
+	if cnf.X5TS256 != "" {
+		entity.X5TS256 = &cnf.X5TS256
+	}
+	return entity
+}
+
+// Domain 데이터 모델을 도메인 모델로 변환한다. 바인딩된 값이 없는 경우 nil 을 반환한다.
+func (entity *Confirmation) Domain() *token.Confirmation {
+	if entity.JKT == nil && entity.X5TS256 == nil {
+		return nil
+	}
+	cnf := &token.Confirmation{}
+	if entity.JKT != nil {
+		cnf.JKT = *entity.JKT
+	}
+	if entity.X5TS256 != nil {
+		cnf.X5TS256 = *entity.X5TS256
+	}
+	return cnf
+}
+
+// DPoPProof 사용된 DPoP 증명 데이터 모델
+// 증명의 재사용을 막기 위해 증명이 허용되는 기간 동안 증명 식별자(jti)를 저장한다.
+type DPoPProof struct {
+	ID        uint
+	JKT       string `gorm:"column:jkt"`
+	JTI       string `gorm:"column:jti"`
+	ExpiredAt time.Time
+}
+
+func (entity *DPoPProof) TableName() string {
+	return "users.oauth2_dpop_proof"
+}
+
+// ClientAssertion 사용된 클라이언트 어설션 데이터 모델
+// 어설션의 재사용을 막기 위해 어설션이 만료될 때 까지 어설션 식별자(jti)를 저장한다.
+type ClientAssertion struct {
+	ID        uint
+	ClientID  string
+	JTI       string `gorm:"column:jti"`
+	ExpiredAt time.Time
+}
+
+func (entity *ClientAssertion) TableName() string {
+	return "users.oauth2_client_assertion"
+}
+
+// SigningKey 토큰 서명키 데이터 모델
+// 개인키는 PKCS #8 형식으로 직렬화 된 후 암호화 되어 저장된다.
+// 파일 저장소를 사용할 경우 JSON으로 직렬화 되어 저장된다.
+type SigningKey struct {
+	ID          uint          `json:"-"`
+	Kid         string        `json:"kid"`
+	Algorithm   key.Algorithm `json:"alg"`
+	State       key.State     `gorm:"column:key_state" json:"state"`
+	PrivateKey  []byte        `json:"private_key"`
+	CreatedAt   time.Time     `json:"created_at"`
+	ActivatedAt *time.Time    `json:"activated_at,omitempty"`
+	RetiredAt   *time.Time    `json:"retired_at,omitempty"`
+}
+
+func (entity *SigningKey) TableName() string {
+	return "users.oauth2_signing_key"
+}
+
+// Domain 데이터 모델을 도메인 모델로 변환한다. 암호화 된 개인키는 인자로 받은 비밀키로 복호화 한다.
+func (entity *SigningKey) Domain(secret string) (*key.Key, error) {
+	private, err := decryptPrivateKey(secret, entity.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	k := key.New(entity.Kid, entity.Algorithm, private)
+	k.SetLifecycle(entity.State, entity.CreatedAt, fromNullTime(entity.ActivatedAt), fromNullTime(entity.RetiredAt))
+	return k, nil
+}
+
+// NewSigningKey 서명키 도메인 모델을 데이터 모델로 변환한다. 개인키는 인자로 받은 비밀키로 암호화 한다.
+func NewSigningKey(secret string, k *key.Key) (*SigningKey, error) {
+	private, err := encryptPrivateKey(secret, k.Private())
+	if err != nil {
+		return nil, err
+	}
+	return &SigningKey{
+		Kid:         k.Id(),
+		Algorithm:   k.Algorithm(),
+		State:       k.State(),
+		PrivateKey:  private,
+		CreatedAt:   k.CreatedAt(),
+		ActivatedAt: toNullTime(k.ActivatedAt()),
+		RetiredAt:   toNullTime(k.RetiredAt()),
+	}, nil
+}
+
+func toNullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func fromNullTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
 }
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"oauth-server-go/internal/config/log"
+	"oauth-server-go/internal/oauth/authorization"
+	oautherr "oauth-server-go/internal/oauth/errors"
+)
+
+// PushedRequestGormBridge 푸시된 인가 요청 도메인을 Gorm을 이용해 데이터베이스에 CRUD 할 수 있도록 변환 및 연결 작업을 하는 객체
+type PushedRequestGormBridge struct {
+	db *gorm.DB
+}
+
+func NewPushedRequestGormBridge(db *gorm.DB) *PushedRequestGormBridge {
+	return &PushedRequestGormBridge{db: db}
+}
+
+// FindByRequestURI Gorm을 이용해 데이터베이스에서 푸시된 인가 요청을 조회하고 이를 도메인 모델로 변환하여 반환한다.
+func (b *PushedRequestGormBridge) FindByRequestURI(ctx context.Context, requestURI string) (*authorization.PushedRequest, bool) {
+	var pushedRequestModel PushedRequest
+	if err := b.db.WithContext(ctx).Where(&PushedRequest{RequestURI: requestURI}).First(&pushedRequestModel).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Sugared().Errorf("error occurred during select pushed request(%s): %v", requestURI, err)
+		}
+		return nil, false
+	}
+
+	pushedRequest, err := pushedRequestModel.Domain()
+	if err != nil {
+		log.Sugared().Errorf("error occurred during unmarshal pushed request(%s): %v", requestURI, err)
+		return nil, false
+	}
+	return pushedRequest, true
+}
+
+// Save Gorm을 이용해 데이터베이스에 푸시된 인가 요청을 저장한다.
+func (b *PushedRequestGormBridge) Save(ctx context.Context, r *authorization.PushedRequest) error {
+	clientModel, ok := FindClientByClientID(ctx, b.db, r.Request().Client)
+	if !ok {
+		return fmt.Errorf("%w: client(%s) not found", oautherr.ErrInvalidClient, r.Request().Client)
+	}
+
+	serial, err := json.Marshal(r.Request())
+	if err != nil {
+		return fmt.Errorf("%w: error occurred during marshal request: %v", oautherr.ErrUnknown, err)
+	}
+
+	pushedRequestModel := &PushedRequest{
+		RequestURI: r.RequestURI(),
+		ClientID:   clientModel.ID,
+		Request:    string(serial),
+		IssuedAt:   r.Start(),
+		ExpiredAt:  r.End(),
+	}
+	return b.db.WithContext(ctx).Create(pushedRequestModel).Error
+}
+
+// Delete Gorm을 이용해 데이터베이스에서 푸시된 인가 요청을 삭제한다.
+func (b *PushedRequestGormBridge) Delete(ctx context.Context, r *authorization.PushedRequest) error {
+	return b.db.WithContext(ctx).Where(&PushedRequest{RequestURI: r.RequestURI()}).Delete(&PushedRequest{}).Error
+}
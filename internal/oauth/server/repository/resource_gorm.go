@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"oauth-server-go/internal/config/log"
+	"oauth-server-go/internal/oauth/resource"
+	"oauth-server-go/pkg/array"
+)
+
+// ResourceServerGormBridge Gorm을 이용해 자원 서버를 데이터베이스에서 조회 할 수 있도록 변환 및 연결 작업을 하는 객체
+type ResourceServerGormBridge struct {
+	db *gorm.DB
+}
+
+func NewResourceServerGormBridge(db *gorm.DB) *ResourceServerGormBridge {
+	return &ResourceServerGormBridge{db: db}
+}
+
+// FindByURI Gorm을 이용해 데이터베이스에서 자원 지시자로 자원 서버와 자원 서버가 소유한 스코프를 조회한다.
+func (b *ResourceServerGormBridge) FindByURI(ctx context.Context, uri string) (*resource.Server, bool) {
+	var s ResourceServer
+	if err := b.db.WithContext(ctx).Preload("Scopes").Where(&ResourceServer{URI: uri}).First(&s).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Sugared().Errorf("error occurred during select resource server(%s): %v", uri, err)
+		}
+		return nil, false
+	}
+	return s.Domain(), true
+}
+
+// FindByClientID Gorm을 이용해 데이터베이스에서 클라이언트 아이디로 클라이언트가 사용하는 자원 서버와 자원 서버가 소유한 스코프를 조회한다.
+func (b *ResourceServerGormBridge) FindByClientID(ctx context.Context, clientID string) []*resource.Server {
+	var servers []ResourceServer
+	if err := b.db.WithContext(ctx).Preload("Scopes").Where("client_id = ?", clientID).Find(&servers).Error; err != nil {
+		log.Sugared().Errorf("error occurred during select resource servers of client(%s): %v", clientID, err)
+		return nil
+	}
+	return array.Map(servers, func(s ResourceServer) *resource.Server {
+		return s.Domain()
+	})
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"gorm.io/gorm"
@@ -47,7 +49,7 @@ func FindAccessTokenByValue(ctx context.Context, db *gorm.DB, value string) (*Ac
 		}
 	}
 	var accessToken AccessToken
-	if err := db.WithContext(ctx).Preload("Scopes").Joins("Client").Where(&AccessToken{Value: value}).First(&accessToken).Error; err != nil {
+	if err := db.WithContext(ctx).Preload("Scopes").Joins("Client").Where(&AccessToken{Hash: hashTokenValue(value)}).First(&accessToken).Error; err != nil {
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Sugared().Errorf("error occurred during select access token(%s): %v", value, err)
 		}
@@ -98,6 +100,15 @@ func FindRefreshTokenByValue(ctx context.Context, db *gorm.DB, value string) (*R
 	return &refreshToken, true
 }
 
+// FindRefreshTokenByUsername Gorm을 이용해 데이터베이스에서 인자로 받은 사용자 아이디로 발급된 리플레시 토큰을 모두 조회한다.
+func FindRefreshTokenByUsername(ctx context.Context, db *gorm.DB, username string) []RefreshToken {
+	var refreshTokens []RefreshToken
+	if err := db.WithContext(ctx).Joins("AccessToken").Joins("AccessToken.Client").Preload("AccessToken.Scopes").Where(`"AccessToken".username = ?`, username).Find(&refreshTokens).Error; err != nil {
+		log.Sugared().Errorf("error occurred during select refresh token(%s): %v", username, err)
+	}
+	return refreshTokens
+}
+
 // SaveAccessToken Gorm을 이용하여 데이터베이스에 엑세스 토큰을 저장한다.
 func SaveAccessToken(ctx context.Context, db *gorm.DB, accessToken *AccessToken) error {
 	err := db.WithContext(ctx).Omit("Scopes.*").Create(accessToken).Error
@@ -167,6 +178,14 @@ func (b *TokenGormBridge) FindAccessTokenByUsername(ctx context.Context, usernam
 	})
 }
 
+// FindRefreshTokenByUsername Gorm을 이용해 인자로 주어진 사용자 아이디로 발급된 리플레시 토큰을 조회하고 도메인 모델로 변환하여 반환한다.
+func (b *TokenGormBridge) FindRefreshTokenByUsername(ctx context.Context, username string) []token.RefreshToken {
+	tokens := FindRefreshTokenByUsername(ctx, b.db, username)
+	return array.Map(tokens, func(e RefreshToken) token.RefreshToken {
+		return *e.Domain()
+	})
+}
+
 // FindRefreshTokenByValue Gorm을 이용해 리플레시 토큰을 조회하고 도메인 모델로 변환하여 반환한다.
 //
 // Returns:
@@ -191,13 +210,27 @@ func (b *TokenGormBridge) SaveAccessToken(ctx context.Context, accessToken *toke
 		return slices.Contains(accessToken.Scopes(), s.Code)
 	})
 
+	actor, err := toActorJSON(accessToken.Actor())
+	if err != nil {
+		return fmt.Errorf("%w: error occurred during marshal actor: %v", oautherr.ErrUnknown, err)
+	}
+
 	tokenModel := &AccessToken{
-		Value:     accessToken.Value(),
-		ClientID:  clientModel.ID,
-		Username:  accessToken.Username(),
-		Scopes:    scopes,
-		IssuedAt:  accessToken.Start(),
-		ExpiredAt: accessToken.End(),
+		Value:                accessToken.Value(),
+		Hash:                 hashTokenValue(accessToken.Value()),
+		JTI:                  accessToken.JTI(),
+		ClientID:             clientModel.ID,
+		Username:             accessToken.Username(),
+		Scopes:               scopes,
+		Audience:             accessToken.Audience(),
+		Actor:                actor,
+		Confirmation:         newConfirmation(accessToken.Confirmation()),
+		AuthorizationDetails: toDetailsJSON(accessToken.AuthorizationDetails()),
+		AuthTime:             toNullTime(accessToken.AuthTime()),
+		ACR:                  accessToken.ACR(),
+		AMR:                  accessToken.AMR(),
+		IssuedAt:             accessToken.Start(),
+		ExpiredAt:            accessToken.End(),
 	}
 
 	return SaveAccessToken(ctx, b.db, tokenModel)
@@ -213,8 +246,13 @@ func (b *TokenGormBridge) SaveRefreshToken(ctx context.Context, refreshToken *to
 	refreshTokenModel := &RefreshToken{
 		Value:         refreshToken.Value(),
 		AccessTokenID: tokenModel.ID,
+		Confirmation:  newConfirmation(refreshToken.Confirmation()),
+		Scopes:        refreshToken.Scopes(),
+		Resources:     refreshToken.Resources(),
 		IssuedAt:      refreshToken.Start(),
 		ExpiredAt:     refreshToken.End(),
+
+		AuthorizationDetails: toDetailsJSON(refreshToken.AuthorizationDetails()),
 	}
 	return SaveRefreshToken(ctx, b.db, refreshTokenModel)
 }
@@ -237,8 +275,27 @@ func (b *TokenGormBridge) DeleteRefreshToken(ctx context.Context, refreshToken *
 	return DeleteByRefreshToken(ctx, b.db, tokenModel)
 }
 
+// DeleteConsent Gorm을 이용해 자원 소유자가 클라이언트에게 한 동의를 삭제한다.
+func (b *TokenGormBridge) DeleteConsent(ctx context.Context, username, clientID string) error {
+	clientModel, ok := FindClientByClientID(ctx, b.db, clientID)
+	if !ok {
+		return fmt.Errorf("%w: client(%s) not found", oautherr.ErrUnknown, clientID)
+	}
+	model, ok := FindConsent(ctx, b.db, username, clientModel.ID)
+	if !ok {
+		return nil
+	}
+	return deleteConsent(b.db.WithContext(ctx), model)
+}
+
 func (b *TokenGormBridge) Transaction(ctx context.Context, fn func(TokenRepository) error) error {
 	return b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		return fn(NewTokenGormBridge(tx))
 	})
 }
+
+// hashTokenValue 엑세스 토큰 조회에 사용할 토큰 값의 SHA-256 해시를 hex 문자열로 반환한다.
+func hashTokenValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,30 +2,169 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/x509"
 	"github.com/gin-gonic/gin"
+	"github.com/go-jose/go-jose/v4"
 	"gorm.io/gorm"
+	"oauth-server-go/internal/config/clientstore"
+	"oauth-server-go/internal/config/dpop"
+	"oauth-server-go/internal/config/jwtbearer"
+	"oauth-server-go/internal/config/keystore"
+	"oauth-server-go/internal/config/mtls"
+	"oauth-server-go/internal/config/registration"
+	"oauth-server-go/internal/oauth/authorization"
 	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/key"
+	"oauth-server-go/internal/oauth/scope"
 	"oauth-server-go/internal/oauth/server/handler"
 	"oauth-server-go/internal/oauth/server/pkg/gen"
+	"oauth-server-go/internal/oauth/server/pkg/notify"
+	"oauth-server-go/internal/oauth/server/pkg/remote"
 	"oauth-server-go/internal/oauth/server/pkg/security"
 	"oauth-server-go/internal/oauth/server/repository"
 	"oauth-server-go/internal/oauth/server/service"
 	"oauth-server-go/internal/oauth/token"
+	"oauth-server-go/internal/oauth/userinfo"
 	"oauth-server-go/internal/pkg/auth"
 	"oauth-server-go/internal/pkg/middleware"
 	"oauth-server-go/internal/pkg/web"
+	"oauth-server-go/pkg/array"
 	"oauth-server-go/pkg/hash"
+	"strings"
 )
 
 var resourceOwnerAuthenticate auth.SimpleAuthenticate
 
+var resourceOwnerProfile auth.RetrieveProfile
+
 func SetResourceOwnerAuthenticate(f auth.SimpleAuthenticate) {
 	resourceOwnerAuthenticate = f
 }
 
+func SetResourceOwnerProfile(f auth.RetrieveProfile) {
+	resourceOwnerProfile = f
+}
+
 // Environment OAuth2 도메인 처리를 위한 환경을 제공하는 인터페이스
 type Environment interface {
 	GetDB() *gorm.DB
+
+	// GetIssuer 인가 서버의 식별자(iss)를 반환한다.
+	GetIssuer() string
+
+	// GetKeyStore 토큰 서명키 저장소 설정을 반환한다.
+	GetKeyStore() *keystore.Config
+
+	// GetClientStore 클라이언트 저장소 설정을 반환한다.
+	GetClientStore() *clientstore.Config
+
+	// GetDPoP DPoP 증명 검증 설정을 반환한다.
+	GetDPoP() *dpop.Config
+
+	// GetTLS 서버 TLS 및 상호 TLS 클라이언트 인증 설정을 반환한다.
+	GetTLS() *mtls.Config
+
+	// GetJWTBearer JWT 인가 승인 방식의 신뢰하는 발급자 설정을 반환한다.
+	GetJWTBearer() *jwtbearer.Config
+
+	// GetRegistration 동적 클라이언트 등록 설정을 반환한다.
+	GetRegistration() *registration.Config
+}
+
+// newKeyRepository 설정에 맞는 토큰 서명키 저장소를 생성한다.
+// 개인키를 암호화 할 비밀키가 설정되지 않은 경우 패닉이 발생한다.
+func newKeyRepository(env Environment) repository.KeyRepository {
+	c := env.GetKeyStore()
+	if c.Secret == "" {
+		panic("key store secret is not configured")
+	}
+	switch c.Storage {
+	case keystore.StorageFile:
+		r, err := repository.NewKeyFileBridge(c.Dir, c.Secret)
+		if err != nil {
+			panic(err)
+		}
+		return r
+	case keystore.StorageDB, "":
+		return repository.NewKeyGormBridge(env.GetDB(), c.Secret)
+	default:
+		panic("unsupported key storage: " + c.Storage)
+	}
+}
+
+// newDPoPNonce 설정에 맞는 DPoP 서버 nonce 를 생성한다. 서버 nonce 를 사용하지 않도록 설정된 경우 nil 을 반환한다.
+// 비밀키가 설정되지 않은 경우 랜덤한 비밀키를 생성하며, 실패할 경우 패닉이 발생한다.
+func newDPoPNonce(env Environment) *token.DPoPNonce {
+	c := env.GetDPoP()
+	if !c.RequireNonce {
+		return nil
+	}
+
+	secret := []byte(c.NonceSecret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic(err)
+		}
+	}
+	return token.NewDPoPNonce(secret, c.NonceLifetime())
+}
+
+// newTrustedIssuers 설정에 맞는 JWT 인가 승인 방식의 신뢰하는 발급자 목록을 생성한다.
+// 주체 변환 규칙의 정규 표현식이 잘못된 경우 패닉이 발생한다.
+func newTrustedIssuers(env Environment) token.TrustedIssuers {
+	issuers := array.Map(env.GetJWTBearer().TrustedIssuers, func(c jwtbearer.TrustedIssuer) *token.TrustedIssuer {
+		mappings := array.Map(c.SubjectMappings, func(m jwtbearer.SubjectMapping) *token.SubjectMapping {
+			mapping, err := token.NewSubjectMapping(m.Pattern, m.Username)
+			if err != nil {
+				panic(err)
+			}
+			return mapping
+		})
+		return token.NewTrustedIssuer(c.Issuer, c.JWKS, c.JWKSURI, mappings, c.Scopes)
+	})
+	return token.NewTrustedIssuers(issuers...)
+}
+
+// newKeyService 토큰 서명키 서비스를 생성하고 서명키 교체 스케줄을 시작한다.
+// 어플리케이션 기동시 한번 교체를 수행하여 사용할 서명키를 준비하며, 실패할 경우 패닉이 발생한다.
+//
+// 다른 서버 인스턴스는 교체 확인 주기가 지나야 교체된 서명키를 반영하여 그 동안 퇴역한 키로 서명 할 수 있으므로
+// 퇴역한 키는 최소 서명된 토큰의 최대 유효기간과 교체 확인 주기를 더한 기간 동안 공개키 목록에 유지한다.
+func newKeyService(env Environment) *service.KeyService {
+	c := env.GetKeyStore()
+	rotation := &key.Rotation{
+		Period:    c.RotationPeriod(),
+		Retention: c.Retention(token.MaxSignedLifetime + c.CheckInterval()),
+	}
+	algorithms := []key.Algorithm{key.AlgorithmRS256}
+	if len(c.Algorithms) > 0 {
+		algorithms = array.Map(c.Algorithms, func(alg string) key.Algorithm {
+			return key.Algorithm(alg)
+		})
+	}
+
+	keyService := service.NewKeyService(newKeyRepository(env), rotation, algorithms, gen.GenerateRandomUUID)
+	if err := keyService.Rotate(context.Background()); err != nil {
+		panic(err)
+	}
+	keyService.Schedule(context.Background(), c.CheckInterval())
+	return keyService
+}
+
+// newClientRepository 클라이언트 저장소를 생성한다.
+// client_secret_jwt 시크릿 원문을 암호화 할 비밀키가 설정되지 않은 경우 패닉이 발생한다.
+func newClientRepository(env Environment) *repository.ClientGormBridge {
+	c := env.GetClientStore()
+	if c.Secret == "" {
+		panic("client store secret is not configured")
+	}
+	r, err := repository.NewClientGormBridge(env.GetDB(), c.Secret)
+	if err != nil {
+		panic(err)
+	}
+	return r
 }
 
 func OAuth2RFCRouting(route *gin.Engine, env Environment) {
@@ -35,45 +174,147 @@ func OAuth2RFCRouting(route *gin.Engine, env Environment) {
 		return cacheContext
 	}
 
-	clientRepository := repository.NewClientGormBridge(env.GetDB())
+	clientRepository := newClientRepository(env)
 	scopeRepository := repository.NewScopeGormBridge(env.GetDB())
 	authCodeRepository := repository.NewAuthCodeGormBride(env.GetDB())
 	tokenRepository := repository.NewTokenGormBridge(env.GetDB())
+	deviceCodeRepository := repository.NewDeviceCodeGormBridge(env.GetDB())
+	pushedRequestRepository := repository.NewPushedRequestGormBridge(env.GetDB())
+	dpopProofRepository := repository.NewDPoPProofGormBridge(env.GetDB())
+	clientAssertionRepository := repository.NewClientAssertionGormBridge(env.GetDB())
+	resourceServerRepository := repository.NewResourceServerGormBridge(env.GetDB())
+	backchannelRepository := repository.NewBackchannelAuthenticationGormBridge(env.GetDB())
+	authorizationDetailTypeRepository := repository.NewAuthorizationDetailTypeGormBridge(env.GetDB())
+	consentRepository := repository.NewConsentGormBridge(env.GetDB())
 
 	clientService := service.NewClientService(clientRepository)
 	scopeService := service.NewScopeService(scopeRepository)
 	authCodeService := service.NewAuthCodeService(authCodeRepository)
 	tokenService := service.NewTokenService(tokenRepository)
+	deviceCodeService := service.NewDeviceCodeService(deviceCodeRepository)
+	pushedRequestService := service.NewPushedRequestService(pushedRequestRepository)
+	dpopService := service.NewDPoPService(dpopProofRepository, env.GetIssuer(), newDPoPNonce(env))
+	resourceServerService := service.NewResourceServerService(resourceServerRepository)
+	authorizationDetailService := service.NewAuthorizationDetailService(authorizationDetailTypeRepository)
+	consentService := service.NewConsentService(consentRepository)
+	connectedAppService := service.NewConnectedAppService(tokenRepository, consentRepository)
+	remoteClient := remote.NewClient()
+	notifier := &remote.Notifier{Client: remoteClient}
+	backchannelService := service.NewBackchannelAuthenticationService(backchannelRepository, resourceOwnerProfile, notify.NewInProcessChannel(), notifier.Ping)
+
+	keyService := newKeyService(env)
+	jwtEncoder := token.JWTEncoder{
+		Issuer: env.GetIssuer(),
+		Sign:   keyService.Sign,
+	}
+
+	responseJWTEncoder := authorization.ResponseJWTEncoder{
+		Issuer: env.GetIssuer(),
+		Sign:   keyService.Sign,
+	}
+
+	fetcher := &remote.Fetcher{Client: remoteClient}
+	tokenIssuer := &service.TokenIssuer{
+		Repository:                    tokenRepository,
+		RetrieveAuthorizationCode:     authCodeService.Consume,
+		AuthenticateResourceOwner:     resourceOwnerAuthenticate,
+		GenerateAccessToken:           gen.GenerateRandomUUID,
+		GenerateRefreshToken:          gen.GenerateRandomUUID,
+		EncodeAccessToken:             jwtEncoder.Encode,
+		Issuer:                        env.GetIssuer(),
+		SignIDToken:                   keyService.Sign,
+		PollDeviceCode:                deviceCodeService.Poll,
+		PollBackchannelAuthentication: backchannelService.Poll,
+		TrustedIssuers:                newTrustedIssuers(env),
+		FetchJWKS:                     fetcher.JWKS,
+		RetrieveResourceServer:        resourceServerService.Retrieve,
+		RetrieveClientResourceServers: resourceServerService.RetrieveByClient,
+
+		RetrieveAuthorizationDetailType: authorizationDetailService.Retrieve,
+	}
+
+	clientCAs, err := env.GetTLS().ClientCAs()
+	if err != nil {
+		panic(err)
+	}
 
 	rfcHandler := handler.Handler{
-		TokenIssuer: &service.TokenIssuer{
-			Repository:                tokenRepository,
-			RetrieveAuthorizationCode: authCodeService.Consume,
-			AuthenticateResourceOwner: resourceOwnerAuthenticate,
-			GenerateAccessToken:       gen.GenerateRandomUUID,
-			GenerateRefreshToken:      gen.GenerateRandomUUID,
+		TokenIssuer:           tokenIssuer,
+		TokenService:          tokenService,
+		ClientService:         clientService,
+		ScopeService:          scopeService,
+		AuthCodeService:       authCodeService,
+		PushedRequestService:  pushedRequestService,
+		DPoPService:           dpopService,
+		ResourceServerService: resourceServerService,
+		ConsentService:        consentService,
+
+		AuthorizationDetailService: authorizationDetailService,
+		ImplicitGranter:            token.NewImplicitGrant(gen.GenerateRandomUUID),
+		RequestObjectVerifier: &authorization.RequestObjectVerifier{
+			Audience:  env.GetIssuer(),
+			FetchJWKS: fetcher.JWKS,
+		},
+		FetchRequestObject: fetcher.RequestObject,
+		EncodeResponseJWT:  responseJWTEncoder.Encode,
+		Issuer:             env.GetIssuer(),
+		IntrospectionEncoder: &token.IntrospectionEncoder{
+			Issuer:     env.GetIssuer(),
+			Algorithms: keyService.Algorithms(),
+			SignWith:   keyService.SignWith,
+			FetchJWKS:  fetcher.JWKS,
 		},
-		TokenService:    tokenService,
-		ClientService:   clientService,
-		ScopeService:    scopeService,
-		AuthCodeService: authCodeService,
-		ImplicitGranter: token.NewImplicitGrant(gen.GenerateRandomUUID),
 	}
 
 	managementHandler := handler.ManagementHandler{
-		TokenService: tokenService,
+		TokenService:        tokenService,
+		ConnectedAppService: connectedAppService,
 	}
 
+	keyHandler := handler.KeyHandler{
+		KeyService: keyService,
+	}
+
+	deviceHandler := handler.DeviceHandler{
+		DeviceCodeService: deviceCodeService,
+		ScopeService:      scopeService,
+	}
+
+	backchannelHandler := handler.BackchannelHandler{
+		BackchannelAuthenticationService: backchannelService,
+		ScopeService:                     scopeService,
+	}
+
+	userInfoHandler := handler.UserInfoHandler{
+		TokenService:    tokenService,
+		DPoPService:     dpopService,
+		RetrieveProfile: resourceOwnerProfile,
+	}
+
+	jwksEndpoint := "/.well-known/jwks.json"
+	route.GET(jwksEndpoint, web.NewHTTPHandler(keyHandler.JWKS))
+
+	// 인가 요청의 에러에는 인가 서버 식별자(iss)를 추가하며, JWT 전달 방식(response_mode=jwt 등)으로 요청한 경우 JWT로 인코딩하여 전달한다.
+	errorEnhancer := handler.ChainEnhancer(
+		handler.EnhanceError,
+		handler.EnhanceIssuer(env.GetIssuer()),
+		handler.EnhanceJWTResponse(responseJWTEncoder.Encode),
+	)
+
 	group := route.Group("/oauth/auth")
 	group.Use(middleware.NoCache)
 	group.Use(handler.OAuth2ErrorWrappingHandler)
-	group.Use(handler.OAuth2ErrorHandler)
+	group.Use(handler.NewOAuth2ErrorHandler(errorEnhancer))
 	group.Use(middleware.EnhanceGinContext(repositoryCachingContext))
 
+	// 인가 요청은 prompt=none 으로 로그인하지 않은 자원 소유자에게 login_required 에러를 전달해야 하므로 로그인 여부를 핸들러에서 확인한다.
 	authorizationEndpoint := group.Group("/authorize")
-	authorizationEndpoint.Use(web.RequestProtect(web.AccessDeniedRedirectHandler("/users/auth")))
+	loginProtect := web.RequestProtect(web.AccessDeniedRedirectHandler("/users/auth"))
+	rfcHandler.LoginPage = "/users/auth"
+	rfcHandler.ResumeAuthorizePath = authorizationEndpoint.BasePath() + "/resume"
 	authorizationEndpoint.GET("", web.NewHTTPHandler(rfcHandler.Authorize))
-	authorizationEndpoint.POST("", web.NewHTTPHandler(rfcHandler.Approve))
+	authorizationEndpoint.POST("", loginProtect, web.NewHTTPHandler(rfcHandler.Approve))
+	authorizationEndpoint.GET("/resume", loginProtect, web.NewHTTPHandler(rfcHandler.ResumeAuthorize))
 
 	clientAuthProvider := func(ctx context.Context, id, secret string) (*client.Client, error) {
 		retriever := func(id string) (*client.Client, bool) {
@@ -83,15 +324,199 @@ func OAuth2RFCRouting(route *gin.Engine, env Environment) {
 		return authProvider.Authenticate(id, secret)
 	}
 
+	clientCertificateAuthProvider := func(ctx context.Context, id string, chain []*x509.Certificate) (*client.Client, bool, error) {
+		retriever := func(id string) (*client.Client, bool) {
+			return clientRepository.FindByClientID(ctx, id)
+		}
+		authProvider := client.NewCertificateAuthenticationProvider(retriever, clientCAs, fetcher.JWKS)
+		return authProvider.Authenticate(ctx, id, chain)
+	}
+
+	// 클라이언트 어설션과 JWT 인가 승인 방식 어설션의 aud 클레임은 인가 서버 식별자나 토큰 엔드포인트 URL 중 하나를 포함해야 한다.
 	tokenIssueEndpoint := group.Group("/token")
+	assertionAudience := []string{env.GetIssuer(), strings.TrimSuffix(env.GetIssuer(), "/") + tokenIssueEndpoint.BasePath()}
+	tokenIssuer.AssertionAudience = assertionAudience
+	clientAssertionAuthProvider := func(ctx context.Context, assertionType, assertion string) (*client.Client, error) {
+		retriever := func(id string) (*client.Client, bool) {
+			return clientRepository.FindByClientID(ctx, id)
+		}
+		authProvider := client.NewAssertionAuthenticationProvider(retriever, assertionAudience, fetcher.JWKS, clientAssertionRepository.SaveIfAbsent)
+		return authProvider.Authenticate(ctx, assertionType, assertion)
+	}
+
+	// 클라이언트 인증 방식은 아래 인증 핸들러와 일치해야 한다.
+	// 상호 TLS 인증은 서버가 TLS로 동작하는 경우에만 사용할 수 있으며 tls_client_auth 는 신뢰하는 인증 기관이 설정되어야 한다.
+	clientAuthMethods := []client.AuthMethod{client.AuthMethodClientSecretBasic, client.AuthMethodClientSecretPost, client.AuthMethodClientSecretJWT, client.AuthMethodPrivateKeyJWT}
+	if env.GetTLS().Enabled() {
+		if clientCAs != nil {
+			clientAuthMethods = append(clientAuthMethods, client.AuthMethodTLSClientAuth)
+		}
+		clientAuthMethods = append(clientAuthMethods, client.AuthMethodSelfSignedTLSClientAuth)
+	}
+	tokenIssueEndpoint.Use(security.ClientCertificateAuthenticationHandler(clientCertificateAuthProvider))
+	tokenIssueEndpoint.Use(security.ClientAssertionAuthenticationHandler(clientAssertionAuthProvider))
 	tokenIssueEndpoint.Use(security.ClientBasicAuthenticateHandler(clientAuthProvider))
 	tokenIssueEndpoint.Use(security.ClientFormAuthenticationHandler(clientAuthProvider))
 	tokenIssueEndpoint.Use(security.ClientRequiredAuthenticationHandler)
 	tokenIssueEndpoint.POST("", web.NewHTTPHandler(rfcHandler.IssueToken))
+	tokenIntrospectEndpoint := tokenIssueEndpoint.BasePath() + "/introspect"
 	tokenIssueEndpoint.POST("/introspect", web.NewHTTPHandler(rfcHandler.InspectToken))
+	tokenRevocationEndpoint := tokenIssueEndpoint.BasePath() + "/revoke"
+	tokenIssueEndpoint.POST("/revoke", web.NewHTTPHandler(rfcHandler.RevokeToken))
+
+	pushedAuthorizationEndpoint := group.Group("/par")
+	pushedAuthorizationEndpoint.Use(security.ClientCertificateAuthenticationHandler(clientCertificateAuthProvider))
+	pushedAuthorizationEndpoint.Use(security.ClientAssertionAuthenticationHandler(clientAssertionAuthProvider))
+	pushedAuthorizationEndpoint.Use(security.ClientBasicAuthenticateHandler(clientAuthProvider))
+	pushedAuthorizationEndpoint.Use(security.ClientFormAuthenticationHandler(clientAuthProvider))
+	pushedAuthorizationEndpoint.Use(security.ClientRequiredAuthenticationHandler)
+	pushedAuthorizationEndpoint.POST("", web.NewHTTPHandler(rfcHandler.PushAuthorization))
+
+	deviceAuthorizationEndpoint := group.Group("/device_authorization")
+	deviceAuthorizationEndpoint.Use(security.ClientCertificateAuthenticationHandler(clientCertificateAuthProvider))
+	deviceAuthorizationEndpoint.Use(security.ClientAssertionAuthenticationHandler(clientAssertionAuthProvider))
+	deviceAuthorizationEndpoint.Use(security.ClientBasicAuthenticateHandler(clientAuthProvider))
+	deviceAuthorizationEndpoint.Use(security.ClientFormAuthenticationHandler(clientAuthProvider))
+	deviceAuthorizationEndpoint.Use(security.ClientRequiredAuthenticationHandler)
+	deviceAuthorizationEndpoint.POST("", web.NewHTTPHandler(deviceHandler.DeviceAuthorization))
+
+	deviceVerificationEndpoint := route.Group("/oauth/device")
+	deviceVerificationEndpoint.Use(middleware.NoCache)
+	deviceVerificationEndpoint.Use(web.RequestProtect(web.AccessDeniedRedirectHandler("/users/auth")))
+	deviceVerificationEndpoint.GET("", web.NewHTTPHandler(deviceHandler.Verification))
+	deviceVerificationEndpoint.POST("", web.NewHTTPHandler(deviceHandler.Approve))
+
+	backchannelAuthenticationEndpoint := route.Group("/oauth/bc-authorize")
+	backchannelAuthenticationEndpoint.Use(middleware.NoCache)
+	backchannelAuthenticationEndpoint.Use(handler.OAuth2ErrorWrappingHandler)
+	backchannelAuthenticationEndpoint.Use(handler.OAuth2ErrorHandler)
+	backchannelAuthenticationEndpoint.Use(middleware.EnhanceGinContext(repositoryCachingContext))
+	backchannelAuthenticationEndpoint.Use(security.ClientCertificateAuthenticationHandler(clientCertificateAuthProvider))
+	backchannelAuthenticationEndpoint.Use(security.ClientAssertionAuthenticationHandler(clientAssertionAuthProvider))
+	backchannelAuthenticationEndpoint.Use(security.ClientBasicAuthenticateHandler(clientAuthProvider))
+	backchannelAuthenticationEndpoint.Use(security.ClientFormAuthenticationHandler(clientAuthProvider))
+	backchannelAuthenticationEndpoint.Use(security.ClientRequiredAuthenticationHandler)
+	backchannelAuthenticationEndpoint.POST("", web.NewHTTPHandler(backchannelHandler.BackchannelAuthorize))
+
+	backchannelVerificationEndpoint := route.Group("/oauth/backchannel")
+	backchannelVerificationEndpoint.Use(middleware.NoCache)
+	backchannelVerificationEndpoint.Use(web.RequestProtect(web.AccessDeniedRedirectHandler("/users/auth")))
+	backchannelVerificationEndpoint.GET("", web.NewHTTPHandler(backchannelHandler.Verification))
+	backchannelVerificationEndpoint.POST("", web.NewHTTPHandler(backchannelHandler.Approve))
+
+	userInfoEndpoint := route.Group("/oauth/userinfo")
+	userInfoEndpoint.Use(middleware.NoCache)
+	userInfoEndpoint.Use(handler.OAuth2ErrorWrappingHandler)
+	userInfoEndpoint.Use(handler.BearerErrorHandler)
+	userInfoEndpoint.Use(middleware.EnhanceGinContext(repositoryCachingContext))
+	userInfoEndpoint.GET("", web.NewHTTPHandler(userInfoHandler.UserInfo))
+	userInfoEndpoint.POST("", web.NewHTTPHandler(userInfoHandler.UserInfo))
+
+	issuer := strings.TrimSuffix(env.GetIssuer(), "/")
+	grantTypes := append(tokenIssuer.GrantTypes(), token.GrantTypeImplicit)
+	deviceHandler.VerificationURI = issuer + deviceVerificationEndpoint.BasePath()
+
+	registrationHandler := handler.RegistrationHandler{
+		ClientRegistrar: &service.ClientRegistrar{
+			Repository:                clientRepository,
+			ScopeRepository:           scopeRepository,
+			GenerateClientID:          gen.GenerateRandomUUID,
+			GenerateSecret:            gen.GenerateRandomUUID,
+			GenerateRegistrationToken: gen.GenerateRandomUUID,
+			Hashing:                   hash.Hashing,
+			Compare:                   hash.Compare,
+			GrantTypes: array.Map(grantTypes, func(t token.GrantType) string {
+				return string(t)
+			}),
+			IntrospectionSigningAlgorithms: array.Map(keyService.Algorithms(), func(alg key.Algorithm) jose.SignatureAlgorithm {
+				return jose.SignatureAlgorithm(alg)
+			}),
+			InitialAccessToken: env.GetRegistration().InitialAccessToken,
+			AnonymousGrantTypes: []string{
+				string(token.GrantTypeAuthorizationCode),
+				string(token.GrantTypeRefreshToken),
+				string(token.GrantTypeDeviceCode),
+			},
+			AnonymousScopes: env.GetRegistration().AnonymousScopes,
+		},
+	}
+
+	registrationEndpoint := route.Group("/oauth/register")
+	registrationEndpoint.Use(middleware.NoCache)
+	registrationEndpoint.Use(handler.OAuth2ErrorWrappingHandler)
+	registrationEndpoint.Use(handler.OAuth2ErrorHandler)
+	registrationEndpoint.POST("", web.NewHTTPHandler(registrationHandler.Register))
+	registrationHandler.RegistrationEndpoint = issuer + registrationEndpoint.BasePath()
+
+	clientConfigurationEndpoint := route.Group("/oauth/register/:clientId")
+	clientConfigurationEndpoint.Use(middleware.NoCache)
+	clientConfigurationEndpoint.Use(handler.OAuth2ErrorWrappingHandler)
+	clientConfigurationEndpoint.Use(handler.BearerErrorHandler)
+	clientConfigurationEndpoint.GET("", web.NewHTTPHandler(registrationHandler.Configuration))
+	clientConfigurationEndpoint.PUT("", web.NewHTTPHandler(registrationHandler.UpdateConfiguration))
+	clientConfigurationEndpoint.DELETE("", web.NewHTTPHandler(registrationHandler.DeleteConfiguration))
 
 	managementGroup := route.Group("/oauth/manage")
 	managementGroup.Use(web.RequestProtect(web.AccessDeniedRedirectHandler("/users/auth")))
 	managementGroup.GET("/tokens", web.NewHTTPHandler(managementHandler.TokenManagement))
 	managementGroup.DELETE("/tokens/:tokenValue", web.NewHTTPHandler(managementHandler.DeleteToken))
+	managementGroup.GET("/apps", web.NewHTTPHandler(managementHandler.ConnectedApps))
+	managementGroup.DELETE("/apps/:clientID", web.NewHTTPHandler(managementHandler.RevokeApp))
+
+	signingAlgorithms := array.Map(client.SigningAlgorithms, func(alg jose.SignatureAlgorithm) string {
+		return string(alg)
+	})
+	requestObjectAlgorithms := append([]string{authorization.AlgorithmNone}, signingAlgorithms...)
+	assertionAlgorithms := append(array.Map(client.HMACAlgorithms, func(alg jose.SignatureAlgorithm) string {
+		return string(alg)
+	}), signingAlgorithms...)
+	metadataHandler := handler.MetadataHandler{
+		Metadata: &handler.OpenIDMetadata{
+			Metadata: handler.Metadata{
+				Issuer:                            env.GetIssuer(),
+				AuthorizationEndpoint:             issuer + authorizationEndpoint.BasePath(),
+				TokenEndpoint:                     issuer + tokenIssueEndpoint.BasePath(),
+				JWKSURI:                           issuer + jwksEndpoint,
+				IntrospectionEndpoint:             issuer + tokenIntrospectEndpoint,
+				ResponseTypesSupported:            authorization.ResponseTypes,
+				ResponseModesSupported:            authorization.ResponseModes,
+				GrantTypesSupported:               grantTypes,
+				CodeChallengeMethodsSupported:     authorization.ChallengeMethods,
+				TokenEndpointAuthMethodsSupported: append([]client.AuthMethod{client.AuthMethodNone}, clientAuthMethods...),
+				TokenEndpointAuthSigningAlgValuesSupported: assertionAlgorithms,
+				IntrospectionEndpointAuthMethodsSupported:  clientAuthMethods,
+				PromptValuesSupported:                      authorization.Prompts,
+				ACRValuesSupported:                         auth.ACRValues,
+				RevocationEndpoint:                         issuer + tokenRevocationEndpoint,
+				RevocationEndpointAuthMethodsSupported:     clientAuthMethods,
+				RegistrationEndpoint:                       registrationHandler.RegistrationEndpoint,
+				DeviceAuthorizationEndpoint:                issuer + deviceAuthorizationEndpoint.BasePath(),
+				PushedAuthorizationRequestEndpoint:         issuer + pushedAuthorizationEndpoint.BasePath(),
+				RequestParameterSupported:                  true,
+				RequestURIParameterSupported:               true,
+				RequireRequestURIRegistration:              true,
+				RequestObjectSigningAlgValuesSupported:     requestObjectAlgorithms,
+				DPoPSigningAlgValuesSupported:              signingAlgorithms,
+				TLSClientCertificateBoundAccessTokens:      env.GetTLS().Enabled(),
+				AuthorizationDetailsTypesSupported:         authorizationDetailService.Types(context.Background()),
+				IntrospectionSigningAlgValuesSupported:     keyService.Algorithms(),
+				IntrospectionEncryptionAlgValuesSupported:  client.EncryptionAlgorithms,
+				IntrospectionEncryptionEncValuesSupported:  client.EncryptionMethods,
+				AuthorizationSigningAlgValuesSupported:     keyService.Algorithms()[:1],
+				AuthorizationResponseIssParameterSupported: true,
+			},
+			UserInfoEndpoint:                  issuer + userInfoEndpoint.BasePath(),
+			ScopesSupported:                   []string{scope.OpenID, userinfo.ScopeProfile, userinfo.ScopeEmail},
+			SubjectTypesSupported:             []string{"public"},
+			IDTokenSigningAlgValuesSupported:  keyService.Algorithms()[:1],
+			ClaimsSupported:                   userinfo.ClaimNames,
+			BackchannelAuthenticationEndpoint: issuer + backchannelAuthenticationEndpoint.BasePath(),
+			BackchannelTokenDeliveryModesSupported: []client.BackchannelTokenDeliveryMode{
+				client.BackchannelTokenDeliveryPoll,
+				client.BackchannelTokenDeliveryPing,
+			},
+		},
+	}
+	route.GET("/.well-known/oauth-authorization-server", web.NewHTTPHandler(metadataHandler.AuthorizationServer))
+	route.GET("/.well-known/openid-configuration", web.NewHTTPHandler(metadataHandler.OpenIDConfiguration))
 }
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/server/repository"
+	"oauth-server-go/internal/oauth/token"
+	"oauth-server-go/internal/pkg/web"
+)
+
+// ConnectedAppService 자원 소유자가 인가한 클라이언트(앱) 관리 서비스
+//
+// 자원 소유자의 동의와 발급된 토큰을 클라이언트 별로 묶어 조회하고, 클라이언트에게 부여한 접근 권한을 한번에 취소 할 수 있도록 한다.
+type ConnectedAppService struct {
+	tokenRepo   repository.TokenRepository
+	consentRepo repository.ConsentRepository
+}
+
+func NewConnectedAppService(tokenRepo repository.TokenRepository, consentRepo repository.ConsentRepository) *ConnectedAppService {
+	return &ConnectedAppService{tokenRepo: tokenRepo, consentRepo: consentRepo}
+}
+
+// GetConnectedApps 자원 소유자의 동의, 엑세스 토큰, 리플레시 토큰을 클라이언트 별로 묶어 반환한다.
+func (srv *ConnectedAppService) GetConnectedApps(ctx context.Context, username string) []*token.ConnectedApp {
+	return srv.group(ctx, srv.tokenRepo, username)
+}
+
+// Revoke 자원 소유자가 클라이언트에게 부여한 접근 권한을 취소한다.
+// 동의와 클라이언트에게 발급된 모든 엑세스 토큰, 리플레시 토큰을 하나의 트랜잭션에서 삭제한다.
+func (srv *ConnectedAppService) Revoke(ctx context.Context, owner *web.Authentication, clientID string) error {
+	return srv.tokenRepo.Transaction(ctx, func(r repository.TokenRepository) error {
+		var app *token.ConnectedApp
+		for _, a := range srv.group(ctx, r, owner.Username) {
+			if a.Client().Id() == clientID {
+				app = a
+				break
+			}
+		}
+		if app == nil {
+			return fmt.Errorf("%w: connected app(%s) not found", oautherr.ErrInvalidRequest, clientID)
+		}
+
+		for _, refreshToken := range app.RefreshTokens() {
+			if err := r.DeleteRefreshToken(ctx, refreshToken); err != nil {
+				return fmt.Errorf("error occurred while deleting refresh token: %w", err)
+			}
+		}
+		for _, accessToken := range app.AccessTokens() {
+			if err := r.DeleteAccessToken(ctx, accessToken); err != nil {
+				return fmt.Errorf("error occurred while deleting access token: %w", err)
+			}
+		}
+		if err := r.DeleteConsent(ctx, owner.Username, clientID); err != nil {
+			return fmt.Errorf("error occurred while deleting consent: %w", err)
+		}
+		return nil
+	})
+}
+
+// group 인자로 받은 토큰 저장소에서 조회한 토큰과 동의를 클라이언트 별로 묶어 반환한다.
+func (srv *ConnectedAppService) group(ctx context.Context, r repository.TokenRepository, username string) []*token.ConnectedApp {
+	consents := srv.consentRepo.FindByUsername(ctx, username)
+	accessTokens := r.FindAccessTokenByUsername(ctx, username)
+	refreshTokens := r.FindRefreshTokenByUsername(ctx, username)
+	return token.GroupByClient(consents, accessTokens, refreshTokens)
+}
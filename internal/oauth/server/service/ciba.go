@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"oauth-server-go/internal/config/log"
+	"oauth-server-go/internal/oauth/authorization"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/server/pkg/gen"
+	"oauth-server-go/internal/oauth/server/repository"
+	"oauth-server-go/internal/oauth/token"
+	"oauth-server-go/internal/pkg/auth"
+	"oauth-server-go/internal/pkg/web"
+	"time"
+)
+
+// AuthenticationDeviceChannel 자원 소유자의 인증 기기로 백채널 인증 요청을 알리는 채널
+//
+// 푸시 알림, SMS 등 실제 인증 기기와의 통신 방식은 구현체에 따라 달라지며,
+// 알림을 받은 자원 소유자는 인증 기기에서 [BackchannelAuthenticationService.Approve] 로 요청을 승인한다.
+type AuthenticationDeviceChannel interface {
+
+	// Notify 자원 소유자의 인증 기기로 백채널 인증 요청을 알린다.
+	Notify(ctx context.Context, b *authorization.BackchannelAuthentication) error
+}
+
+// PingClient ping 방식의 클라이언트 알림 엔드포인트로 자원 소유자의 인증이 끝났음을 알린다.
+type PingClient func(ctx context.Context, endpoint, notificationToken, authReqID string) error
+
+// BackchannelAuthenticationService 백채널 인증 요청 서비스
+//
+// [OpenID CIBA] 백채널 인증 요청에 대한 관리 포인트를 제공하여
+// 새 인증 요청 생성 및 인증 기기로의 알림, 자원 소유자의 승인, 클라이언트의 폴링 등을 작업한다.
+//
+// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html
+type BackchannelAuthenticationService struct {
+	repo            repository.BackchannelAuthenticationRepository
+	retrieveProfile auth.RetrieveProfile
+	channel         AuthenticationDeviceChannel
+	ping            PingClient
+}
+
+func NewBackchannelAuthenticationService(repo repository.BackchannelAuthenticationRepository, retrieveProfile auth.RetrieveProfile,
+	channel AuthenticationDeviceChannel, ping PingClient) *BackchannelAuthenticationService {
+	return &BackchannelAuthenticationService{repo: repo, retrieveProfile: retrieveProfile, channel: channel, ping: ping}
+}
+
+// Authenticate 새 백채널 인증 요청을 생성하여 저장소에 저장하고 자원 소유자의 인증 기기로 알린다.
+//
+// CIBA 는 비공개 클라이언트만 사용 할 수 있으며, 공개 클라이언트의 요청은 [oautherr.ErrUnauthorizedClient] 를 반환한다.
+// 자원 소유자는 login_hint 로만 식별하며 조회 할 수 없는 자원 소유자인 경우 [oautherr.ErrUnknownUserID] 를 반환한다.
+// 인증 기기로의 알림이 실패하더라도 자원 소유자는 승인 페이지에서 요청을 승인 할 수 있으므로 에러 로그만 남긴다.
+func (srv *BackchannelAuthenticationService) Authenticate(ctx context.Context, c *client.Client, r *authorization.BackchannelRequest) (*authorization.BackchannelAuthentication, error) {
+	if c.T() != client.TypeConfidential {
+		return nil, fmt.Errorf("%w: public client", oautherr.ErrUnauthorizedClient)
+	}
+	if !c.AllowGrantType(string(token.GrantTypeCIBA)) {
+		return nil, fmt.Errorf("%w: ciba grant is not allowed", oautherr.ErrUnauthorizedClient)
+	}
+
+	username, err := srv.identify(r)
+	if err != nil {
+		return nil, err
+	}
+
+	authentication, err := authorization.NewBackchannelAuthentication(c, r, username, gen.GenerateRandomUUID)
+	if err != nil {
+		return nil, err
+	}
+	if err = srv.repo.Save(ctx, authentication); err != nil {
+		return nil, err
+	}
+
+	if err = srv.channel.Notify(ctx, authentication); err != nil {
+		log.Sugared().Errorf("error occurred during notify backchannel authentication(%s) to user(%s): %v", authentication.AuthReqID(), username, err)
+	}
+	return authentication, nil
+}
+
+// identify 인증 요청의 힌트로 자원 소유자를 식별한다. 힌트는 하나만 입력해야 한다.
+func (srv *BackchannelAuthenticationService) identify(r *authorization.BackchannelRequest) (string, error) {
+	hints := 0
+	for _, h := range []string{r.LoginHint, r.LoginHintToken, r.IDTokenHint} {
+		if h != "" {
+			hints++
+		}
+	}
+	if hints != 1 {
+		return "", fmt.Errorf("%w: exactly one of login_hint, login_hint_token, id_token_hint is required", oautherr.ErrInvalidRequest)
+	}
+	if r.LoginHint == "" {
+		return "", fmt.Errorf("%w: only login_hint is supported", oautherr.ErrInvalidRequest)
+	}
+
+	profile, err := srv.retrieveProfile(r.LoginHint)
+	if err != nil || profile == nil {
+		return "", fmt.Errorf("%w: user(%s) could not find", oautherr.ErrUnknownUserID, r.LoginHint)
+	}
+	return profile.Username, nil
+}
+
+// RetrievePending 자원 소유자의 승인을 기다리는 백채널 인증 요청 목록을 조회한다.
+func (srv *BackchannelAuthenticationService) RetrievePending(ctx context.Context, username string) []*authorization.BackchannelAuthentication {
+	return srv.repo.FindPendingByUsername(ctx, username)
+}
+
+// Approve 자원 소유자가 인증 기기에서 백채널 인증 요청을 승인한다.
+// 승인한 스코프가 없는 경우 거부한 것으로 처리하며, ping 방식의 클라이언트에는 승인 또는 거부 후 알림을 보낸다.
+// 인증 시간(auth_time)은 승인 시간이 아닌 자원 소유자가 로그인한 시간을, 인증 컨텍스트 클래스(acr)와 인증 방식(amr)은 로그인 정보를 사용한다.
+func (srv *BackchannelAuthenticationService) Approve(ctx context.Context, authReqID string, owner *web.Authentication, scopes []string) (*authorization.BackchannelAuthentication, error) {
+	authentication, ok := srv.repo.FindByAuthReqID(ctx, authReqID)
+	if !ok {
+		return nil, fmt.Errorf("%w: auth_req_id(%s) could not find", oautherr.ErrInvalidRequest, authReqID)
+	}
+	if err := authentication.Approve(owner.Username, scopes, owner.AuthTime, owner.ACR, owner.AMR); err != nil {
+		return nil, err
+	}
+	if err := srv.repo.Update(ctx, authentication); err != nil {
+		return nil, err
+	}
+
+	c := authentication.Client()
+	if c.BackchannelTokenDeliveryMode() == client.BackchannelTokenDeliveryPing {
+		err := srv.ping(ctx, c.BackchannelClientNotificationEndpoint(), authentication.NotificationToken(), authentication.AuthReqID())
+		if err != nil {
+			log.Sugared().Errorf("error occurred during ping client(%s) for backchannel authentication(%s): %v", c.Id(), authReqID, err)
+		}
+	}
+	return authentication, nil
+}
+
+// Poll 클라이언트의 토큰 엔드포인트 폴링을 처리한다.
+// 승인, 거부 또는 만료로 더 이상 폴링 할 수 없는 백채널 인증 요청은 반환 전 삭제하며,
+// 승인을 기다리는 중이라면 폴링 정보를 저장소에 갱신한다.
+func (srv *BackchannelAuthenticationService) Poll(ctx context.Context, c *client.Client, authReqID string) (*authorization.BackchannelAuthentication, error) {
+	authentication, ok := srv.repo.FindByAuthReqID(ctx, authReqID)
+	if !ok {
+		return nil, fmt.Errorf("%w: auth_req_id(%s) could not find", oautherr.ErrUnauthorized, authReqID)
+	}
+	if authentication.Client().Id() != c.Id() {
+		return nil, fmt.Errorf("%w: auth_req_id was not issued to client(%s)", oautherr.ErrInvalidClient, c.Id())
+	}
+
+	pollErr := authentication.Poll(time.Now())
+	if pollErr == nil || errors.Is(pollErr, oautherr.ErrExpiredToken) || errors.Is(pollErr, oautherr.ErrAccessDenied) {
+		if err := srv.repo.Delete(ctx, authentication); err != nil {
+			return nil, err
+		}
+	} else if err := srv.repo.Update(ctx, authentication); err != nil {
+		return nil, err
+	}
+
+	if pollErr != nil {
+		return nil, pollErr
+	}
+	return authentication, nil
+}
@@ -2,8 +2,15 @@ package service
 
 import (
 	"context"
+	"crypto/subtle"
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
 	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/scope"
 	"oauth-server-go/internal/oauth/server/repository"
+	"oauth-server-go/internal/oauth/token"
+	"slices"
 )
 
 // ClientService 클라이언트 서비스
@@ -26,3 +33,212 @@ func (srv *ClientService) Retrieve(ctx context.Context, clientID string) (*clien
 	cacheContext := repository.WithClientCaching(ctx)
 	return srv.repo.FindByClientID(cacheContext, clientID)
 }
+
+// ClientRegistration 클라이언트 등록 및 수정 결과
+//
+// 클라이언트 시크릿과 등록 엑세스 토큰은 해싱되어 저장되므로 생성된 시점에만 원본 값을 확인 할 수 있다.
+type ClientRegistration struct {
+	Client *client.Client
+
+	// Secret 새로 생성된 클라이언트 시크릿 시크릿이 생성되지 않은 경우 공백("")
+	Secret string
+
+	// RegistrationToken 새로 생성된 등록 엑세스 토큰 토큰이 생성되지 않은 경우 공백("")
+	RegistrationToken string
+}
+
+// ClientRegistrar [RFC 7591] 동적 클라이언트 등록과 [RFC 7592] 클라이언트 관리를 처리하는 서비스 구조체
+//
+// [RFC 7591]: https://datatracker.ietf.org/doc/html/rfc7591
+// [RFC 7592]: https://datatracker.ietf.org/doc/html/rfc7592
+type ClientRegistrar struct {
+	Repository      repository.ClientRepository
+	ScopeRepository repository.ScopeRepository
+
+	GenerateClientID          token.GenerateToken
+	GenerateSecret            token.GenerateToken
+	GenerateRegistrationToken token.GenerateToken
+
+	// Hashing 클라이언트 시크릿과 등록 엑세스 토큰을 해싱하는 함수
+	Hashing func(plain string) (string, error)
+
+	// Compare 해싱된 등록 엑세스 토큰과 입력 받은 토큰을 비교하는 함수
+	Compare client.CompareSecret
+
+	// GrantTypes 클라이언트에 등록 할 수 있는 인가 방식 목록
+	GrantTypes []string
+
+	// IntrospectionSigningAlgorithms 클라이언트에 토큰 질의 응답 서명 알고리즘으로 등록 할 수 있는 알고리즘 목록
+	// 인가 서버의 서명키로 서명 할 수 있는 알고리즘만 등록 할 수 있다.
+	IntrospectionSigningAlgorithms []jose.SignatureAlgorithm
+
+	// InitialAccessToken 제한 없이 클라이언트를 등록 할 수 있는 [RFC 7591] 초기 엑세스 토큰
+	// 공백("")인 경우 익명 등록만 허용한다.
+	//
+	// [RFC 7591]: https://datatracker.ietf.org/doc/html/rfc7591#section-3
+	InitialAccessToken string
+
+	// AnonymousGrantTypes, AnonymousScopes 초기 엑세스 토큰 없이 등록하는 경우 등록 할 수 있는 인가 방식과 스코프 목록
+	AnonymousGrantTypes []string
+	AnonymousScopes     []string
+}
+
+// Register 메타데이터를 검증하고 새 클라이언트를 등록한다.
+// 기밀 클라이언트인 경우 클라이언트 시크릿을 생성하며 등록 엑세스 토큰은 항상 생성한다.
+//
+// 초기 엑세스 토큰(initialAccessToken)이 공백("")인 경우 익명 등록으로 처리하여 [ClientRegistrar.AnonymousGrantTypes] 와
+// [ClientRegistrar.AnonymousScopes] 에 포함된 인가 방식과 스코프만 등록 할 수 있다.
+// 초기 엑세스 토큰이 일치하지 않는 경우 [oautherr.ErrInvalidToken] 을 반환한다.
+func (srv *ClientRegistrar) Register(ctx context.Context, m *client.Metadata, initialAccessToken string) (*ClientRegistration, error) {
+	if err := srv.validate(ctx, m); err != nil {
+		return nil, err
+	}
+	if initialAccessToken == "" {
+		if err := srv.restrict(m, nil); err != nil {
+			return nil, err
+		}
+	} else if srv.InitialAccessToken == "" || subtle.ConstantTimeCompare([]byte(srv.InitialAccessToken), []byte(initialAccessToken)) != 1 {
+		return nil, fmt.Errorf("%w: initial access token is not matched", oautherr.ErrInvalidToken)
+	}
+
+	c := client.New(srv.GenerateClientID(), "", m.ClientName, m.Type())
+	c.ApplyMetadata(m)
+
+	registration := &ClientRegistration{Client: c}
+	if err := srv.issueCredentials(c, registration, true); err != nil {
+		return nil, err
+	}
+
+	if err := srv.Repository.Save(ctx, c); err != nil {
+		return nil, fmt.Errorf("error occurred while saving client: %w", err)
+	}
+	return registration, nil
+}
+
+// Retrieve 등록 엑세스 토큰을 검증하고 클라이언트를 조회한다.
+// 클라이언트를 찾을 수 없거나 토큰이 일치하지 않는 경우 모두 [oautherr.ErrInvalidToken] 을 반환한다.
+func (srv *ClientRegistrar) Retrieve(ctx context.Context, clientID, registrationToken string) (*client.Client, error) {
+	c, ok := srv.Repository.FindByClientID(ctx, clientID)
+	if !ok || c.RegistrationToken() == "" {
+		return nil, fmt.Errorf("%w: client(%s) could not find", oautherr.ErrInvalidToken, clientID)
+	}
+	if eq, err := srv.Compare(c.RegistrationToken(), registrationToken); !eq || err != nil {
+		return nil, fmt.Errorf("%w: registration access token is not matched", oautherr.ErrInvalidToken)
+	}
+	return c, nil
+}
+
+// Update 등록 엑세스 토큰을 검증하고 클라이언트의 메타데이터를 입력 받은 메타데이터로 교체한다.
+// 공개 클라이언트에서 기밀 클라이언트로 변경되는 경우 클라이언트 시크릿을 새로 생성하고,
+// 시크릿을 사용하지 않는 인증 방식으로 변경되는 경우 기존 시크릿을 제거한다.
+//
+// 익명 등록으로 허용되지 않는 인가 방식과 스코프는 클라이언트에 이미 등록되어 있던 경우에만 유지 할 수 있다.
+func (srv *ClientRegistrar) Update(ctx context.Context, clientID, registrationToken string, m *client.Metadata) (*ClientRegistration, error) {
+	c, err := srv.Retrieve(ctx, clientID, registrationToken)
+	if err != nil {
+		return nil, err
+	}
+	if err = srv.validate(ctx, m); err != nil {
+		return nil, err
+	}
+	if err = srv.restrict(m, c); err != nil {
+		return nil, err
+	}
+
+	c.ApplyMetadata(m)
+
+	registration := &ClientRegistration{Client: c}
+	if err = srv.issueCredentials(c, registration, false); err != nil {
+		return nil, err
+	}
+
+	if err = srv.Repository.Update(ctx, c); err != nil {
+		return nil, fmt.Errorf("error occurred while updating client: %w", err)
+	}
+	return registration, nil
+}
+
+// Delete 등록 엑세스 토큰을 검증하고 클라이언트를 삭제한다.
+func (srv *ClientRegistrar) Delete(ctx context.Context, clientID, registrationToken string) error {
+	c, err := srv.Retrieve(ctx, clientID, registrationToken)
+	if err != nil {
+		return err
+	}
+	if err = srv.Repository.Delete(ctx, c); err != nil {
+		return fmt.Errorf("error occurred while deleting client: %w", err)
+	}
+	return nil
+}
+
+// validate 메타데이터를 검증하고 요청한 스코프가 모두 저장소에 존재하는지 확인한다.
+func (srv *ClientRegistrar) validate(ctx context.Context, m *client.Metadata) error {
+	if err := m.Validate(srv.GrantTypes, srv.IntrospectionSigningAlgorithms); err != nil {
+		return err
+	}
+	if scopes := scope.Split(m.Scope); len(scopes) > 0 {
+		if found := srv.ScopeRepository.FindByValue(ctx, scopes...); len(found) != len(scopes) {
+			return fmt.Errorf("%w: unknown scope(%s)", oautherr.ErrInvalidClientMetadata, m.Scope)
+		}
+	}
+	return nil
+}
+
+// restrict 메타데이터의 인가 방식과 스코프가 익명 등록으로 허용된 목록이나 기존 클라이언트(registered)에 등록된 목록에 포함 되는지 확인한다.
+// 새로 등록하는 경우 registered 는 nil 이다.
+func (srv *ClientRegistrar) restrict(m *client.Metadata, registered *client.Client) error {
+	grantTypes, scopes := srv.AnonymousGrantTypes, srv.AnonymousScopes
+	if registered != nil {
+		grantTypes = append(slices.Clone(grantTypes), registered.GrantTypes()...)
+		scopes = append(slices.Clone(scopes), registered.Scopes()...)
+	}
+
+	for _, t := range m.GrantTypes {
+		if !slices.Contains(grantTypes, t) {
+			return fmt.Errorf("%w: grant_type(%s) requires initial access token", oautherr.ErrInvalidClientMetadata, t)
+		}
+	}
+	for _, s := range scope.Split(m.Scope) {
+		if !slices.Contains(scopes, s) {
+			return fmt.Errorf("%w: scope(%s) requires initial access token", oautherr.ErrInvalidClientMetadata, s)
+		}
+	}
+	return nil
+}
+
+// issueCredentials 클라이언트에 필요한 시크릿과 등록 엑세스 토큰을 생성하고 해싱하여 클라이언트에 설정한다.
+// 생성된 원본 값은 registration 에 설정된다.
+// 클라이언트 인증 방식이 시크릿을 사용하지 않는 경우 이전 시크릿으로 인증 할 수 없도록 시크릿을 제거한다.
+//
+// client_secret_jwt 인증 방식은 어설션의 HMAC 서명을 검증할 시크릿 원문이 필요하므로 원문을 클라이언트에 함께 설정하며,
+// 다른 인증 방식에서 변경되어 원문이 없는 경우 시크릿을 새로 생성한다.
+func (srv *ClientRegistrar) issueCredentials(c *client.Client, registration *ClientRegistration, withRegistrationToken bool) error {
+	method := c.AuthMethod()
+	if method != client.AuthMethodClientSecretJWT {
+		c.SetJWTSecret("")
+	}
+	if !method.IssuesSecret() {
+		c.SetSecret("")
+	} else if c.Secret() == "" || (method == client.AuthMethodClientSecretJWT && c.JWTSecret() == "") {
+		secret := srv.GenerateSecret()
+		hashed, err := srv.Hashing(secret)
+		if err != nil {
+			return fmt.Errorf("%w: error occurred while hashing client secret: %v", oautherr.ErrUnknown, err)
+		}
+		c.SetSecret(hashed)
+		if method == client.AuthMethodClientSecretJWT {
+			c.SetJWTSecret(secret)
+		}
+		registration.Secret = secret
+	}
+
+	if withRegistrationToken {
+		registrationToken := srv.GenerateRegistrationToken()
+		hashed, err := srv.Hashing(registrationToken)
+		if err != nil {
+			return fmt.Errorf("%w: error occurred while hashing registration access token: %v", oautherr.ErrUnknown, err)
+		}
+		c.SetRegistrationToken(hashed)
+		registration.RegistrationToken = registrationToken
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"oauth-server-go/internal/oauth/authorization"
+	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/server/repository"
+)
+
+// ConsentService 자원 소유자의 동의 서비스
+//
+// 자원 소유자가 인가 승인 페이지에서 승인한 스코프를 저장하여
+// 이미 동의한 스코프로 요청한 인가 요청에서 인가 승인 페이지를 생략 할 수 있도록 한다.
+type ConsentService struct {
+	repo repository.ConsentRepository
+}
+
+func NewConsentService(repo repository.ConsentRepository) *ConsentService {
+	return &ConsentService{repo: repo}
+}
+
+// Consented 요청한 스코프 중 자원 소유자가 클라이언트에게 이미 동의한 스코프를 반환한다.
+// 저장된 동의가 없거나 만료된 경우 nil 을 반환한다.
+func (srv *ConsentService) Consented(ctx context.Context, username string, c *client.Client, scopes []string) []string {
+	if consent, ok := srv.repo.FindByUsernameAndClientID(ctx, username, c.Id()); ok {
+		return consent.Consented(scopes)
+	}
+	return nil
+}
+
+// Grant 자원 소유자가 승인한 스코프를 기존에 동의한 스코프와 합쳐 저장한다.
+// 동의의 유효 기간은 클라이언트에 설정된 기간([client.Client.ConsentTTL])으로 갱신되며, 기간이 0 인 클라이언트는 동의를 저장하지 않는다.
+func (srv *ConsentService) Grant(ctx context.Context, username string, c *client.Client, scopes []string) error {
+	if c.ConsentTTL() <= 0 || len(scopes) == 0 {
+		return nil
+	}
+
+	consent, ok := srv.repo.FindByUsernameAndClientID(ctx, username, c.Id())
+	if ok {
+		consent = consent.Merge(scopes)
+	} else {
+		consent = authorization.NewConsent(username, c, scopes)
+	}
+	return srv.repo.Save(ctx, consent)
+}
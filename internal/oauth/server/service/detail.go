@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"oauth-server-go/internal/oauth/detail"
+	"oauth-server-go/internal/oauth/server/repository"
+)
+
+// AuthorizationDetailService 인가 상세 정보 서비스
+//
+// [RFC 9396] 인가 상세 정보의 타입 조회와 타입별로 등록된 스키마를 이용한 검증 기능을 제공한다.
+//
+// [RFC 9396]: https://datatracker.ietf.org/doc/html/rfc9396
+type AuthorizationDetailService struct {
+	repo repository.AuthorizationDetailTypeRepository
+}
+
+func NewAuthorizationDetailService(repo repository.AuthorizationDetailTypeRepository) *AuthorizationDetailService {
+	return &AuthorizationDetailService{repo: repo}
+}
+
+// Retrieve 타입 이름으로 등록된 인가 상세 정보 타입을 조회한다.
+func (srv *AuthorizationDetailService) Retrieve(ctx context.Context, name string) (*detail.Type, bool) {
+	return srv.repo.FindByType(ctx, name)
+}
+
+// Retriever 요청 컨텍스트로 인가 상세 정보 타입을 조회하는 [detail.Retrieve] 함수를 반환한다.
+func (srv *AuthorizationDetailService) Retriever(ctx context.Context) detail.Retrieve {
+	return func(name string) (*detail.Type, bool) {
+		return srv.Retrieve(ctx, name)
+	}
+}
+
+// Types 등록된 모든 인가 상세 정보 타입의 이름을 반환한다.
+func (srv *AuthorizationDetailService) Types(ctx context.Context) []string {
+	var names []string
+	for _, t := range srv.repo.FindAll(ctx) {
+		names = append(names, t.Name())
+	}
+	return names
+}
+
+// Validate JSON 배열 문자열로 받은 인가 상세 정보를 변환하고 타입별로 등록된 스키마로 검증한다.
+// 형식이 잘못되었거나 등록되지 않은 타입, 스키마를 만족하지 않는 인가 상세 정보가 있는 경우 [oautherr.ErrInvalidAuthorizationDetails] 를 반환한다.
+func (srv *AuthorizationDetailService) Validate(ctx context.Context, v string) (detail.Details, error) {
+	details, err := detail.Parse(v)
+	if err != nil {
+		return nil, err
+	}
+	if err = detail.Validate(srv.Retriever(ctx), details); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"oauth-server-go/internal/oauth/authorization"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/scope"
+	"oauth-server-go/internal/oauth/server/pkg/gen"
+	"oauth-server-go/internal/oauth/server/repository"
+	"oauth-server-go/internal/oauth/token"
+	"oauth-server-go/internal/pkg/web"
+	"time"
+)
+
+// DeviceCodeService 디바이스 인가 요청 서비스
+//
+// [RFC 8628] 디바이스 인가 요청에 대한 관리 포인트를 제공하여
+// 새 디바이스 코드 생성 및 자원 소유자의 승인, 디바이스의 폴링 등을 작업한다.
+//
+// [RFC 8628]: https://datatracker.ietf.org/doc/html/rfc8628
+type DeviceCodeService struct {
+	repo repository.DeviceCodeRepository
+}
+
+func NewDeviceCodeService(repo repository.DeviceCodeRepository) *DeviceCodeService {
+	return &DeviceCodeService{repo: repo}
+}
+
+// NewDeviceCode 새 디바이스 인가 요청을 생성하여 저장소에 저장한다.
+//
+// Parameters:
+//   - c: 디바이스 인가를 요청한 클라이언트
+//   - requestScope: 공백으로 구분된 요청 스코프
+func (srv *DeviceCodeService) NewDeviceCode(ctx context.Context, c *client.Client, requestScope string) (*authorization.DeviceCode, error) {
+	if !c.AllowGrantType(string(token.GrantTypeDeviceCode)) {
+		return nil, fmt.Errorf("%w: device code grant is not allowed", oautherr.ErrUnauthorizedClient)
+	}
+
+	deviceCode, err := authorization.NewDeviceCode(c, scope.Split(requestScope), gen.GenerateRandomUUID, gen.GenerateUserCode)
+	if err != nil {
+		return nil, err
+	}
+	if err = srv.repo.Save(ctx, deviceCode); err != nil {
+		return nil, err
+	}
+	return deviceCode, nil
+}
+
+// RetrievePending 자원 소유자가 입력한 사용자 코드로 승인을 기다리는 디바이스 인가 요청을 조회한다.
+//
+// Returns:
+//   - *authorization.DeviceCode: 조회된 디바이스 인가 요청
+//   - bool: 조회 성공 여부 만료되었거나 이미 처리된 요청은 조회되지 않는다.
+func (srv *DeviceCodeService) RetrievePending(ctx context.Context, userCode string) (*authorization.DeviceCode, bool) {
+	deviceCode, ok := srv.repo.FindByUserCode(ctx, authorization.FormatUserCode(userCode))
+	if !ok || deviceCode.Status() != authorization.DeviceStatusPending || !deviceCode.Available() {
+		return nil, false
+	}
+	return deviceCode, true
+}
+
+// Approve 자원 소유자가 디바이스 인가 요청을 승인한다.
+// 승인한 스코프가 없는 경우 거부한 것으로 처리하며, 인증 정보(auth_time, acr, amr)는 자원 소유자의 로그인 정보를 사용한다.
+func (srv *DeviceCodeService) Approve(ctx context.Context, userCode string, owner *web.Authentication, scopes []string) (*authorization.DeviceCode, error) {
+	deviceCode, ok := srv.RetrievePending(ctx, userCode)
+	if !ok {
+		return nil, fmt.Errorf("%w: user code(%s) could not find", oautherr.ErrInvalidRequest, userCode)
+	}
+	if err := deviceCode.Approve(owner.Username, scopes, owner.AuthTime, owner.ACR, owner.AMR); err != nil {
+		return nil, err
+	}
+	if err := srv.repo.Update(ctx, deviceCode); err != nil {
+		return nil, err
+	}
+	return deviceCode, nil
+}
+
+// Poll 디바이스의 토큰 엔드포인트 폴링을 처리한다.
+// 승인, 거부 또는 만료로 더 이상 폴링 할 수 없는 디바이스 인가 요청은 반환 전 삭제하며,
+// 승인을 기다리는 중이라면 폴링 정보를 저장소에 갱신한다.
+func (srv *DeviceCodeService) Poll(ctx context.Context, c *client.Client, code string) (*authorization.DeviceCode, error) {
+	deviceCode, ok := srv.repo.FindByDeviceCode(ctx, code)
+	if !ok {
+		return nil, fmt.Errorf("%w: device code(%s) could not find", oautherr.ErrInvalidRequest, code)
+	}
+	if deviceCode.Client().Id() != c.Id() {
+		return nil, fmt.Errorf("%w: device code was not issued to client(%s)", oautherr.ErrInvalidClient, c.Id())
+	}
+
+	pollErr := deviceCode.Poll(time.Now())
+	if pollErr == nil || errors.Is(pollErr, oautherr.ErrExpiredToken) || errors.Is(pollErr, oautherr.ErrAccessDenied) {
+		if err := srv.repo.Delete(ctx, deviceCode); err != nil {
+			return nil, err
+		}
+	} else if err := srv.repo.Update(ctx, deviceCode); err != nil {
+		return nil, err
+	}
+
+	if pollErr != nil {
+		return nil, pollErr
+	}
+	return deviceCode, nil
+}
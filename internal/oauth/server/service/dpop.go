@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"oauth-server-go/internal/config/log"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/server/repository"
+	"oauth-server-go/internal/oauth/token"
+	"strings"
+	"time"
+)
+
+// DPoPService DPoP 증명 서비스
+//
+// [RFC 9449] DPoP 증명의 검증과 서버 nonce 발급, 증명 재사용(replay) 방지 등을 작업한다.
+//
+// [RFC 9449]: https://datatracker.ietf.org/doc/html/rfc9449
+type DPoPService struct {
+	repo repository.DPoPProofRepository
+
+	// baseURL 증명의 htu 클레임과 비교할 요청 URI의 기준 주소(인가 서버 식별자)
+	baseURL string
+
+	// nonce 서버 nonce 발급 및 검증 nil 인 경우 서버 nonce 를 사용하지 않는다.
+	nonce *token.DPoPNonce
+}
+
+func NewDPoPService(repo repository.DPoPProofRepository, baseURL string, nonce *token.DPoPNonce) *DPoPService {
+	return &DPoPService{repo: repo, baseURL: strings.TrimSuffix(baseURL, "/"), nonce: nonce}
+}
+
+// Nonce 클라이언트가 다음 증명에 사용할 새 서버 nonce 를 발급한다. 서버 nonce 를 사용하지 않는 경우 빈 문자열을 반환한다.
+func (srv *DPoPService) Nonce() string {
+	if srv.nonce == nil {
+		return ""
+	}
+	return srv.nonce.Issue(time.Now())
+}
+
+// Verify DPoP 증명을 검증한다.
+//
+// Parameters:
+//   - raw: DPoP 요청 헤더의 값
+//   - method: 증명을 전달한 요청의 HTTP 메소드
+//   - path: 증명을 전달한 요청의 경로 인가 서버 식별자와 합쳐 htu 클레임과 비교한다.
+//
+// 서버 nonce 를 사용하는 경우 증명의 nonce 가 유효하지 않으면 [oautherr.ErrUseDPoPNonce] 를,
+// 그 외 검증에 실패하거나 이미 사용된 증명인 경우 [oautherr.ErrInvalidDPoPProof] 를 반환한다.
+func (srv *DPoPService) Verify(ctx context.Context, raw, method, path string) (*token.DPoPProof, error) {
+	now := time.Now()
+	proof, err := token.ParseDPoPProof(raw, method, srv.baseURL+path, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if srv.nonce != nil && !srv.nonce.Validate(proof.Nonce(), now) {
+		return nil, fmt.Errorf("%w: nonce is missing or expired", oautherr.ErrUseDPoPNonce)
+	}
+
+	saved, err := srv.repo.SaveIfAbsent(ctx, proof, proof.IssuedAt().Add(token.DPoPProofMaxAge))
+	if err != nil {
+		log.Sugared().Errorf("error occurred during save dpop proof(%s): %v", proof.JTI(), err)
+		return nil, fmt.Errorf("%w: error occurred during save dpop proof", oautherr.ErrUnknown)
+	}
+	if !saved {
+		return nil, fmt.Errorf("%w: proof(%s) is already used", oautherr.ErrInvalidDPoPProof, proof.JTI())
+	}
+	return proof, nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"oauth-server-go/internal/config/log"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/key"
+	"oauth-server-go/internal/oauth/server/repository"
+	"slices"
+	"sync"
+	"time"
+)
+
+// KeyService 토큰 서명키 서비스
+//
+// 저장소의 서명키를 메모리에 유지하며 서명과 공개키 목록(JWKS)을 제공하고, 교체 정책에 따라 서명키를 교체한다.
+type KeyService struct {
+	repo       repository.KeyRepository
+	rotation   *key.Rotation
+	algorithms []key.Algorithm
+	generate   key.GenerateID
+
+	// now 교체 기준 시간을 반환하는 함수
+	now func() time.Time
+
+	// rotating 서명키 교체가 동시에 수행되지 않도록 한다.
+	rotating sync.Mutex
+
+	mu   sync.RWMutex
+	keys key.Set
+}
+
+// NewKeyService 새 서명키 서비스를 생성한다. algorithms 의 첫번째 알고리즘이 기본 서명 알고리즘으로 사용된다.
+func NewKeyService(repo repository.KeyRepository, rotation *key.Rotation, algorithms []key.Algorithm, generate key.GenerateID) *KeyService {
+	return &KeyService{
+		repo:       repo,
+		rotation:   rotation,
+		algorithms: algorithms,
+		generate:   generate,
+		now:        time.Now,
+	}
+}
+
+// Algorithms 서명에 사용하는 알고리즘 목록을 반환한다.
+func (srv *KeyService) Algorithms() []key.Algorithm {
+	return srv.algorithms
+}
+
+// Rotate 저장소를 잠근 상태에서 서명키를 다시 읽어 교체 정책을 적용하고 변경 사항을 하나의 트랜잭션으로 저장소에 반영한다.
+// 여러 서버 인스턴스가 동시에 교체를 시도 하더라도 저장소의 잠금으로 순서대로 수행되며 다른 서버 인스턴스에서 교체한 서명키도 이때 함께 반영된다.
+//
+// 교체는 저장소에서 새로 읽은 서명키로 수행되며 모든 변경 사항이 반영된 후에 서명에 사용되는 키 목록을 교체하므로
+// 교체 중에도 기존 서명키로 서명을 할 수 있다.
+func (srv *KeyService) Rotate(ctx context.Context) error {
+	srv.rotating.Lock()
+	defer srv.rotating.Unlock()
+
+	keys, plan, err := srv.repo.Rotate(ctx, func(keys key.Set) (*key.Plan, error) {
+		return srv.rotation.Rotate(keys, srv.algorithms, srv.now(), srv.generate)
+	})
+	if err != nil {
+		return err
+	}
+
+	srv.mu.Lock()
+	srv.keys = keys
+	srv.mu.Unlock()
+	if !plan.Empty() {
+		log.Sugared().Infof("signing keys rotated (created: %d, updated: %d, deleted: %d)",
+			len(plan.Created), len(plan.Updated), len(plan.Deleted))
+	}
+	return nil
+}
+
+// Schedule 인자로 받은 주기마다 서명키 교체를 수행한다. 컨텍스트가 종료되면 교체를 멈춘다.
+func (srv *KeyService) Schedule(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := srv.Rotate(ctx); err != nil {
+					log.Sugared().Errorf("error occurred during rotate signing keys: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Sign 기본 서명 알고리즘의 활성화 된 서명키로 클레임을 서명한다. [token.Sign] 의 구현체로 사용된다.
+func (srv *KeyService) Sign(typ string, claims any) (string, error) {
+	return srv.SignWith(srv.algorithms[0], typ, claims)
+}
+
+// SignWith 인자로 받은 알고리즘의 활성화 된 서명키로 클레임을 서명한다.
+func (srv *KeyService) SignWith(alg key.Algorithm, typ string, claims any) (string, error) {
+	srv.mu.RLock()
+	active := srv.keys.Active(alg)
+	srv.mu.RUnlock()
+
+	if active == nil {
+		return "", fmt.Errorf("%w: active signing key is not found(%s)", oautherr.ErrUnknown, alg)
+	}
+	return active.Sign(typ, claims)
+}
+
+// JWKS 공개키 목록을 반환한다.
+//
+// 다른 서버 인스턴스에서 교체한 서명키도 게시 될 수 있도록 저장소의 서명키 식별자(kid) 목록을 조회하여
+// 메모리의 서명키와 다른 경우 저장소에서 서명키를 다시 읽는다. 저장소 조회에 실패한 경우 메모리의 서명키로 공개키 목록을 반환한다.
+func (srv *KeyService) JWKS(ctx context.Context) jose.JSONWebKeySet {
+	if err := srv.refresh(ctx); err != nil {
+		log.Sugared().Errorf("error occurred during refresh signing keys: %v", err)
+	}
+
+	srv.mu.RLock()
+	defer srv.mu.RUnlock()
+	return srv.keys.JWKS()
+}
+
+// refresh 저장소의 서명키 식별자 목록이 메모리의 서명키와 다른 경우 저장소에서 서명키를 다시 읽는다.
+// 서명키를 읽을 때 개인키를 복호화 해야 하므로 동시에 여러 요청이 들어오더라도 한번만 읽도록 교체와 같은 잠금을 사용한다.
+func (srv *KeyService) refresh(ctx context.Context) error {
+	kids, err := srv.repo.FindKids(ctx)
+	if err != nil {
+		return err
+	}
+	if srv.holds(kids) {
+		return nil
+	}
+
+	srv.rotating.Lock()
+	defer srv.rotating.Unlock()
+	if srv.holds(kids) {
+		return nil
+	}
+	keys, err := srv.repo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	srv.mu.Lock()
+	srv.keys = keys
+	srv.mu.Unlock()
+	return nil
+}
+
+// holds 메모리의 서명키 식별자 목록이 인자로 받은 식별자 목록과 같은지 여부
+func (srv *KeyService) holds(kids []string) bool {
+	srv.mu.RLock()
+	current := srv.keys.Kids()
+	srv.mu.RUnlock()
+
+	kids = slices.Clone(kids)
+	slices.Sort(kids)
+	slices.Sort(current)
+	return slices.Equal(kids, current)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/config/log"
+	"oauth-server-go/internal/oauth/key"
+	"oauth-server-go/internal/oauth/server/repository"
+	"oauth-server-go/internal/oauth/token"
+	"testing"
+	"time"
+)
+
+// newTestKeyService 인자로 받은 디렉토리의 서명키 파일을 공유하는 서명키 서비스를 생성한다.
+// 같은 디렉토리로 생성한 서비스들은 같은 저장소를 사용하는 서로 다른 서버 인스턴스처럼 동작한다.
+func newTestKeyService(t *testing.T, dir string, rotation *key.Rotation, generate key.GenerateID, now *time.Time) *KeyService {
+	repo, err := repository.NewKeyFileBridge(dir, "test_key_secret")
+	assert.Nil(t, err)
+	srv := NewKeyService(repo, rotation, []key.Algorithm{key.AlgorithmES256}, generate)
+	srv.now = func() time.Time { return *now }
+	return srv
+}
+
+// signedKid 서비스로 서명한 JWS 의 kid 헤더를 반환한다.
+func signedKid(t *testing.T, srv *KeyService) string {
+	signed, err := srv.Sign("at+jwt", map[string]string{"sub": "username"})
+	assert.Nil(t, err)
+	jws, err := jose.ParseSigned(signed, []jose.SignatureAlgorithm{jose.ES256})
+	assert.Nil(t, err)
+	return jws.Signatures[0].Header.KeyID
+}
+
+// published 공개키 목록에 인자로 받은 kid 가 게시 되어 있는지 여부
+func published(jwks jose.JSONWebKeySet, kid string) bool {
+	return len(jwks.Key(kid)) > 0
+}
+
+func TestKeyService_Rotate(t *testing.T) {
+	log.NewLogger(&log.Config{Dir: t.TempDir(), Name: "test.log", Level: "ERROR"})
+
+	t.Run("다른 인스턴스가 교체를 반영하기 전까지 서명한 키는 토큰이 만료될 때까지 공개키 목록에 유지됨", func(t *testing.T) {
+		ctx := context.Background()
+		dir := t.TempDir()
+		checkInterval := time.Hour
+
+		seq := 0
+		generate := func() string {
+			seq++
+			return fmt.Sprintf("kid_%d", seq)
+		}
+		rotation := &key.Rotation{Period: 24 * time.Hour, Retention: token.MaxSignedLifetime + checkInterval}
+
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		a := newTestKeyService(t, dir, rotation, generate, &now)
+		b := newTestKeyService(t, dir, rotation, generate, &now)
+		assert.Nil(t, a.Rotate(ctx))
+		assert.Nil(t, b.Rotate(ctx))
+		retired := signedKid(t, b)
+
+		// 인스턴스 A 가 서명키를 교체 하였지만 인스턴스 B 는 아직 교체 확인 주기가 되지 않아 퇴역한 키로 서명한다.
+		now = now.Add(rotation.Period)
+		assert.Nil(t, a.Rotate(ctx))
+		assert.NotEqual(t, retired, signedKid(t, a))
+
+		// 인스턴스 B 가 교체를 반영하기 직전에 서명한 토큰이 만료되기 전까지 인스턴스 A 는 퇴역한 키를 게시한다.
+		now = now.Add(checkInterval - time.Second)
+		assert.Equal(t, retired, signedKid(t, b))
+		now = now.Add(token.MaxSignedLifetime)
+		assert.Nil(t, a.Rotate(ctx))
+		assert.True(t, published(a.JWKS(ctx), retired))
+
+		// 인스턴스 B 가 교체를 반영한 후 서명한 토큰이 모두 만료되면 퇴역한 키는 폐기된다.
+		assert.Nil(t, b.Rotate(ctx))
+		assert.NotEqual(t, retired, signedKid(t, b))
+		now = now.Add(2 * time.Second)
+		assert.Nil(t, a.Rotate(ctx))
+		assert.False(t, published(a.JWKS(ctx), retired))
+		assert.False(t, published(b.JWKS(ctx), retired))
+	})
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"oauth-server-go/internal/oauth/authorization"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/server/pkg/gen"
+	"oauth-server-go/internal/oauth/server/repository"
+)
+
+// PushedRequestService 푸시된 인가 요청 서비스
+//
+// [RFC 9126] 푸시된 인가 요청에 대한 관리 포인트를 제공하여
+// 검증된 인가 요청의 저장과 인가 엔드포인트에서의 조회 등을 작업한다.
+//
+// [RFC 9126]: https://datatracker.ietf.org/doc/html/rfc9126
+type PushedRequestService struct {
+	repo repository.PushedRequestRepository
+}
+
+func NewPushedRequestService(repo repository.PushedRequestRepository) *PushedRequestService {
+	return &PushedRequestService{repo: repo}
+}
+
+// Push 검증이 완료된 인가 요청을 저장하고 요청 URI를 발급한다.
+func (srv *PushedRequestService) Push(ctx context.Context, request *authorization.Request) (*authorization.PushedRequest, error) {
+	pushedRequest := authorization.NewPushedRequest(request, gen.GenerateRandomUUID)
+	if err := srv.repo.Save(ctx, pushedRequest); err != nil {
+		return nil, err
+	}
+	return pushedRequest, nil
+}
+
+// Consume 요청 URI로 푸시된 인가 요청을 조회하여 인가 요청을 반환한다.
+// 요청 URI는 한번만 사용 할 수 있도록 조회된 푸시된 인가 요청은 반환 전 삭제한다.
+// 다른 클라이언트가 요청 URI를 소모하지 못하도록 요청 URI를 발급 받은 클라이언트인지 먼저 확인 후 삭제한다.
+func (srv *PushedRequestService) Consume(ctx context.Context, c *client.Client, requestURI string) (*authorization.Request, error) {
+	pushedRequest, ok := srv.repo.FindByRequestURI(ctx, requestURI)
+	if !ok {
+		return nil, fmt.Errorf("%w: request_uri(%s) could not find", oautherr.ErrInvalidRequest, requestURI)
+	}
+	request, err := pushedRequest.Resolve(c.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err = srv.repo.Delete(ctx, pushedRequest); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
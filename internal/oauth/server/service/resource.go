@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"oauth-server-go/internal/oauth/resource"
+	"oauth-server-go/internal/oauth/server/repository"
+)
+
+// ResourceServerService 자원 서버 서비스
+//
+// [RFC 8707] 자원 지시자로 요청된 자원 서버의 조회와 검증 기능을 제공한다.
+//
+// [RFC 8707]: https://datatracker.ietf.org/doc/html/rfc8707
+type ResourceServerService struct {
+	repo repository.ResourceServerRepository
+}
+
+func NewResourceServerService(repo repository.ResourceServerRepository) *ResourceServerService {
+	return &ResourceServerService{repo: repo}
+}
+
+// Retrieve 자원 지시자로 등록된 자원 서버를 조회한다.
+func (srv *ResourceServerService) Retrieve(ctx context.Context, uri string) (*resource.Server, bool) {
+	return srv.repo.FindByURI(ctx, uri)
+}
+
+// RetrieveByClient 클라이언트 아이디로 클라이언트가 사용하는 자원 서버 목록을 조회한다.
+func (srv *ResourceServerService) RetrieveByClient(ctx context.Context, clientID string) []*resource.Server {
+	return srv.repo.FindByClientID(ctx, clientID)
+}
+
+// Retriever 요청 컨텍스트로 자원 서버를 조회하는 [resource.Retrieve] 함수를 반환한다.
+func (srv *ResourceServerService) Retriever(ctx context.Context) resource.Retrieve {
+	return func(uri string) (*resource.Server, bool) {
+		return srv.Retrieve(ctx, uri)
+	}
+}
+
+// Validate 인가 요청의 자원 지시자가 모두 등록된 자원 서버인지 확인한다.
+// 형식이 잘못되었거나 등록되지 않은 자원 지시자가 있는 경우 [oautherr.ErrInvalidTarget] 을 반환한다.
+func (srv *ResourceServerService) Validate(ctx context.Context, resources []string) error {
+	_, err := resource.Resolve(srv.Retriever(ctx), resources)
+	return err
+}
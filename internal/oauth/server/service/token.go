@@ -3,10 +3,13 @@ package service
 import (
 	"context"
 	"fmt"
+	"github.com/go-jose/go-jose/v4"
 	"oauth-server-go/internal/config/log"
 	"oauth-server-go/internal/oauth/authorization"
 	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/detail"
 	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/resource"
 	"oauth-server-go/internal/oauth/server/repository"
 	"oauth-server-go/internal/oauth/token"
 	"oauth-server-go/internal/pkg/auth"
@@ -15,6 +18,21 @@ import (
 
 type RetrieveAuthorizationCode func(ctx context.Context, code string) (*authorization.Code, bool, error)
 
+// PollDeviceCode 디바이스 코드로 토큰 엔드포인트 폴링을 처리한다.
+type PollDeviceCode func(ctx context.Context, c *client.Client, deviceCode string) (*authorization.DeviceCode, error)
+
+// PollBackchannelAuthentication 인증 요청 식별자로 토큰 엔드포인트 폴링을 처리한다.
+type PollBackchannelAuthentication func(ctx context.Context, c *client.Client, authReqID string) (*authorization.BackchannelAuthentication, error)
+
+// RetrieveResourceServer 자원 지시자로 등록된 자원 서버를 조회한다.
+type RetrieveResourceServer func(ctx context.Context, uri string) (*resource.Server, bool)
+
+// RetrieveClientResourceServers 클라이언트 아이디로 클라이언트가 사용하는 자원 서버 목록을 조회한다.
+type RetrieveClientResourceServers func(ctx context.Context, clientID string) []*resource.Server
+
+// RetrieveAuthorizationDetailType 타입 이름으로 등록된 인가 상세 정보 타입을 조회한다.
+type RetrieveAuthorizationDetailType func(ctx context.Context, name string) (*detail.Type, bool)
+
 // GrantToken 신규 토큰을 발행한다.
 type GrantToken func(c *client.Client, request *token.Request) (*token.AccessToken, *token.RefreshToken, error)
 
@@ -29,6 +47,72 @@ type TokenIssuer struct {
 
 	GenerateAccessToken  token.GenerateToken
 	GenerateRefreshToken token.GenerateToken
+
+	// EncodeAccessToken 클라이언트의 토큰 형식이 JWT인 경우 엑세스 토큰의 토큰값을 생성하는 함수
+	EncodeAccessToken token.EncodeToken
+
+	// Issuer ID 토큰 발행자(iss) 식별자
+	Issuer string
+
+	// SignIDToken ID 토큰 서명 함수
+	SignIDToken token.Sign
+
+	// PollDeviceCode 디바이스 코드 폴링 함수 설정되지 않은 경우 디바이스 인가 승인 방식을 지원하지 않는다.
+	PollDeviceCode PollDeviceCode
+
+	// PollBackchannelAuthentication 백채널 인증 요청 폴링 함수 설정되지 않은 경우 백채널 인증 승인 방식을 지원하지 않는다.
+	PollBackchannelAuthentication PollBackchannelAuthentication
+
+	// TrustedIssuers JWT 인가 승인 방식에서 어설션을 발급 할 수 있는 신뢰하는 발급자 목록
+	// 설정되지 않은 경우 JWT 인가 승인 방식을 지원하지 않는다.
+	TrustedIssuers token.TrustedIssuers
+
+	// FetchJWKS 신뢰하는 발급자의 jwks_uri 에서 공개키 목록을 조회하는 함수
+	FetchJWKS client.FetchJWKS
+
+	// AssertionAudience JWT 어설션의 aud 클레임에 포함되어야 하는 인가 서버 식별자나 토큰 엔드포인트 URL 목록
+	AssertionAudience []string
+
+	// RetrieveResourceServer 자원 서버 조회 함수 설정되지 않은 경우 자원 지시자(resource)를 지원하지 않는다.
+	RetrieveResourceServer RetrieveResourceServer
+
+	// RetrieveClientResourceServers 클라이언트가 사용하는 자원 서버 조회 함수
+	// 설정되지 않은 경우 토큰 교환시 교환을 요청한 클라이언트에게 발급된 토큰만 교환 할 수 있다.
+	RetrieveClientResourceServers RetrieveClientResourceServers
+
+	// RetrieveAuthorizationDetailType 인가 상세 정보 타입 조회 함수 설정되지 않은 경우 인가 코드나 리플레시 토큰으로 부여된 인가 상세 정보의 축소만 지원한다.
+	RetrieveAuthorizationDetailType RetrieveAuthorizationDetailType
+}
+
+// resourceRetriever 요청 컨텍스트로 자원 서버를 조회하는 함수를 반환한다. 자원 서버 조회 함수가 설정되지 않은 경우 nil 을 반환한다.
+func (srv *TokenIssuer) resourceRetriever(ctx context.Context) resource.Retrieve {
+	if srv.RetrieveResourceServer == nil {
+		return nil
+	}
+	return func(uri string) (*resource.Server, bool) {
+		return srv.RetrieveResourceServer(ctx, uri)
+	}
+}
+
+// clientResourceRetriever 요청 컨텍스트로 클라이언트가 사용하는 자원 서버를 조회하는 함수를 반환한다.
+// 조회 함수가 설정되지 않은 경우 nil 을 반환한다.
+func (srv *TokenIssuer) clientResourceRetriever(ctx context.Context) resource.RetrieveByClient {
+	if srv.RetrieveClientResourceServers == nil {
+		return nil
+	}
+	return func(clientID string) []*resource.Server {
+		return srv.RetrieveClientResourceServers(ctx, clientID)
+	}
+}
+
+// detailTypeRetriever 요청 컨텍스트로 인가 상세 정보 타입을 조회하는 함수를 반환한다. 타입 조회 함수가 설정되지 않은 경우 nil 을 반환한다.
+func (srv *TokenIssuer) detailTypeRetriever(ctx context.Context) detail.Retrieve {
+	if srv.RetrieveAuthorizationDetailType == nil {
+		return nil
+	}
+	return func(name string) (*detail.Type, bool) {
+		return srv.RetrieveAuthorizationDetailType(ctx, name)
+	}
 }
 
 func (srv *TokenIssuer) chooseGranter(ctx context.Context, t token.GrantType) (GrantToken, error) {
@@ -47,6 +131,8 @@ func (srv *TokenIssuer) chooseGranter(ctx context.Context, t token.GrantType) (G
 				AccessTokenGenerator:      srv.GenerateAccessToken,
 				RefreshTokenGenerator:     srv.GenerateRefreshToken,
 				RetrieveAuthorizationCode: authCodeRetriever,
+				Issuer:                    srv.Issuer,
+				RetrieveResourceServer:    srv.resourceRetriever(ctx),
 			}
 
 			return granter.GenerateToken(c, request)
@@ -58,17 +144,20 @@ func (srv *TokenIssuer) chooseGranter(ctx context.Context, t token.GrantType) (G
 			}
 
 			granter := token.RefreshTokenGranter{
-				AccessTokenGenerator:  srv.GenerateAccessToken,
-				RefreshTokenGenerator: srv.GenerateRefreshToken,
-				RetrieveRefreshToken:  refreshTokenRetriever,
-				Rotation:              true,
+				AccessTokenGenerator:   srv.GenerateAccessToken,
+				RefreshTokenGenerator:  srv.GenerateRefreshToken,
+				RetrieveRefreshToken:   refreshTokenRetriever,
+				Rotation:               true,
+				RetrieveResourceServer: srv.resourceRetriever(ctx),
 			}
 			return granter.GenerateToken(c, request)
 		}, nil
 	case token.GrantTypeClientCredentials:
 		return func(c *client.Client, request *token.Request) (*token.AccessToken, *token.RefreshToken, error) {
 			granter := token.ClientCredentialsGranter{
-				AccessTokenGenerator: srv.GenerateAccessToken,
+				AccessTokenGenerator:            srv.GenerateAccessToken,
+				RetrieveResourceServer:          srv.resourceRetriever(ctx),
+				RetrieveAuthorizationDetailType: srv.detailTypeRetriever(ctx),
 			}
 			act, err := granter.GenerateToken(c, request)
 			return act, nil, err
@@ -76,9 +165,81 @@ func (srv *TokenIssuer) chooseGranter(ctx context.Context, t token.GrantType) (G
 	case token.GrantTypePassword:
 		return func(c *client.Client, request *token.Request) (*token.AccessToken, *token.RefreshToken, error) {
 			granter := token.ResourceOwnerPasswordCredentialsGranter{
-				Authenticate:          srv.AuthenticateResourceOwner,
-				AccessTokenGenerator:  srv.GenerateAccessToken,
-				RefreshTokenGenerator: srv.GenerateRefreshToken,
+				Authenticate:           srv.AuthenticateResourceOwner,
+				AccessTokenGenerator:   srv.GenerateAccessToken,
+				RefreshTokenGenerator:  srv.GenerateRefreshToken,
+				RetrieveResourceServer: srv.resourceRetriever(ctx),
+			}
+			return granter.GenerateToken(c, request)
+		}, nil
+	case token.GrantTypeDeviceCode:
+		if srv.PollDeviceCode == nil {
+			return nil, fmt.Errorf("%w: undefined grant type", oautherr.ErrInvalidRequest)
+		}
+		return func(c *client.Client, request *token.Request) (*token.AccessToken, *token.RefreshToken, error) {
+			poll := func(c *client.Client, deviceCode string) (*authorization.DeviceCode, error) {
+				return srv.PollDeviceCode(ctx, c, deviceCode)
+			}
+
+			granter := token.DeviceCodeGranter{
+				AccessTokenGenerator:   srv.GenerateAccessToken,
+				RefreshTokenGenerator:  srv.GenerateRefreshToken,
+				PollDeviceCode:         poll,
+				RetrieveResourceServer: srv.resourceRetriever(ctx),
+			}
+			return granter.GenerateToken(c, request)
+		}, nil
+	case token.GrantTypeCIBA:
+		if srv.PollBackchannelAuthentication == nil {
+			return nil, fmt.Errorf("%w: undefined grant type", oautherr.ErrInvalidRequest)
+		}
+		return func(c *client.Client, request *token.Request) (*token.AccessToken, *token.RefreshToken, error) {
+			poll := func(c *client.Client, authReqID string) (*authorization.BackchannelAuthentication, error) {
+				return srv.PollBackchannelAuthentication(ctx, c, authReqID)
+			}
+
+			granter := token.BackchannelGranter{
+				AccessTokenGenerator:          srv.GenerateAccessToken,
+				RefreshTokenGenerator:         srv.GenerateRefreshToken,
+				PollBackchannelAuthentication: poll,
+				Issuer:                        srv.Issuer,
+				RetrieveResourceServer:        srv.resourceRetriever(ctx),
+			}
+			return granter.GenerateToken(c, request)
+		}, nil
+	case token.GrantTypeTokenExchange:
+		return func(c *client.Client, request *token.Request) (*token.AccessToken, *token.RefreshToken, error) {
+			accessTokenRetriever := func(accessToken string) (*token.AccessToken, bool) {
+				return srv.Repository.FindAccessTokenByValue(ctx, accessToken)
+			}
+			refreshTokenRetriever := func(refreshToken string) (*token.RefreshToken, bool) {
+				return srv.Repository.FindRefreshTokenByValue(ctx, refreshToken)
+			}
+
+			granter := token.TokenExchangeGranter{
+				AccessTokenGenerator:    srv.GenerateAccessToken,
+				RetrieveAccessToken:     accessTokenRetriever,
+				RetrieveRefreshToken:    refreshTokenRetriever,
+				RetrieveResourceServer:  srv.resourceRetriever(ctx),
+				RetrieveClientResources: srv.clientResourceRetriever(ctx),
+			}
+			return granter.GenerateToken(c, request)
+		}, nil
+	case token.GrantTypeJWTBearer:
+		if len(srv.TrustedIssuers) == 0 {
+			return nil, fmt.Errorf("%w: undefined grant type", oautherr.ErrInvalidRequest)
+		}
+		return func(c *client.Client, request *token.Request) (*token.AccessToken, *token.RefreshToken, error) {
+			fetch := func(uri string) (*jose.JSONWebKeySet, error) {
+				return srv.FetchJWKS(ctx, uri)
+			}
+
+			granter := token.JWTBearerGranter{
+				AccessTokenGenerator:   srv.GenerateAccessToken,
+				TrustedIssuers:         srv.TrustedIssuers,
+				FetchJWKS:              fetch,
+				Audience:               srv.AssertionAudience,
+				RetrieveResourceServer: srv.resourceRetriever(ctx),
 			}
 			return granter.GenerateToken(c, request)
 		}, nil
@@ -87,7 +248,22 @@ func (srv *TokenIssuer) chooseGranter(ctx context.Context, t token.GrantType) (G
 	}
 }
 
+// GrantTypes 토큰 엔드포인트에서 처리 할 수 있는 인가 방식 목록을 반환한다.
+func (srv *TokenIssuer) GrantTypes() []token.GrantType {
+	var types []token.GrantType
+	for _, t := range token.TokenEndpointGrantTypes {
+		if _, err := srv.chooseGranter(context.Background(), t); err == nil {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
 func (srv *TokenIssuer) Issue(ctx context.Context, c *client.Client, request *token.Request) (*token.AccessToken, *token.RefreshToken, error) {
+	if !c.AllowGrantType(string(request.Type)) {
+		return nil, nil, fmt.Errorf("%w: grant type(%s) is not allowed", oautherr.ErrUnauthorizedClient, request.Type)
+	}
+
 	granter, err := srv.chooseGranter(ctx, request.Type)
 	if err != nil {
 		return nil, nil, err
@@ -98,6 +274,28 @@ func (srv *TokenIssuer) Issue(ctx context.Context, c *client.Client, request *to
 		return nil, nil, err
 	}
 
+	// cnf 클레임이 JWT 엑세스 토큰에 포함될 수 있도록 DPoP 및 인증서 바인딩은 인코딩 전에 한다.
+	if request.DPoPJKT != "" {
+		token.BindDPoPKey(request.DPoPJKT, accessToken, refreshToken)
+	}
+	if c.CertificateBoundAccessTokens() {
+		if request.CertificateThumbprint == "" {
+			return nil, nil, fmt.Errorf("%w: client(%s) requires client certificate to bind access token", oautherr.ErrInvalidRequest, c.Id())
+		}
+		token.BindCertificate(request.CertificateThumbprint, accessToken, refreshToken)
+	}
+
+	if err = srv.Encode(c, accessToken); err != nil {
+		return nil, nil, err
+	}
+
+	// at_hash 클레임 계산을 위해 ID 토큰은 엑세스 토큰의 인코딩이 끝난 후 서명한다.
+	if idToken := accessToken.IDToken(); idToken != nil {
+		if err = idToken.Sign(srv.SignIDToken, accessToken.Value()); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	err = srv.Repository.Transaction(ctx, func(r repository.TokenRepository) error {
 		if err = r.SaveAccessToken(ctx, accessToken); err != nil {
 			return fmt.Errorf("error occurred while saving access token: %w", err)
@@ -128,6 +326,18 @@ func (srv *TokenIssuer) Issue(ctx context.Context, c *client.Client, request *to
 	return accessToken, refreshToken, err
 }
 
+// Encode 클라이언트에 설정된 토큰 형식에 따라 엑세스 토큰의 토큰값을 인코딩 한다.
+// 토큰 형식이 [client.TokenFormatOpaque] 인 경우 아무런 처리도 하지 않는다.
+func (srv *TokenIssuer) Encode(c *client.Client, accessToken *token.AccessToken) error {
+	if c.TokenFormat() != client.TokenFormatJWT {
+		return nil
+	}
+	if err := accessToken.Encode(srv.EncodeAccessToken); err != nil {
+		return fmt.Errorf("error occurred while encoding access token: %w", err)
+	}
+	return nil
+}
+
 // TokenService 엑세스 토큰 및 리플레시 토큰에 대한 관리 포인트를 제공하는 서비스 구조체
 type TokenService struct {
 	repo repository.TokenRepository
@@ -173,6 +383,66 @@ func (srv *TokenService) Inspection(ctx context.Context, c *client.Client, reque
 	return inspection, true, nil
 }
 
+// Revoke 요청한 토큰을 폐기한다.
+// 리플레시 토큰을 폐기하는 경우 리플레시 토큰과 연결된 엑세스 토큰도 함께 폐기한다.
+//
+// token_type_hint 로 지정된 타입의 토큰을 먼저 조회하며 찾지 못한 경우 나머지 타입의 토큰을 조회한다.
+// 조회된 토큰이 없는 경우 [RFC 7009] 에 따라 에러 없이 종료한다.
+//
+// [RFC 7009]: https://datatracker.ietf.org/doc/html/rfc7009#section-2.2
+func (srv *TokenService) Revoke(ctx context.Context, c *client.Client, request *token.RevocationRequest) error {
+	var accessToken *token.AccessToken
+	var refreshToken *token.RefreshToken
+	findAccessToken := func() bool {
+		accessToken, _ = srv.repo.FindAccessTokenByValue(ctx, request.Token)
+		return accessToken != nil
+	}
+	findRefreshToken := func() bool {
+		refreshToken, _ = srv.repo.FindRefreshTokenByValue(ctx, request.Token)
+		if refreshToken != nil {
+			accessToken = refreshToken.Token()
+		}
+		return refreshToken != nil
+	}
+	if request.TokenTypeHint == token.TypeHintRefreshToken {
+		if !findRefreshToken() {
+			findAccessToken()
+		}
+	} else if !findAccessToken() {
+		findRefreshToken()
+	}
+
+	// 조회된 토큰이 없을 경우 함수 종료
+	if accessToken == nil {
+		return nil
+	}
+
+	if accessToken.Client().Id() != c.Id() {
+		return fmt.Errorf("%w: token was not issued to the client", oautherr.ErrUnauthorizedClient)
+	}
+
+	return srv.repo.Transaction(ctx, func(r repository.TokenRepository) error {
+		if refreshToken != nil {
+			if err := r.DeleteRefreshToken(ctx, refreshToken); err != nil {
+				return fmt.Errorf("error occurred while deleting refresh token: %w", err)
+			}
+		}
+		if err := r.DeleteAccessToken(ctx, accessToken); err != nil {
+			return fmt.Errorf("error occurred while deleting access token: %w", err)
+		}
+		return nil
+	})
+}
+
+// Retrieve 저장소에서 엑세스 토큰을 조회한다.
+//
+// Returns:
+//   - *token.AccessToken: 조회된 엑세스 토큰
+//   - bool: 조회 성공 여부
+func (srv *TokenService) Retrieve(ctx context.Context, value string) (*token.AccessToken, bool) {
+	return srv.repo.FindAccessTokenByValue(ctx, value)
+}
+
 func (srv *TokenService) GetIssuedTokens(ctx context.Context, username string) []token.AccessToken {
 	return srv.repo.FindAccessTokenByUsername(ctx, username)
 }
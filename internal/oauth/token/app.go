@@ -0,0 +1,147 @@
+package token
+
+import (
+	"oauth-server-go/internal/oauth/authorization"
+	"oauth-server-go/internal/oauth/client"
+	"slices"
+	"time"
+)
+
+// ConnectedApp 자원 소유자가 인가한 클라이언트(앱)
+//
+// 자원 소유자의 동의와 클라이언트에게 발급된 엑세스 토큰, 리플레시 토큰을 클라이언트 별로 묶어
+// 자원 소유자가 토큰 단위가 아닌 앱 단위로 부여한 권한을 확인하고 취소 할 수 있도록 한다.
+type ConnectedApp struct {
+	client *client.Client
+
+	// consent 자원 소유자가 클라이언트에게 한 동의. 기억된 동의가 없는 경우 nil 이다.
+	consent *authorization.Consent
+
+	accessTokens  []*AccessToken
+	refreshTokens []*RefreshToken
+}
+
+// GroupByClient 자원 소유자의 동의, 엑세스 토큰, 리플레시 토큰을 클라이언트 별로 묶어 반환한다.
+// 반환되는 목록은 마지막 토큰 발급 시각의 내림차순으로 정렬된다.
+func GroupByClient(consents []*authorization.Consent, accessTokens []AccessToken, refreshTokens []RefreshToken) []*ConnectedApp {
+	var apps []*ConnectedApp
+	find := func(c *client.Client) *ConnectedApp {
+		for _, app := range apps {
+			if app.client.Id() == c.Id() {
+				return app
+			}
+		}
+		app := &ConnectedApp{client: c}
+		apps = append(apps, app)
+		return app
+	}
+
+	for _, c := range consents {
+		find(c.Client()).consent = c
+	}
+	for _, t := range accessTokens {
+		app := find(t.Client())
+		app.accessTokens = append(app.accessTokens, &t)
+	}
+	for _, t := range refreshTokens {
+		app := find(t.Token().Client())
+		app.refreshTokens = append(app.refreshTokens, &t)
+	}
+
+	slices.SortStableFunc(apps, func(a, b *ConnectedApp) int {
+		return b.LastIssuedAt().Compare(a.LastIssuedAt())
+	})
+	return apps
+}
+
+func (a *ConnectedApp) Client() *client.Client {
+	return a.client
+}
+
+func (a *ConnectedApp) Consent() *authorization.Consent {
+	return a.consent
+}
+
+func (a *ConnectedApp) AccessTokens() []*AccessToken {
+	return a.accessTokens
+}
+
+func (a *ConnectedApp) RefreshTokens() []*RefreshToken {
+	return a.refreshTokens
+}
+
+// Scopes 동의한 스코프와 토큰에 부여된 스코프를 중복 없이 합쳐 반환한다.
+func (a *ConnectedApp) Scopes() []string {
+	var scopes []string
+	add := func(s []string) {
+		for _, e := range s {
+			if !slices.Contains(scopes, e) {
+				scopes = append(scopes, e)
+			}
+		}
+	}
+	if a.consent != nil {
+		add(a.consent.Scopes())
+	}
+	for _, t := range a.accessTokens {
+		add(t.Scopes())
+	}
+	for _, t := range a.refreshTokens {
+		add(t.Scopes())
+	}
+	return scopes
+}
+
+// AuthorizedAt 자원 소유자가 클라이언트를 처음 인가한 시각을 반환한다. 동의 시각과 토큰 발급 시각 중 가장 이른 시각이다.
+func (a *ConnectedApp) AuthorizedAt() time.Time {
+	var first time.Time
+	earlier := func(t time.Time) {
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+	}
+	if a.consent != nil {
+		earlier(a.consent.Start())
+	}
+	for _, t := range a.accessTokens {
+		earlier(t.Start())
+	}
+	for _, t := range a.refreshTokens {
+		earlier(t.Start())
+	}
+	return first
+}
+
+// LastIssuedAt 클라이언트에게 마지막으로 토큰을 발급한 시각을 반환한다. 발급된 토큰이 없는 경우 zero value 를 반환한다.
+func (a *ConnectedApp) LastIssuedAt() time.Time {
+	var last time.Time
+	for _, t := range a.accessTokens {
+		if t.Start().After(last) {
+			last = t.Start()
+		}
+	}
+	for _, t := range a.refreshTokens {
+		if t.Start().After(last) {
+			last = t.Start()
+		}
+	}
+	return last
+}
+
+// Active 유효한 동의나 토큰이 있는지 여부를 반환한다.
+func (a *ConnectedApp) Active() bool {
+	if a.consent != nil && a.consent.Available() {
+		return true
+	}
+	for _, t := range a.accessTokens {
+		if t.Available() {
+			return true
+		}
+	}
+	for _, t := range a.refreshTokens {
+		if t.Available() {
+			return true
+		}
+	}
+	return false
+}
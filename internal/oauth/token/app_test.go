@@ -0,0 +1,82 @@
+package token
+
+import (
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/authorization"
+	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/pkg/period"
+	"testing"
+	"time"
+)
+
+func TestGroupByClient(t *testing.T) {
+	now := time.Now()
+	first := newClient("first_client", client.TypeConfidential, testScopeArray)
+	second := newClient("second_client", client.TypeConfidential, testScopeArray)
+
+	newToken := func(c *client.Client, scopes []string, start time.Time) AccessToken {
+		t := NewWithRange(c, generateTestAccessToken, period.NewWithStartEnd(start, start.Add(time.Hour)))
+		t.ApplyResourceOwnerInfo(testUsername, scopes)
+		return *t
+	}
+
+	consents := []*authorization.Consent{
+		authorization.NewConsentWithState(testUsername, first, []string{"scope_1"}, period.NewWithStartEnd(now.Add(-48*time.Hour), now.Add(time.Hour))),
+	}
+	firstToken := newToken(first, []string{"scope_1", "scope_2"}, now.Add(-24*time.Hour))
+	secondToken := newToken(second, []string{"scope_3"}, now.Add(-time.Minute))
+	accessTokens := []AccessToken{firstToken, secondToken}
+	refreshTokens := []RefreshToken{
+		*NewRefreshTokenWithRange(&firstToken, generateTestRefreshToken, period.NewWithStartEnd(now.Add(-24*time.Hour), now.Add(time.Hour))),
+	}
+
+	apps := GroupByClient(consents, accessTokens, refreshTokens)
+
+	assert.Len(t, apps, 2)
+
+	t.Run("마지막 토큰 발급 시각의 내림차순으로 정렬", func(t *testing.T) {
+		assert.Equal(t, second.Id(), apps[0].Client().Id())
+		assert.Equal(t, first.Id(), apps[1].Client().Id())
+	})
+
+	t.Run("동의와 토큰을 클라이언트 별로 묶음", func(t *testing.T) {
+		app := apps[1]
+		assert.NotNil(t, app.Consent())
+		assert.Len(t, app.AccessTokens(), 1)
+		assert.Len(t, app.RefreshTokens(), 1)
+		assert.Equal(t, []string{"scope_1", "scope_2"}, app.Scopes())
+		assert.Equal(t, now.Add(-48*time.Hour), app.AuthorizedAt())
+		assert.Equal(t, now.Add(-24*time.Hour), app.LastIssuedAt())
+		assert.True(t, app.Active())
+	})
+
+	t.Run("동의가 없는 클라이언트", func(t *testing.T) {
+		app := apps[0]
+		assert.Nil(t, app.Consent())
+		assert.Empty(t, app.RefreshTokens())
+		assert.Equal(t, []string{"scope_3"}, app.Scopes())
+		assert.Equal(t, now.Add(-time.Minute), app.AuthorizedAt())
+	})
+}
+
+func TestConnectedApp_Active(t *testing.T) {
+	now := time.Now()
+	c := newClient(testClientID, client.TypeConfidential, testScopeArray)
+	expired := period.NewWithStartEnd(now.Add(-time.Hour), now.Add(-time.Minute))
+
+	t.Run("만료된 동의와 토큰만 있는 경우 비활성", func(t *testing.T) {
+		consent := authorization.NewConsentWithState(testUsername, c, testScopeArray, expired)
+		accessToken := NewWithRange(c, generateTestAccessToken, expired)
+
+		apps := GroupByClient([]*authorization.Consent{consent}, []AccessToken{*accessToken}, nil)
+		assert.False(t, apps[0].Active())
+	})
+
+	t.Run("동의만 유효한 경우 활성", func(t *testing.T) {
+		consent := authorization.NewConsentWithState(testUsername, c, testScopeArray, period.New(time.Hour))
+
+		apps := GroupByClient([]*authorization.Consent{consent}, nil, nil)
+		assert.True(t, apps[0].Active())
+		assert.True(t, apps[0].LastIssuedAt().IsZero())
+	})
+}
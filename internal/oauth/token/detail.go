@@ -0,0 +1,48 @@
+package token
+
+import (
+	"fmt"
+	"oauth-server-go/internal/oauth/detail"
+	oautherr "oauth-server-go/internal/oauth/errors"
+)
+
+// RestrictAuthorizationDetails [RFC 9396] 토큰 요청의 인가 상세 정보로 이미 부여된 인가 상세 정보를 축소한다.
+//
+// granted 는 인가 코드나 기존 토큰으로 이미 부여된 인가 상세 정보 목록으로, 요청된 인가 상세 정보는 모두 granted 에 포함되어야 하며
+// 요청된 인가 상세 정보가 없는 경우 granted 를 그대로 사용한다.
+//
+// [RFC 9396]: https://datatracker.ietf.org/doc/html/rfc9396#section-6.1
+func (t *AccessToken) RestrictAuthorizationDetails(requested string, granted detail.Details) error {
+	details, err := detail.Parse(requested)
+	if err != nil {
+		return err
+	}
+	if len(details) == 0 {
+		t.authorizationDetails = granted
+		return nil
+	}
+	if !granted.ContainsAll(details) {
+		return fmt.Errorf("%w: requested authorization_details is not granted", oautherr.ErrInvalidAuthorizationDetails)
+	}
+	t.authorizationDetails = details
+	return nil
+}
+
+// GrantAuthorizationDetails [RFC 9396] 토큰 요청의 인가 상세 정보를 검증하고 토큰에 부여한다.
+// 자원 소유자의 승인 없이 발급되는 토큰(클라이언트 자격 증명 방식 등)에서 사용된다.
+//
+// [RFC 9396]: https://datatracker.ietf.org/doc/html/rfc9396#section-6
+func (t *AccessToken) GrantAuthorizationDetails(retrieve detail.Retrieve, requested string) error {
+	details, err := detail.Parse(requested)
+	if err != nil || len(details) == 0 {
+		return err
+	}
+	if retrieve == nil {
+		return fmt.Errorf("%w: authorization_details is not supported", oautherr.ErrInvalidAuthorizationDetails)
+	}
+	if err = detail.Validate(retrieve, details); err != nil {
+		return err
+	}
+	t.authorizationDetails = details
+	return nil
+}
@@ -0,0 +1,106 @@
+package token
+
+import (
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/detail"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"testing"
+)
+
+// 인가 상세 정보 테스트에서 사용할 인가 상세 정보
+const (
+	testAccountDetail = `{"type":"account_information","actions":["read"]}`
+	testPaymentDetail = `{"type":"payment_initiation","amount":"45.00"}`
+)
+
+// retrieveTestDetailType 테스트용 인가 상세 정보 타입 조회 함수
+// [detail.Retrieve] 함수의 구현체로 사용된다.
+func retrieveTestDetailType(name string) (*detail.Type, bool) {
+	if name != "account_information" {
+		return nil, false
+	}
+	schema, _ := detail.ParseSchema(`{"type":"object","required":["actions"]}`)
+	return detail.NewType(name, "계좌 정보 조회", schema), true
+}
+
+func TestAccessToken_RestrictAuthorizationDetails(t *testing.T) {
+	granted, _ := detail.Parse("[" + testAccountDetail + "," + testPaymentDetail + "]")
+	newToken := func() *AccessToken {
+		return New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+	}
+
+	t.Run("요청된 인가 상세 정보로 축소", func(t *testing.T) {
+		accessToken := newToken()
+
+		err := accessToken.RestrictAuthorizationDetails("["+testPaymentDetail+"]", granted)
+
+		assert.NoError(t, err)
+		assert.Equal(t, detail.Details{granted[1]}, accessToken.AuthorizationDetails())
+	})
+
+	t.Run("요청된 인가 상세 정보가 없는 경우 부여된 인가 상세 정보 사용", func(t *testing.T) {
+		accessToken := newToken()
+
+		err := accessToken.RestrictAuthorizationDetails("", granted)
+
+		assert.NoError(t, err)
+		assert.Equal(t, granted, accessToken.AuthorizationDetails())
+	})
+
+	tests := []struct {
+		name      string
+		requested string
+		granted   detail.Details
+	}{
+		{name: "부여되지 않은 인가 상세 정보 요청", requested: `[{"type":"payment_initiation","amount":"100.00"}]`, granted: granted},
+		{name: "부여된 인가 상세 정보가 없음", requested: "[" + testAccountDetail + "]"},
+		{name: "잘못된 형식", requested: testAccountDetail, granted: granted},
+	}
+	for _, tc := range tests {
+		t.Run("ErrInvalidAuthorizationDetails 발생/"+tc.name, func(t *testing.T) {
+			err := newToken().RestrictAuthorizationDetails(tc.requested, tc.granted)
+			assert.ErrorIs(t, err, oautherr.ErrInvalidAuthorizationDetails)
+		})
+	}
+}
+
+func TestAccessToken_GrantAuthorizationDetails(t *testing.T) {
+	newToken := func() *AccessToken {
+		return New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+	}
+
+	t.Run("등록된 타입의 인가 상세 정보 부여", func(t *testing.T) {
+		accessToken := newToken()
+
+		err := accessToken.GrantAuthorizationDetails(retrieveTestDetailType, "["+testAccountDetail+"]")
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, "["+testAccountDetail+"]", accessToken.AuthorizationDetails().String())
+	})
+
+	t.Run("요청된 인가 상세 정보가 없는 경우 부여하지 않음", func(t *testing.T) {
+		accessToken := newToken()
+
+		err := accessToken.GrantAuthorizationDetails(nil, "")
+
+		assert.NoError(t, err)
+		assert.Nil(t, accessToken.AuthorizationDetails())
+	})
+
+	tests := []struct {
+		name      string
+		retrieve  detail.Retrieve
+		requested string
+	}{
+		{name: "등록되지 않은 타입", retrieve: retrieveTestDetailType, requested: "[" + testPaymentDetail + "]"},
+		{name: "스키마를 만족하지 않음", retrieve: retrieveTestDetailType, requested: `[{"type":"account_information"}]`},
+		{name: "인가 상세 정보를 지원하지 않음", requested: "[" + testAccountDetail + "]"},
+	}
+	for _, tc := range tests {
+		t.Run("ErrInvalidAuthorizationDetails 발생/"+tc.name, func(t *testing.T) {
+			err := newToken().GrantAuthorizationDetails(tc.retrieve, tc.requested)
+			assert.ErrorIs(t, err, oautherr.ErrInvalidAuthorizationDetails)
+		})
+	}
+}
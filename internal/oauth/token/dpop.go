@@ -0,0 +1,221 @@
+package token
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"net/url"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"strings"
+	"time"
+)
+
+const (
+	// DPoPProofType DPoP 증명 JWT의 JOSE 헤더 typ 값
+	DPoPProofType = "dpop+jwt"
+
+	// DPoPProofMaxAge DPoP 증명의 발급 시간(iat)으로 부터 증명을 허용하는 기간
+	// 증명 식별자(jti)의 재사용 여부는 이 기간 동안만 확인하면 된다.
+	DPoPProofMaxAge = time.Minute * 5
+
+	// dpopProofLeeway 클라이언트와 서버의 시간 오차로 미래의 발급 시간(iat)을 허용하는 범위
+	dpopProofLeeway = time.Second * 30
+)
+
+// dpopClaims DPoP 증명 JWT의 클레임
+type dpopClaims struct {
+	JTI      string `json:"jti"`
+	Method   string `json:"htm"`
+	URI      string `json:"htu"`
+	IssuedAt int64  `json:"iat"`
+	Nonce    string `json:"nonce,omitempty"`
+	ATH      string `json:"ath,omitempty"`
+}
+
+// DPoPProof [RFC 9449] 서명 검증이 완료된 DPoP 증명
+//
+// [RFC 9449]: https://datatracker.ietf.org/doc/html/rfc9449#section-4
+type DPoPProof struct {
+	// jkt 증명에 서명한 공개키의 JWK SHA-256 Thumbprint
+	jkt string
+
+	// jti 증명의 고유 식별자로 재전송 공격을 막는데 사용한다.
+	jti string
+
+	// nonce 서버가 발급한 nonce 서버가 nonce 를 요구하지 않는 경우 비어 있을 수 있다.
+	nonce string
+
+	// ath 자원 접근시 함께 전달하는 엑세스 토큰의 SHA-256 해시
+	ath string
+
+	issuedAt time.Time
+}
+
+// ParseDPoPProof DPoP 증명을 파싱하고 [RFC 9449] 에 정의된 규칙으로 검증한다.
+// 서버 nonce 와 증명 식별자(jti)의 재사용 여부는 검증하지 않으므로 호출자가 직접 검증해야 한다.
+//
+// Parameters:
+//   - raw: DPoP 요청 헤더의 값
+//   - method: 증명을 전달한 요청의 HTTP 메소드
+//   - uri: 증명을 전달한 요청의 URI 쿼리와 프래그먼트는 비교하지 않는다.
+//   - now: 현재 시간
+//
+// 검증에 실패한 경우 [oautherr.ErrInvalidDPoPProof] 를 반환한다.
+//
+// [RFC 9449]: https://datatracker.ietf.org/doc/html/rfc9449#section-4.3
+func ParseDPoPProof(raw, method, uri string, now time.Time) (*DPoPProof, error) {
+	jws, err := jose.ParseSigned(raw, client.SigningAlgorithms)
+	if err != nil || len(jws.Signatures) != 1 {
+		return nil, fmt.Errorf("%w: proof is malformed", oautherr.ErrInvalidDPoPProof)
+	}
+
+	header := jws.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != DPoPProofType {
+		return nil, fmt.Errorf("%w: typ must be %s", oautherr.ErrInvalidDPoPProof, DPoPProofType)
+	}
+	jwk := header.JSONWebKey
+	if jwk == nil || !jwk.IsPublic() {
+		return nil, fmt.Errorf("%w: jwk header must be public key", oautherr.ErrInvalidDPoPProof)
+	}
+
+	payload, err := jws.Verify(jwk)
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature could not be verified", oautherr.ErrInvalidDPoPProof)
+	}
+	var claims dpopClaims
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims are malformed", oautherr.ErrInvalidDPoPProof)
+	}
+
+	if claims.JTI == "" {
+		return nil, fmt.Errorf("%w: jti is required", oautherr.ErrInvalidDPoPProof)
+	}
+	if claims.Method != method {
+		return nil, fmt.Errorf("%w: htm(%s) is not matched", oautherr.ErrInvalidDPoPProof, claims.Method)
+	}
+	if !equalsHTU(claims.URI, uri) {
+		return nil, fmt.Errorf("%w: htu(%s) is not matched", oautherr.ErrInvalidDPoPProof, claims.URI)
+	}
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	if issuedAt.After(now.Add(dpopProofLeeway)) || issuedAt.Before(now.Add(-DPoPProofMaxAge)) {
+		return nil, fmt.Errorf("%w: iat is out of acceptable range", oautherr.ErrInvalidDPoPProof)
+	}
+
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("%w: jwk thumbprint could not be computed", oautherr.ErrInvalidDPoPProof)
+	}
+	return &DPoPProof{
+		jkt:      base64.RawURLEncoding.EncodeToString(thumbprint),
+		jti:      claims.JTI,
+		nonce:    claims.Nonce,
+		ath:      claims.ATH,
+		issuedAt: issuedAt,
+	}, nil
+}
+
+// equalsHTU 증명의 htu 와 요청 URI가 같은지 확인한다.
+// 스킴과 호스트는 대소문자를 구분하지 않으며 쿼리와 프래그먼트는 비교하지 않는다.
+func equalsHTU(htu, uri string) bool {
+	normalize := func(v string) (string, bool) {
+		u, err := url.Parse(v)
+		if err != nil || !u.IsAbs() {
+			return "", false
+		}
+		return strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host) + u.EscapedPath(), true
+	}
+	a, ok := normalize(htu)
+	if !ok {
+		return false
+	}
+	b, ok := normalize(uri)
+	return ok && a == b
+}
+
+func (p *DPoPProof) JKT() string {
+	return p.jkt
+}
+
+func (p *DPoPProof) JTI() string {
+	return p.jti
+}
+
+func (p *DPoPProof) Nonce() string {
+	return p.nonce
+}
+
+func (p *DPoPProof) IssuedAt() time.Time {
+	return p.issuedAt
+}
+
+// VerifyAccessToken 자원 접근시 전달된 증명의 ath 클레임이 엑세스 토큰의 해시와 같은지,
+// 엑세스 토큰이 증명에 서명한 공개키에 바인딩 되어 있는지 검증한다.
+func (p *DPoPProof) VerifyAccessToken(t *AccessToken) error {
+	hash := sha256.Sum256([]byte(t.Value()))
+	if p.ath != base64.RawURLEncoding.EncodeToString(hash[:]) {
+		return fmt.Errorf("%w: ath is not matched", oautherr.ErrInvalidDPoPProof)
+	}
+	if cnf := t.Confirmation(); cnf == nil || cnf.JKT != p.jkt {
+		return fmt.Errorf("%w: access token is not bound to proof key", oautherr.ErrInvalidToken)
+	}
+	return nil
+}
+
+// BindDPoPKey 발급된 토큰을 DPoP 증명의 공개키에 바인딩한다.
+// 기밀 클라이언트의 리플레시 토큰은 클라이언트 인증으로 이미 발신자가 제한되므로 [RFC 9449] 에 따라 공개 클라이언트의 리플레시 토큰만 바인딩한다.
+//
+// [RFC 9449]: https://datatracker.ietf.org/doc/html/rfc9449#section-5
+func BindDPoPKey(jkt string, accessToken *AccessToken, refreshToken *RefreshToken) {
+	accessToken.confirm().JKT = jkt
+	if refreshToken != nil && accessToken.Client().T() == client.TypePublic {
+		refreshToken.confirm().JKT = jkt
+	}
+}
+
+// DPoPNonce [RFC 9449] DPoP 증명에 포함될 서버 nonce 를 발급하고 검증한다.
+//
+// nonce 는 발급 시간과 발급 시간의 HMAC 으로 만들어 저장소 없이 검증 할 수 있으며 유효기간이 지난 nonce 는 거부한다.
+//
+// [RFC 9449]: https://datatracker.ietf.org/doc/html/rfc9449#section-8
+type DPoPNonce struct {
+	secret   []byte
+	lifetime time.Duration
+}
+
+func NewDPoPNonce(secret []byte, lifetime time.Duration) *DPoPNonce {
+	return &DPoPNonce{secret: secret, lifetime: lifetime}
+}
+
+// Issue 새 서버 nonce 를 발급한다.
+func (n *DPoPNonce) Issue(now time.Time) string {
+	ts := make([]byte, 8)
+	binary.BigEndian.PutUint64(ts, uint64(now.Unix()))
+	return base64.RawURLEncoding.EncodeToString(append(ts, n.sign(ts)...))
+}
+
+// Validate 서버가 발급한 nonce 인지, 유효기간이 지나지 않았는지 확인한다.
+func (n *DPoPNonce) Validate(nonce string, now time.Time) bool {
+	decoded, err := base64.RawURLEncoding.DecodeString(nonce)
+	if err != nil || len(decoded) <= 8 {
+		return false
+	}
+
+	ts, mac := decoded[:8], decoded[8:]
+	if !hmac.Equal(mac, n.sign(ts)) {
+		return false
+	}
+	issuedAt := time.Unix(int64(binary.BigEndian.Uint64(ts)), 0)
+	return !issuedAt.After(now.Add(dpopProofLeeway)) && now.Sub(issuedAt) <= n.lifetime
+}
+
+func (n *DPoPNonce) sign(ts []byte) []byte {
+	mac := hmac.New(sha256.New, n.secret)
+	mac.Write(ts)
+	return mac.Sum(nil)[:16]
+}
@@ -0,0 +1,177 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"testing"
+	"time"
+)
+
+// testDPoPURI 테스트용으로 사용할 DPoP 증명을 전달하는 요청 URI
+const testDPoPURI = "https://auth.example.com/oauth/auth/token"
+
+// signTestDPoPProof 주어진 키와 JOSE 헤더 typ 값으로 테스트용 DPoP 증명을 서명한다.
+func signTestDPoPProof(t *testing.T, private *ecdsa.PrivateKey, typ string, claims dpopClaims) string {
+	opts := (&jose.SignerOptions{EmbedJWK: true}).WithType(jose.ContentType(typ))
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: private}, opts)
+	assert.NoError(t, err)
+
+	raw, err := jwt.Signed(signer).Claims(claims).Serialize()
+	assert.NoError(t, err)
+	return raw
+}
+
+// testJKT 주어진 키의 JWK SHA-256 Thumbprint 를 반환한다.
+func testJKT(private *ecdsa.PrivateKey) string {
+	thumbprint, _ := (&jose.JSONWebKey{Key: private.Public()}).Thumbprint(crypto.SHA256)
+	return base64.RawURLEncoding.EncodeToString(thumbprint)
+}
+
+func TestParseDPoPProof(t *testing.T) {
+	private, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	now := time.Now()
+	validClaims := func() dpopClaims {
+		return dpopClaims{
+			JTI:      "test_jti",
+			Method:   "POST",
+			URI:      testDPoPURI,
+			IssuedAt: now.Unix(),
+			Nonce:    "test_nonce",
+		}
+	}
+
+	t.Run("검증된 증명의 공개키 Thumbprint 와 클레임 반환", func(t *testing.T) {
+		raw := signTestDPoPProof(t, private, DPoPProofType, validClaims())
+
+		proof, err := ParseDPoPProof(raw, "POST", testDPoPURI, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, testJKT(private), proof.JKT())
+		assert.Equal(t, "test_jti", proof.JTI())
+		assert.Equal(t, "test_nonce", proof.Nonce())
+		assert.Equal(t, now.Unix(), proof.IssuedAt().Unix())
+	})
+
+	t.Run("htu 의 스킴과 호스트는 대소문자를 구분하지 않고 쿼리는 비교하지 않음", func(t *testing.T) {
+		claims := validClaims()
+		claims.URI = "HTTPS://Auth.Example.com/oauth/auth/token"
+
+		_, err := ParseDPoPProof(signTestDPoPProof(t, private, DPoPProofType, claims), "POST", testDPoPURI+"?q=1", now)
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name string
+		typ  string
+		edit func(c *dpopClaims)
+	}{
+		{name: "typ 이 dpop+jwt 가 아님", typ: "JWT"},
+		{name: "jti 가 없음", edit: func(c *dpopClaims) { c.JTI = "" }},
+		{name: "htm 이 요청 메소드와 다름", edit: func(c *dpopClaims) { c.Method = "GET" }},
+		{name: "htu 가 요청 URI와 다름", edit: func(c *dpopClaims) { c.URI = "https://auth.example.com/oauth/userinfo" }},
+		{name: "허용 기간이 지난 iat", edit: func(c *dpopClaims) { c.IssuedAt = now.Add(-DPoPProofMaxAge - time.Minute).Unix() }},
+		{name: "미래의 iat", edit: func(c *dpopClaims) { c.IssuedAt = now.Add(time.Hour).Unix() }},
+	}
+	for _, tc := range tests {
+		t.Run("검증 실패시 ErrInvalidDPoPProof/"+tc.name, func(t *testing.T) {
+			typ, claims := DPoPProofType, validClaims()
+			if tc.typ != "" {
+				typ = tc.typ
+			}
+			if tc.edit != nil {
+				tc.edit(&claims)
+			}
+
+			_, err := ParseDPoPProof(signTestDPoPProof(t, private, typ, claims), "POST", testDPoPURI, now)
+			assert.ErrorIs(t, err, oautherr.ErrInvalidDPoPProof)
+		})
+	}
+}
+
+func TestDPoPProof_VerifyAccessToken(t *testing.T) {
+	accessToken := New(newClient(testClientID, client.TypePublic, testScopeArray), generateTestAccessToken)
+	accessToken.SetConfirmation(&Confirmation{JKT: "test_jkt"})
+	hash := sha256.Sum256([]byte(testAccessTokenValue))
+	ath := base64.RawURLEncoding.EncodeToString(hash[:])
+
+	t.Run("ath 와 공개키가 일치함", func(t *testing.T) {
+		proof := &DPoPProof{jkt: "test_jkt", ath: ath}
+		assert.NoError(t, proof.VerifyAccessToken(accessToken))
+	})
+
+	t.Run("ath 불일치시 ErrInvalidDPoPProof 발생", func(t *testing.T) {
+		proof := &DPoPProof{jkt: "test_jkt", ath: "wrong_ath"}
+		assert.ErrorIs(t, proof.VerifyAccessToken(accessToken), oautherr.ErrInvalidDPoPProof)
+	})
+
+	t.Run("다른 공개키에 바인딩된 토큰은 ErrInvalidToken 발생", func(t *testing.T) {
+		proof := &DPoPProof{jkt: "other_jkt", ath: ath}
+		assert.ErrorIs(t, proof.VerifyAccessToken(accessToken), oautherr.ErrInvalidToken)
+	})
+}
+
+func TestBindDPoPKey(t *testing.T) {
+	t.Run("공개 클라이언트는 엑세스 토큰과 리플레시 토큰 모두 바인딩", func(t *testing.T) {
+		accessToken := New(newClient(testClientID, client.TypePublic, testScopeArray), generateTestAccessToken)
+		refreshToken := NewRefreshToken(accessToken, generateTestRefreshToken)
+
+		BindDPoPKey("test_jkt", accessToken, refreshToken)
+
+		assert.Equal(t, TypeDPoP, accessToken.Type())
+		assert.Equal(t, "test_jkt", accessToken.Confirmation().JKT)
+		assert.Equal(t, "test_jkt", refreshToken.Confirmation().JKT)
+	})
+
+	t.Run("기밀 클라이언트는 엑세스 토큰만 바인딩", func(t *testing.T) {
+		accessToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+		refreshToken := NewRefreshToken(accessToken, generateTestRefreshToken)
+
+		BindDPoPKey("test_jkt", accessToken, refreshToken)
+
+		assert.Equal(t, TypeDPoP, accessToken.Type())
+		assert.Nil(t, refreshToken.Confirmation())
+	})
+
+	t.Run("인증서 바인딩과 함께 사용시 기존 바인딩을 유지", func(t *testing.T) {
+		accessToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+
+		BindCertificate("test_x5t", accessToken, nil)
+		BindDPoPKey("test_jkt", accessToken, nil)
+
+		assert.Equal(t, &Confirmation{JKT: "test_jkt", X5TS256: "test_x5t"}, accessToken.Confirmation())
+	})
+}
+
+func TestDPoPNonce(t *testing.T) {
+	now := time.Now()
+	nonce := NewDPoPNonce([]byte("test_secret"), time.Minute)
+
+	t.Run("발급한 nonce 는 유효기간 동안 유효함", func(t *testing.T) {
+		issued := nonce.Issue(now)
+		assert.True(t, nonce.Validate(issued, now.Add(time.Second*30)))
+	})
+
+	t.Run("유효기간이 지난 nonce 는 유효하지 않음", func(t *testing.T) {
+		issued := nonce.Issue(now)
+		assert.False(t, nonce.Validate(issued, now.Add(time.Minute*2)))
+	})
+
+	t.Run("다른 비밀키로 발급한 nonce 는 유효하지 않음", func(t *testing.T) {
+		issued := NewDPoPNonce([]byte("other_secret"), time.Minute).Issue(now)
+		assert.False(t, nonce.Validate(issued, now))
+	})
+
+	t.Run("형식이 잘못된 nonce 는 유효하지 않음", func(t *testing.T) {
+		assert.False(t, nonce.Validate("", now))
+		assert.False(t, nonce.Validate("not nonce", now))
+	})
+}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"oauth-server-go/internal/oauth/authorization"
 	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/detail"
 	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/resource"
 	"oauth-server-go/internal/oauth/scope"
 	"oauth-server-go/internal/pkg/auth"
 	"oauth-server-go/pkg/array"
+	"slices"
 )
 
 // RetrieveAuthorizationCode 인가 코드를 조회하여 반환한다.
@@ -29,9 +32,16 @@ type AuthorizationCodeGranter struct {
 
 	// RetrieveAuthorizationCode 인가 코드를 조회 하는 함수
 	RetrieveAuthorizationCode RetrieveAuthorizationCode
+
+	// Issuer ID 토큰 발행자(iss) 식별자
+	Issuer string
+
+	// RetrieveResourceServer 자원 지시자로 등록된 자원 서버를 조회하는 함수. nil 인 경우 자원 지시자를 지원하지 않는다.
+	RetrieveResourceServer resource.Retrieve
 }
 
 // GenerateToken 인가 코드를 검증하고 엑세스 토큰과 리플레시 토큰을 발급한다.
+// 인가 코드에 openid 스코프가 부여되어 있을 경우 ID 토큰을 생성하여 엑세스 토큰에 설정한다.
 func (srv *AuthorizationCodeGranter) GenerateToken(c *client.Client, request *Request) (*AccessToken, *RefreshToken, error) {
 	if request.Code == "" {
 		return nil, nil, fmt.Errorf("%w: code", oautherr.ErrMissingParameter)
@@ -60,14 +70,38 @@ func (srv *AuthorizationCodeGranter) GenerateToken(c *client.Client, request *Re
 
 	token := New(c, srv.AccessTokenGenerator)
 	token.ApplyAuthorizationCode(authCode)
+	// 인가 요청에서 부여된 자원 지시자 이내로 토큰의 대상을 제한한다.
+	if err := token.RestrictResources(srv.RetrieveResourceServer, request.Resource, authCode.Resources()); err != nil {
+		return nil, nil, err
+	}
+	// 인가 요청에서 자원 소유자가 승인한 인가 상세 정보 이내로 토큰의 인가 상세 정보를 제한한다.
+	if err := token.RestrictAuthorizationDetails(request.AuthorizationDetails, authCode.AuthorizationDetails()); err != nil {
+		return nil, nil, err
+	}
+	if slices.Contains(authCode.Scopes(), scope.OpenID) {
+		token.SetIDToken(NewIDToken(srv.Issuer, authCode, token))
+	}
 
 	if c.T() == client.TypeConfidential {
-		return token, NewRefreshToken(token, srv.RefreshTokenGenerator), nil
+		return token, newGrantedRefreshToken(token, srv.RefreshTokenGenerator, authCode.Scopes(), authCode.Resources(), authCode.AuthorizationDetails()), nil
 	} else {
 		return token, nil, nil
 	}
 }
 
+// newGrantedRefreshToken 엑세스 토큰의 리플레시 토큰을 생성하고 토큰 요청으로 축소되기 전에 부여된 스코프와 자원 지시자, 인가 상세 정보를 설정한다.
+// 리플레시 토큰으로 재발급하는 엑세스 토큰은 이 범위 이내로 제한된다. ([RFC 8707], [RFC 9396])
+//
+// [RFC 8707]: https://datatracker.ietf.org/doc/html/rfc8707#section-2.2
+// [RFC 9396]: https://datatracker.ietf.org/doc/html/rfc9396#section-6.2
+func newGrantedRefreshToken(token *AccessToken, g GenerateToken, scopes, resources []string, details detail.Details) *RefreshToken {
+	refreshToken := NewRefreshToken(token, g)
+	refreshToken.SetScopes(scopes)
+	refreshToken.SetResources(resources)
+	refreshToken.SetAuthorizationDetails(details)
+	return refreshToken
+}
+
 // ImplicitGranter OAuth2 암묵적 승인 방식 구현체
 type ImplicitGranter struct {
 	// accessTokenGenerator 텍스트 형태의 랜덤 문자열로 토큰을 생성하는 함수
@@ -110,6 +144,9 @@ type ResourceOwnerPasswordCredentialsGranter struct {
 	// RefreshTokenGenerator 텍스트 형태의 랜덤 문자열 토큰을 생성하는 함수
 	// 리플레시 토큰의 실제 토큰값을 생성하는데 사용한다.
 	RefreshTokenGenerator GenerateToken
+
+	// RetrieveResourceServer 자원 지시자로 등록된 자원 서버를 조회하는 함수. nil 인 경우 자원 지시자를 지원하지 않는다.
+	RetrieveResourceServer resource.Retrieve
 }
 
 // GenerateToken 자원 소유자의 식별자(아이디)와 패스워드를 가지고 인증을 진행하고 인증 성공시 새로운 엑세스 토큰을 발급한다.
@@ -134,9 +171,12 @@ func (srv *ResourceOwnerPasswordCredentialsGranter) GenerateToken(c *client.Clie
 
 	token := New(c, srv.AccessTokenGenerator)
 	token.ApplyResourceOwnerInfo(request.Username, scopes)
+	if err := token.RestrictResources(srv.RetrieveResourceServer, request.Resource, nil); err != nil {
+		return nil, nil, err
+	}
 
 	if c.T() == client.TypeConfidential {
-		return token, NewRefreshToken(token, srv.RefreshTokenGenerator), nil
+		return token, newGrantedRefreshToken(token, srv.RefreshTokenGenerator, scopes, request.Resource, token.AuthorizationDetails()), nil
 	} else {
 		return token, nil, nil
 	}
@@ -147,6 +187,12 @@ type ClientCredentialsGranter struct {
 	// AccessTokenGenerator 텍스트 형태의 랜덤 문자열로 토큰을 생성하는 함수
 	// 엑세스 토큰의 실제 토큰값을 생성하는데 사용한다.
 	AccessTokenGenerator GenerateToken
+
+	// RetrieveResourceServer 자원 지시자로 등록된 자원 서버를 조회하는 함수. nil 인 경우 자원 지시자를 지원하지 않는다.
+	RetrieveResourceServer resource.Retrieve
+
+	// RetrieveAuthorizationDetailType 인가 상세 정보 타입을 조회하는 함수. nil 인 경우 인가 상세 정보를 지원하지 않는다.
+	RetrieveAuthorizationDetailType detail.Retrieve
 }
 
 // GenerateToken 클라이언트 자격 증명을 이용하여 새 엑세스 토큰을 발급한다.
@@ -164,6 +210,12 @@ func (srv *ClientCredentialsGranter) GenerateToken(c *client.Client, request *Re
 	token := New(c, srv.AccessTokenGenerator)
 	// 자원 소유자가 없음으로 공백("")을 저장한다.
 	token.ApplyResourceOwnerInfo("", scopes)
+	if err := token.RestrictResources(srv.RetrieveResourceServer, request.Resource, nil); err != nil {
+		return nil, err
+	}
+	if err := token.GrantAuthorizationDetails(srv.RetrieveAuthorizationDetailType, request.AuthorizationDetails); err != nil {
+		return nil, err
+	}
 
 	return token, nil
 }
@@ -190,6 +242,9 @@ type RefreshTokenGranter struct {
 
 	// Rotation 신규 토큰 발행 후 기존의 리플래시 토큰을 재사용할지 여부
 	Rotation bool
+
+	// RetrieveResourceServer 자원 지시자로 등록된 자원 서버를 조회하는 함수. nil 인 경우 자원 지시자를 지원하지 않는다.
+	RetrieveResourceServer resource.Retrieve
 }
 
 // GenerateToken 리플레시 토큰을 이용하여 새 엑세스 토큰과 리플레시 토큰을 생성한다.
@@ -212,26 +267,332 @@ func (srv *RefreshTokenGranter) GenerateToken(c *client.Client, request *Request
 		return nil, nil, fmt.Errorf("%w: refresh token is expired", oautherr.ErrExpiredResource)
 	}
 
-	// 따로 요청된 스코프가 없을 경우 기존 토큰의 스코프를 그대로 사용
+	// DPoP 공개키나 클라이언트 인증서에 바인딩된 리플레시 토큰은 같은 공개키의 증명이나 같은 인증서와 함께 사용해야 한다.
+	if err := verifyConfirmation(storedRefreshToken.Confirmation(), request); err != nil {
+		return nil, nil, err
+	}
+
+	// 따로 요청된 스코프가 없을 경우 리플레시 토큰에 부여된 스코프를 그대로 사용
 	scopes := scope.Split(request.Scope)
 	if len(scopes) == 0 {
-		scopes = expiredToken.Scopes()
+		scopes = storedRefreshToken.Scopes()
 	}
 
-	// 부여하려는 스코프 중 기존 토큰에 없는 스코프가 있을 경우 에러
-	if !array.ContainsAll(expiredToken.Scopes(), scopes) {
+	// 부여하려는 스코프 중 리플레시 토큰에 부여되지 않은 스코프가 있을 경우 에러
+	if !array.ContainsAll(storedRefreshToken.Scopes(), scopes) {
 		return nil, nil, oautherr.ErrInvalidScope
 	}
 
 	token := New(c, srv.AccessTokenGenerator)
 	token.ApplyResourceOwnerInfo(expiredToken.Username(), scopes)
+	// 재발급 받은 토큰도 자원 소유자가 처음 인증했을 때의 인증 정보를 유지한다.
+	token.ApplyAuthentication(expiredToken.AuthTime(), expiredToken.ACR(), expiredToken.AMR())
+	// 기존 엑세스 토큰은 축소된 대상일 수 있으므로 리플레시 토큰에 부여된 자원 지시자 이내로 토큰의 대상을 제한하며,
+	// 생략된 경우 부여된 자원 지시자를 그대로 사용한다.
+	if err := token.RestrictResources(srv.RetrieveResourceServer, request.Resource, storedRefreshToken.Resources()); err != nil {
+		return nil, nil, err
+	}
+	// 인가 상세 정보도 기존 엑세스 토큰이 아닌 리플레시 토큰에 부여된 인가 상세 정보 이내로 제한한다.
+	if err := token.RestrictAuthorizationDetails(request.AuthorizationDetails, storedRefreshToken.AuthorizationDetails()); err != nil {
+		return nil, nil, err
+	}
 
 	var refreshToken *RefreshToken
 	if srv.RefreshTokenGenerator != nil && srv.Rotation {
-		refreshToken = NewRefreshToken(token, srv.RefreshTokenGenerator)
+		refreshToken = newGrantedRefreshToken(token, srv.RefreshTokenGenerator, storedRefreshToken.Scopes(), storedRefreshToken.Resources(), storedRefreshToken.AuthorizationDetails())
 	} else {
 		storedRefreshToken.token = token
 		refreshToken = storedRefreshToken
 	}
 	return token, refreshToken, nil
 }
+
+// verifyConfirmation 토큰이 바인딩된 DPoP 공개키나 클라이언트 인증서가 요청과 일치하는지 확인한다. 바인딩 되지 않은 토큰(cnf 가 nil)은 확인하지 않는다.
+func verifyConfirmation(cnf *Confirmation, request *Request) error {
+	if cnf == nil {
+		return nil
+	}
+	if cnf.JKT != "" && cnf.JKT != request.DPoPJKT {
+		return fmt.Errorf("%w: token is bound to another dpop key", oautherr.ErrInvalidDPoPProof)
+	}
+	if cnf.X5TS256 != "" && cnf.X5TS256 != request.CertificateThumbprint {
+		return fmt.Errorf("%w: token is bound to another client certificate", oautherr.ErrUnauthorized)
+	}
+	return nil
+}
+
+// PollDeviceCode 디바이스 코드로 토큰 엔드포인트 폴링을 처리하는 함수
+// 자원 소유자가 승인한 경우에만 디바이스 코드를 반환하며 그 외에는 [RFC 8628] 에 정의된 에러를 반환한다.
+//
+// [RFC 8628]: https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+type PollDeviceCode func(c *client.Client, deviceCode string) (*authorization.DeviceCode, error)
+
+// DeviceCodeGranter 디바이스 인가 승인 방식
+type DeviceCodeGranter struct {
+	// AccessTokenGenerator 텍스트 형태의 랜덤 문자열로 토큰을 생성하는 함수
+	// 엑세스 토큰의 실제 토큰값을 생성하는데 사용한다.
+	AccessTokenGenerator GenerateToken
+
+	// RefreshTokenGenerator 텍스트 형태의 랜덤 문자열 토큰을 생성하는 함수
+	// 리플레시 토큰의 실제 토큰값을 생성하는데 사용한다.
+	RefreshTokenGenerator GenerateToken
+
+	// PollDeviceCode 디바이스 코드 폴링을 처리하는 함수
+	PollDeviceCode PollDeviceCode
+
+	// RetrieveResourceServer 자원 지시자로 등록된 자원 서버를 조회하는 함수. nil 인 경우 자원 지시자를 지원하지 않는다.
+	RetrieveResourceServer resource.Retrieve
+}
+
+// GenerateToken 자원 소유자가 승인한 디바이스 코드로 새 엑세스 토큰과 리플레시 토큰을 발급한다.
+func (srv *DeviceCodeGranter) GenerateToken(c *client.Client, request *Request) (*AccessToken, *RefreshToken, error) {
+	if request.DeviceCode == "" {
+		return nil, nil, fmt.Errorf("%w: device_code", oautherr.ErrMissingParameter)
+	}
+
+	deviceCode, err := srv.PollDeviceCode(c, request.DeviceCode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token := New(c, srv.AccessTokenGenerator)
+	token.ApplyResourceOwnerInfo(deviceCode.Username(), deviceCode.Scopes())
+	token.ApplyAuthentication(deviceCode.AuthTime(), deviceCode.ACR(), deviceCode.AMR())
+	if err := token.RestrictResources(srv.RetrieveResourceServer, request.Resource, nil); err != nil {
+		return nil, nil, err
+	}
+
+	if c.T() == client.TypeConfidential {
+		return token, newGrantedRefreshToken(token, srv.RefreshTokenGenerator, deviceCode.Scopes(), request.Resource, token.AuthorizationDetails()), nil
+	} else {
+		return token, nil, nil
+	}
+}
+
+// PollBackchannelAuthentication 인증 요청 식별자로 토큰 엔드포인트 폴링을 처리하는 함수
+// 자원 소유자가 승인한 경우에만 백채널 인증 요청을 반환하며 그 외에는 [OpenID CIBA] 에 정의된 에러를 반환한다.
+//
+// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.11
+type PollBackchannelAuthentication func(c *client.Client, authReqID string) (*authorization.BackchannelAuthentication, error)
+
+// BackchannelGranter [OpenID CIBA] 백채널 인증 승인 방식
+//
+// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.10.1
+type BackchannelGranter struct {
+	// AccessTokenGenerator 텍스트 형태의 랜덤 문자열로 토큰을 생성하는 함수
+	// 엑세스 토큰의 실제 토큰값을 생성하는데 사용한다.
+	AccessTokenGenerator GenerateToken
+
+	// RefreshTokenGenerator 텍스트 형태의 랜덤 문자열 토큰을 생성하는 함수
+	// 리플레시 토큰의 실제 토큰값을 생성하는데 사용한다.
+	RefreshTokenGenerator GenerateToken
+
+	// PollBackchannelAuthentication 백채널 인증 요청 폴링을 처리하는 함수
+	PollBackchannelAuthentication PollBackchannelAuthentication
+
+	// Issuer ID 토큰 발행자(iss) 식별자
+	Issuer string
+
+	// RetrieveResourceServer 자원 지시자로 등록된 자원 서버를 조회하는 함수. nil 인 경우 자원 지시자를 지원하지 않는다.
+	RetrieveResourceServer resource.Retrieve
+}
+
+// GenerateToken 자원 소유자가 승인한 백채널 인증 요청으로 새 엑세스 토큰과 리플레시 토큰, ID 토큰을 발급한다.
+func (srv *BackchannelGranter) GenerateToken(c *client.Client, request *Request) (*AccessToken, *RefreshToken, error) {
+	// 비공개 클라이언트만 백채널 인증 요청으로 토큰 발급 가능
+	if c.T() != client.TypeConfidential {
+		return nil, nil, fmt.Errorf("%w: public client", oautherr.ErrInvalidClient)
+	}
+
+	if request.AuthReqID == "" {
+		return nil, nil, fmt.Errorf("%w: auth_req_id", oautherr.ErrMissingParameter)
+	}
+
+	authentication, err := srv.PollBackchannelAuthentication(c, request.AuthReqID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token := New(c, srv.AccessTokenGenerator)
+	token.ApplyResourceOwnerInfo(authentication.Username(), authentication.Scopes())
+	token.ApplyAuthentication(authentication.AuthTime(), authentication.ACR(), authentication.AMR())
+	if err := token.RestrictResources(srv.RetrieveResourceServer, request.Resource, nil); err != nil {
+		return nil, nil, err
+	}
+	token.SetIDToken(NewBackchannelIDToken(srv.Issuer, authentication, token))
+
+	return token, newGrantedRefreshToken(token, srv.RefreshTokenGenerator, authentication.Scopes(), request.Resource, token.AuthorizationDetails()), nil
+}
+
+// RetrieveAccessToken 엑세스 토큰을 조회하는 함수
+//
+// Returns:
+//   - *AccessToken: 조회된 엑세스 토큰
+//   - bool: 조회 성공 여부
+type RetrieveAccessToken func(accessToken string) (*AccessToken, bool)
+
+// TokenExchangeGranter [RFC 8693] 토큰 교환 방식
+//
+// 자원 소유자의 토큰(subject_token)을 다른 대상 서비스에서 사용할 토큰으로 교환한다.
+// 행동 주체의 토큰(actor_token)이 함께 입력된 경우 위임(delegation)으로 처리하여 새 토큰에 행동 주체(act)를 기록하며,
+// 입력되지 않은 경우 가장(impersonation)으로 처리한다.
+//
+// 교환할 토큰과 행동 주체의 토큰은 교환을 요청한 클라이언트에게 발급되었거나 대상(aud)에 클라이언트 아이디가 포함된 엑세스 토큰이어야 하며,
+// 리플레시 토큰은 교환을 요청한 클라이언트에게 발급된 경우에만 사용 할 수 있다.
+// DPoP 공개키나 클라이언트 인증서에 바인딩된 토큰은 같은 공개키의 증명이나 같은 인증서와 함께 요청해야 한다.
+//
+// [RFC 8693]: https://datatracker.ietf.org/doc/html/rfc8693
+type TokenExchangeGranter struct {
+	// AccessTokenGenerator 텍스트 형태의 랜덤 문자열로 토큰을 생성하는 함수
+	// 엑세스 토큰의 실제 토큰값을 생성하는데 사용한다.
+	AccessTokenGenerator GenerateToken
+
+	// RetrieveAccessToken 엑세스 토큰을 조회하는 함수
+	RetrieveAccessToken RetrieveAccessToken
+
+	// RetrieveRefreshToken 리플레시 토큰을 조회하는 함수
+	RetrieveRefreshToken RetrieveRefreshToken
+
+	// RetrieveResourceServer 자원 지시자로 등록된 자원 서버를 조회하는 함수. nil 인 경우 자원 지시자를 지원하지 않는다.
+	RetrieveResourceServer resource.Retrieve
+
+	// RetrieveClientResources 교환을 요청한 클라이언트가 사용하는 자원 서버를 조회하는 함수
+	// nil 인 경우 교환을 요청한 클라이언트에게 발급된 토큰만 교환 할 수 있다.
+	RetrieveClientResources resource.RetrieveByClient
+}
+
+// GenerateToken 교환할 토큰을 검증하고 새 엑세스 토큰을 발급한다. 리플레시 토큰은 항상 nil을 반환한다.
+//
+// 새 토큰의 스코프는 교환할 토큰의 스코프 이내여야 하며, 생략된 경우 교환할 토큰의 스코프를 그대로 사용한다.
+// 자원 소유자의 인증 시간(auth_time)과 인증 수준(acr, amr)은 교환할 토큰의 값을 그대로 사용한다.
+// audience 와 resource 는 모두 등록된 자원 서버의 자원 지시자여야 하며, 새 토큰의 스코프는 자원 서버들이 소유한 스코프로 제한된다.
+func (srv *TokenExchangeGranter) GenerateToken(c *client.Client, request *Request) (*AccessToken, *RefreshToken, error) {
+	// 비공개 클라이언트만 토큰 교환 가능
+	if c.T() != client.TypeConfidential {
+		return nil, nil, fmt.Errorf("%w: public client", oautherr.ErrInvalidClient)
+	}
+
+	if request.SubjectToken == "" || request.SubjectTokenType == "" {
+		return nil, nil, fmt.Errorf("%w: subject_token and subject_token_type are required", oautherr.ErrMissingParameter)
+	}
+
+	if _, err := IssuedTokenType(c, request); err != nil {
+		return nil, nil, err
+	}
+
+	subject, err := srv.retrieve(c, request, request.SubjectToken, request.SubjectTokenType)
+	if err != nil {
+		return nil, nil, fmt.Errorf("subject_token: %w", err)
+	}
+
+	// 자원 소유자를 대신하여 행동하는 주체가 없으면 기존 위임 관계를 그대로 유지한다.
+	actor := subject.Actor()
+	if request.ActorToken != "" {
+		if request.ActorTokenType == "" {
+			return nil, nil, fmt.Errorf("%w: actor_token_type", oautherr.ErrMissingParameter)
+		}
+		actorToken, err := srv.retrieve(c, request, request.ActorToken, request.ActorTokenType)
+		if err != nil {
+			return nil, nil, fmt.Errorf("actor_token: %w", err)
+		}
+		actor = &Actor{
+			Subject:  actorToken.Subject(),
+			ClientID: actorToken.Client().Id(),
+			Actor:    subject.Actor(),
+		}
+	}
+
+	// audience 파라미터와 자원 지시자를 합쳐 등록된 자원 서버로 검증한 후 새 토큰의 대상으로 사용한다.
+	targets := slices.Clone(request.Audience)
+	for _, r := range request.Resource {
+		if !slices.Contains(targets, r) {
+			targets = append(targets, r)
+		}
+	}
+	for _, r := range targets {
+		if err := resource.ValidateIndicator(r); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	scopes := scope.Split(request.Scope)
+	if len(scopes) == 0 {
+		scopes = subject.Scopes()
+	}
+	if !array.ContainsAll(subject.Scopes(), scopes) {
+		return nil, nil, oautherr.ErrInvalidScope
+	}
+
+	token := New(c, srv.AccessTokenGenerator)
+	token.ApplyResourceOwnerInfo(subject.Username(), scopes)
+	// 자원 소유자가 인증한 시간과 인증 수준은 교환된 토큰에서도 유지되어야 자원 서버가 단계별 인증 여부를 판단 할 수 있다.
+	token.ApplyAuthentication(subject.AuthTime(), subject.ACR(), subject.AMR())
+	if err := token.RestrictResources(srv.RetrieveResourceServer, targets, nil); err != nil {
+		return nil, nil, err
+	}
+	token.SetActor(actor)
+
+	return token, nil, nil
+}
+
+// retrieve 토큰 타입 식별자에 맞게 토큰을 조회한다. 리플레시 토큰인 경우 리플레시 토큰과 연결된 엑세스 토큰을 반환한다.
+//
+// 엑세스 토큰은 교환을 요청한 클라이언트에게 발급되었거나 대상(aud)에 클라이언트가 사용하는 자원 서버가 포함되어야 하며,
+// 리플레시 토큰은 교환을 요청한 클라이언트에게 발급된 경우에만 사용 할 수 있다.
+// 사용 할 수 없는 토큰이거나 토큰이 바인딩된 공개키나 인증서가 요청과 일치하지 않는 경우 에러를 반환한다.
+func (srv *TokenExchangeGranter) retrieve(c *client.Client, request *Request, value string, t TokenTypeIdentifier) (*AccessToken, error) {
+	var accessToken *AccessToken
+	var cnf *Confirmation
+	switch t {
+	case TokenTypeAccessToken, TokenTypeJWT:
+		if found, ok := srv.RetrieveAccessToken(value); ok && found.Available() {
+			if found.Client().Id() != c.Id() && !srv.intendedFor(found, c) {
+				return nil, fmt.Errorf("%w: token was not issued to the client", oautherr.ErrUnauthorized)
+			}
+			accessToken, cnf = found, found.Confirmation()
+		}
+	case TokenTypeRefreshToken:
+		if found, ok := srv.RetrieveRefreshToken(value); ok && found.Available() {
+			if found.Token().Client().Id() != c.Id() {
+				return nil, fmt.Errorf("%w: refresh token was not issued to the client", oautherr.ErrUnauthorized)
+			}
+			accessToken, cnf = found.Token(), found.Confirmation()
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported token type(%s)", oautherr.ErrInvalidRequest, t)
+	}
+	if accessToken == nil {
+		return nil, fmt.Errorf("%w: token is invalid or expired", oautherr.ErrInvalidRequest)
+	}
+	if err := verifyConfirmation(cnf, request); err != nil {
+		return nil, err
+	}
+	return accessToken, nil
+}
+
+// intendedFor 엑세스 토큰의 대상(aud)에 클라이언트가 사용하는 자원 서버의 자원 지시자가 포함되어 있는지 여부
+func (srv *TokenExchangeGranter) intendedFor(accessToken *AccessToken, c *client.Client) bool {
+	if srv.RetrieveClientResources == nil {
+		return false
+	}
+	for _, s := range srv.RetrieveClientResources(c.Id()) {
+		if slices.Contains(accessToken.Audience(), s.URI()) {
+			return true
+		}
+	}
+	return false
+}
+
+// IssuedTokenType 토큰 교환 요청에서 발급할 토큰의 타입 식별자를 반환한다.
+// requested_token_type 이 생략된 경우 엑세스 토큰을 발급하며, JWT는 클라이언트의 토큰 형식이 JWT 인 경우에만 발급 할 수 있다.
+func IssuedTokenType(c *client.Client, request *Request) (TokenTypeIdentifier, error) {
+	switch request.RequestedTokenType {
+	case "", TokenTypeAccessToken:
+		return TokenTypeAccessToken, nil
+	case TokenTypeJWT:
+		if c.TokenFormat() == client.TokenFormatJWT {
+			return TokenTypeJWT, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unsupported requested_token_type(%s)", oautherr.ErrInvalidRequest, request.RequestedTokenType)
+}
@@ -4,7 +4,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"oauth-server-go/internal/oauth/authorization"
 	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/detail"
 	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/resource"
 	"oauth-server-go/internal/oauth/scope"
 	"oauth-server-go/internal/pkg/auth"
 	"oauth-server-go/pkg/period"
@@ -274,6 +276,52 @@ func TestAuthorizationCodeGrant_GenerateToken(t *testing.T) {
 				return c, true
 			},
 		},
+		{
+			grantTestCase: grantTestCase{
+				name: "openid 스코프가 부여되지 않은 경우 ID 토큰을 생성하지 않음",
+				request: &Request{
+					Code:         testAuthorizationCodeValue,
+					Redirect:     testRedirectURI,
+					CodeVerifier: testCodeChallenge,
+				},
+				client:               newClient(testClientID, client.TypePublic, testScopeArray),
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			grantExceptCase: grantExceptCase{
+				assertAccessToken: func(t *testing.T, accessToken *AccessToken) {
+					assert.Nil(t, accessToken.IDToken())
+				},
+			},
+			retrieveAuthorizationCode: func(code string) (*authorization.Code, bool) {
+				c := authorization.NewCode(newClient(testClientID, client.TypePublic, testScopeArray), generateTestAuthorizationCode)
+				r := newAuthorizationRequest(testRedirectURI, testCodeChallenge, testScopeArray)
+				_ = c.CopyFrom(r)
+				return c, true
+			},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name: "openid 스코프가 부여된 경우 ID 토큰 생성",
+				request: &Request{
+					Code:         testAuthorizationCodeValue,
+					Redirect:     testRedirectURI,
+					CodeVerifier: testCodeChallenge,
+				},
+				client:               newClient(testClientID, client.TypeConfidential, testScopeArray),
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			grantExceptCase: grantExceptCase{
+				assertAccessToken: func(t *testing.T, accessToken *AccessToken) {
+					assert.NotNil(t, accessToken.IDToken())
+					assert.Equal(t, testIssuer, accessToken.IDToken().Claims().Issuer)
+					assert.Equal(t, testNonce, accessToken.IDToken().Claims().Nonce)
+				},
+			},
+			refreshTokenGenerator: generateTestRefreshToken,
+			retrieveAuthorizationCode: func(code string) (*authorization.Code, bool) {
+				return newTestOpenIDCode(time.Now()), true
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -282,6 +330,7 @@ func TestAuthorizationCodeGrant_GenerateToken(t *testing.T) {
 				AccessTokenGenerator:      tc.accessTokenGenerator,
 				RefreshTokenGenerator:     tc.refreshTokenGenerator,
 				RetrieveAuthorizationCode: tc.retrieveAuthorizationCode,
+				Issuer:                    testIssuer,
 			}
 			accessToken, refreshToken, err := granter.GenerateToken(tc.client, tc.request)
 			if tc.grantExceptCase.err != nil {
@@ -720,6 +769,46 @@ func TestRefreshTokenGrant_GenerateToken(t *testing.T) {
 				err: oautherr.ErrExpiredResource,
 			},
 		},
+		{
+			grantTestCase: grantTestCase{
+				name:   "DPoP 공개키에 바인딩된 리프레시 토큰을 다른 공개키로 사용시 ErrInvalidDPoPProof 발생",
+				client: newClient(testClientID, client.TypePublic, testScopeArray),
+				request: &Request{
+					RefreshToken: testRefreshTokenValue,
+					DPoPJKT:      "other_jkt",
+				},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			refreshTokenRetriever: func() RetrieveRefreshToken {
+				expiredToken := New(newClient(testClientID, client.TypePublic, testScopeArray), generateTestAccessToken)
+				refreshToken := NewRefreshToken(expiredToken, generateStoredRefreshToken)
+				refreshToken.SetConfirmation(&Confirmation{JKT: "test_jkt"})
+				return retrieveRefreshToken(testRefreshTokenValue, refreshToken)
+			}(),
+			grantExceptCase: grantExceptCase{
+				err: oautherr.ErrInvalidDPoPProof,
+			},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:   "클라이언트 인증서에 바인딩된 리프레시 토큰을 다른 인증서로 사용시 ErrUnauthorized 발생",
+				client: newClient(testClientID, client.TypePublic, testScopeArray),
+				request: &Request{
+					RefreshToken:          testRefreshTokenValue,
+					CertificateThumbprint: "other_x5t",
+				},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			refreshTokenRetriever: func() RetrieveRefreshToken {
+				expiredToken := New(newClient(testClientID, client.TypePublic, testScopeArray), generateTestAccessToken)
+				refreshToken := NewRefreshToken(expiredToken, generateStoredRefreshToken)
+				refreshToken.SetConfirmation(&Confirmation{X5TS256: "test_x5t"})
+				return retrieveRefreshToken(testRefreshTokenValue, refreshToken)
+			}(),
+			grantExceptCase: grantExceptCase{
+				err: oautherr.ErrUnauthorized,
+			},
+		},
 		{
 			grantTestCase: grantTestCase{
 				name:   "스코프 미지정시 기존 토큰의 모든 스코프가 설정됨",
@@ -785,6 +874,128 @@ func TestRefreshTokenGrant_GenerateToken(t *testing.T) {
 				},
 			},
 		},
+		{
+			grantTestCase: grantTestCase{
+				name:   "새 토큰에 기존 토큰의 자원 소유자 인증 정보가 유지됨",
+				client: newClient(testClientID, client.TypeConfidential, testScopeArray),
+				request: &Request{
+					RefreshToken: testRefreshTokenValue,
+				},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			refreshTokenRetriever: func() RetrieveRefreshToken {
+				expiredToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+				expiredToken.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+				expiredToken.ApplyAuthentication(testStoredStart, "urn:example:acr:mfa", []string{"pwd", "otp"})
+				refreshToken := NewRefreshToken(expiredToken, generateStoredRefreshToken)
+				return retrieveRefreshToken(testRefreshTokenValue, refreshToken)
+			}(),
+			grantExceptCase: grantExceptCase{
+				assertAccessToken: func(t *testing.T, accessToken *AccessToken) {
+					assert.Equal(t, testStoredStart, accessToken.AuthTime())
+					assert.Equal(t, "urn:example:acr:mfa", accessToken.ACR())
+					assert.Equal(t, []string{"pwd", "otp"}, accessToken.AMR())
+				},
+			},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:   "기존 토큰의 대상이 축소된 경우에도 리플레시 토큰에 부여된 다른 자원을 요청 할 수 있음",
+				client: newClient(testClientID, client.TypeConfidential, testScopeArray),
+				request: &Request{
+					RefreshToken: testRefreshTokenValue,
+					Resource:     []string{"https://orders.example.com"},
+				},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			refreshTokenRetriever: func() RetrieveRefreshToken {
+				granted := []string{"https://api.example.com", "https://orders.example.com"}
+				expiredToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+				expiredToken.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+				_ = expiredToken.RestrictResources(retrieveTestResourceServer, []string{"https://api.example.com"}, granted)
+				refreshToken := newGrantedRefreshToken(expiredToken, generateStoredRefreshToken, testScopeArray, granted, nil)
+				return retrieveRefreshToken(testRefreshTokenValue, refreshToken)
+			}(),
+			grantExceptCase: grantExceptCase{
+				assertAccessToken: func(t *testing.T, accessToken *AccessToken) {
+					assert.Equal(t, []string{"https://orders.example.com"}, accessToken.Audience())
+					assert.Equal(t, []string{"scope_3"}, accessToken.Scopes())
+				},
+				assertRefreshToken: func(t *testing.T, refreshToken *RefreshToken) {
+					assert.Equal(t, testScopeArray, refreshToken.Scopes())
+					assert.Equal(t, []string{"https://api.example.com", "https://orders.example.com"}, refreshToken.Resources())
+				},
+			},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:   "리플레시 토큰에 부여되지 않은 자원 요청시 ErrInvalidTarget 발생",
+				client: newClient(testClientID, client.TypeConfidential, testScopeArray),
+				request: &Request{
+					RefreshToken: testRefreshTokenValue,
+					Resource:     []string{"https://orders.example.com"},
+				},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			refreshTokenRetriever: func() RetrieveRefreshToken {
+				expiredToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+				expiredToken.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+				refreshToken := newGrantedRefreshToken(expiredToken, generateStoredRefreshToken, testScopeArray, []string{"https://api.example.com"}, nil)
+				return retrieveRefreshToken(testRefreshTokenValue, refreshToken)
+			}(),
+			grantExceptCase: grantExceptCase{
+				err: oautherr.ErrInvalidTarget,
+			},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:   "기존 토큰의 인가 상세 정보가 축소된 경우에도 리플레시 토큰에 부여된 인가 상세 정보를 모두 부여",
+				client: newClient(testClientID, client.TypeConfidential, testScopeArray),
+				request: &Request{
+					RefreshToken: testRefreshTokenValue,
+				},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			refreshTokenGenerator: generateTestRefreshToken,
+			refreshTokenRetriever: func() RetrieveRefreshToken {
+				granted, _ := detail.Parse("[" + testAccountDetail + "," + testPaymentDetail + "]")
+				expiredToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+				expiredToken.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+				_ = expiredToken.RestrictAuthorizationDetails("["+testPaymentDetail+"]", granted)
+				refreshToken := newGrantedRefreshToken(expiredToken, generateStoredRefreshToken, testScopeArray, nil, granted)
+				return retrieveRefreshToken(testRefreshTokenValue, refreshToken)
+			}(),
+			rotation: true,
+			grantExceptCase: grantExceptCase{
+				assertAccessToken: func(t *testing.T, accessToken *AccessToken) {
+					assert.Len(t, accessToken.AuthorizationDetails(), 2)
+				},
+				assertRefreshToken: func(t *testing.T, refreshToken *RefreshToken) {
+					assert.Len(t, refreshToken.AuthorizationDetails(), 2)
+				},
+			},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:   "리플레시 토큰에 부여되지 않은 인가 상세 정보 요청시 ErrInvalidAuthorizationDetails 발생",
+				client: newClient(testClientID, client.TypeConfidential, testScopeArray),
+				request: &Request{
+					RefreshToken:         testRefreshTokenValue,
+					AuthorizationDetails: "[" + testAccountDetail + "]",
+				},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			refreshTokenRetriever: func() RetrieveRefreshToken {
+				granted, _ := detail.Parse("[" + testPaymentDetail + "]")
+				expiredToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+				expiredToken.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+				refreshToken := newGrantedRefreshToken(expiredToken, generateStoredRefreshToken, testScopeArray, nil, granted)
+				return retrieveRefreshToken(testRefreshTokenValue, refreshToken)
+			}(),
+			grantExceptCase: grantExceptCase{
+				err: oautherr.ErrInvalidAuthorizationDetails,
+			},
+		},
 		{
 			grantTestCase: grantTestCase{
 				name:   "rotaiton이 fasle로 설정되어 있을 경우 기존의 리플래시 토큰을 사용한다.",
@@ -840,12 +1051,458 @@ func TestRefreshTokenGrant_GenerateToken(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			granter := RefreshTokenGranter{
+				AccessTokenGenerator:   tc.accessTokenGenerator,
+				RefreshTokenGenerator:  tc.refreshTokenGenerator,
+				RetrieveRefreshToken:   tc.refreshTokenRetriever,
+				Rotation:               tc.rotation,
+				RetrieveResourceServer: retrieveTestResourceServer,
+			}
+
+			accessToken, refreshToken, err := granter.GenerateToken(tc.client, tc.request)
+			if tc.grantExceptCase.err != nil {
+				assert.ErrorIs(t, err, tc.grantExceptCase.err)
+			} else {
+				assert.Nil(t, err)
+				if tc.assertAccessToken != nil {
+					tc.assertAccessToken(t, accessToken)
+				}
+				if tc.assertRefreshToken != nil {
+					tc.assertRefreshToken(t, refreshToken)
+				}
+			}
+		})
+	}
+}
+
+// testDeviceCodeValue 테스트용으로 사용할 디바이스 코드
+const testDeviceCodeValue = "test_device_code"
+
+// deviceCodeGrantTestCase 디바이스 인가 승인 방식 테스트 케이스
+type deviceCodeGrantTestCase struct {
+	grantTestCase
+	grantExceptCase
+
+	pollDeviceCode PollDeviceCode
+}
+
+// pollTestDeviceCode 테스트용으로 사용할 디바이스 코드 폴링 함수
+func pollTestDeviceCode(d *authorization.DeviceCode, err error) PollDeviceCode {
+	return func(c *client.Client, deviceCode string) (*authorization.DeviceCode, error) {
+		return d, err
+	}
+}
+
+func TestDeviceCodeGrant_GenerateToken(t *testing.T) {
+	authTime := time.Now().Add(-time.Minute)
+	approved := func(c *client.Client) *authorization.DeviceCode {
+		return authorization.NewDeviceCodeWithState(testDeviceCodeValue, "BCDF-GHJK", c, testScopeArray, testUsername,
+			authorization.DeviceStatusApproved, authorization.DevicePollingInterval, time.Time{}, authTime, auth.ACRPassword, []string{auth.MethodPassword}, period.New(time.Minute))
+	}
+	confidential := newClient(testClientID, client.TypeConfidential, testScopeArray)
+	public := newClient(testClientID, client.TypePublic, testScopeArray)
+
+	tests := []deviceCodeGrantTestCase{
+		{
+			grantTestCase: grantTestCase{
+				name:    "디바이스 코드 누락시 ErrMissingParameter 발생",
+				client:  confidential,
+				request: &Request{},
+			},
+			grantExceptCase: grantExceptCase{
+				err: oautherr.ErrMissingParameter,
+			},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "폴링 에러는 그대로 반환",
+				client:  confidential,
+				request: &Request{DeviceCode: testDeviceCodeValue},
+			},
+			grantExceptCase: grantExceptCase{
+				err: oautherr.ErrAuthorizationPending,
+			},
+			pollDeviceCode: pollTestDeviceCode(nil, oautherr.ErrAuthorizationPending),
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:                 "승인된 디바이스 코드의 자원 소유자와 스코프, 인증 정보로 엑세스 토큰 발급",
+				client:               confidential,
+				request:              &Request{DeviceCode: testDeviceCodeValue},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			grantExceptCase: grantExceptCase{
+				assertAccessToken: func(t *testing.T, accessToken *AccessToken) {
+					assert.Equal(t, testUsername, accessToken.Username())
+					assert.Equal(t, testScopeArray, accessToken.Scopes())
+					assert.Equal(t, authTime, accessToken.AuthTime())
+					assert.Equal(t, auth.ACRPassword, accessToken.ACR())
+					assert.Equal(t, []string{auth.MethodPassword}, accessToken.AMR())
+				},
+				assertRefreshToken: func(t *testing.T, refreshToken *RefreshToken) {
+					assert.NotNil(t, refreshToken)
+				},
+			},
+			pollDeviceCode: pollTestDeviceCode(approved(confidential), nil),
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:                 "공개 클라이언트에는 리플레시 토큰을 발급하지 않음",
+				client:               public,
+				request:              &Request{DeviceCode: testDeviceCodeValue},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			grantExceptCase: grantExceptCase{
+				assertRefreshToken: func(t *testing.T, refreshToken *RefreshToken) {
+					assert.Nil(t, refreshToken)
+				},
+			},
+			pollDeviceCode: pollTestDeviceCode(approved(public), nil),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			granter := DeviceCodeGranter{
 				AccessTokenGenerator:  tc.accessTokenGenerator,
-				RefreshTokenGenerator: tc.refreshTokenGenerator,
-				RetrieveRefreshToken:  tc.refreshTokenRetriever,
-				Rotation:              tc.rotation,
+				RefreshTokenGenerator: generateTestRefreshToken,
+				PollDeviceCode:        tc.pollDeviceCode,
+			}
+			accessToken, refreshToken, err := granter.GenerateToken(tc.client, tc.request)
+			if tc.grantExceptCase.err != nil {
+				assert.ErrorIs(t, err, tc.grantExceptCase.err)
+			} else {
+				assert.Nil(t, err)
+				if tc.assertAccessToken != nil {
+					tc.assertAccessToken(t, accessToken)
+				}
+				if tc.assertRefreshToken != nil {
+					tc.assertRefreshToken(t, refreshToken)
+				}
 			}
+		})
+	}
+}
+
+// testAuthReqID 테스트용으로 사용할 백채널 인증 요청 식별자
+const testAuthReqID = "test_auth_req_id"
+
+func TestBackchannelGrant_GenerateToken(t *testing.T) {
+	confidential := newClient(testClientID, client.TypeConfidential, testScopeArray)
+	authTime := time.Now().Add(-time.Minute)
+	approved := authorization.NewBackchannelAuthenticationWithState(testAuthReqID, confidential, testUsername, testScopeArray, "", "",
+		authorization.BackchannelStatusApproved, authorization.BackchannelPollingInterval, time.Time{}, authTime, auth.ACRMultiFactor, []string{auth.MethodPassword, auth.MethodOTP}, period.New(time.Minute))
+
+	newGranter := func(d *authorization.BackchannelAuthentication, err error) BackchannelGranter {
+		return BackchannelGranter{
+			AccessTokenGenerator:  generateTestAccessToken,
+			RefreshTokenGenerator: generateTestRefreshToken,
+			Issuer:                "https://auth.example.com",
+			PollBackchannelAuthentication: func(c *client.Client, authReqID string) (*authorization.BackchannelAuthentication, error) {
+				return d, err
+			},
+		}
+	}
+
+	t.Run("인증 요청 식별자 누락시 ErrMissingParameter 발생", func(t *testing.T) {
+		granter := newGranter(approved, nil)
+
+		_, _, err := granter.GenerateToken(confidential, &Request{})
+		assert.ErrorIs(t, err, oautherr.ErrMissingParameter)
+	})
+
+	t.Run("공개 클라이언트는 ErrInvalidClient 발생", func(t *testing.T) {
+		granter := newGranter(approved, nil)
+
+		_, _, err := granter.GenerateToken(newClient(testClientID, client.TypePublic, testScopeArray), &Request{AuthReqID: testAuthReqID})
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("폴링 에러는 그대로 반환", func(t *testing.T) {
+		granter := newGranter(nil, oautherr.ErrSlowDown)
+
+		_, _, err := granter.GenerateToken(confidential, &Request{AuthReqID: testAuthReqID})
+		assert.ErrorIs(t, err, oautherr.ErrSlowDown)
+	})
+
+	t.Run("승인된 요청의 자원 소유자로 엑세스 토큰과 ID 토큰 발급", func(t *testing.T) {
+		granter := newGranter(approved, nil)
+
+		accessToken, refreshToken, err := granter.GenerateToken(confidential, &Request{AuthReqID: testAuthReqID})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, refreshToken)
+		assert.Equal(t, testUsername, accessToken.Username())
+		assert.Equal(t, testScopeArray, accessToken.Scopes())
+		assert.Equal(t, testUsername, accessToken.IDToken().Claims().Subject)
+		assert.Equal(t, authTime.Unix(), accessToken.IDToken().Claims().AuthTime)
+		assert.Equal(t, auth.ACRMultiFactor, accessToken.ACR())
+		assert.Equal(t, []string{auth.MethodPassword, auth.MethodOTP}, accessToken.AMR())
+		assert.Equal(t, auth.ACRMultiFactor, accessToken.IDToken().Claims().ACR)
+	})
+}
+
+// 토큰 교환 방식 테스트에서 사용할 상수 모음
+const (
+	// testSubjectTokenValue 테스트용으로 사용할 교환할 토큰
+	testSubjectTokenValue = "test_subject_token"
+	// testActorTokenValue 테스트용으로 사용할 행동 주체의 토큰
+	testActorTokenValue = "test_actor_token"
+	// testActorClientID 테스트용으로 사용할 행동 주체의 클라이언트 아이디
+	testActorClientID = "test_actor_client_id"
+)
+
+// retrieveTestAccessTokens 토큰값에 맞는 엑세스 토큰을 반환하는 테스트용 엑세스 토큰 조회 함수
+func retrieveTestAccessTokens(tokens map[string]*AccessToken) RetrieveAccessToken {
+	return func(accessToken string) (*AccessToken, bool) {
+		t, ok := tokens[accessToken]
+		return t, ok
+	}
+}
+
+func TestTokenExchangeGrant_GenerateToken(t *testing.T) {
+	confidential := newClient(testClientID, client.TypeConfidential, testScopeArray)
+
+	// 교환을 요청할 클라이언트가 사용하는 자원 서버
+	clientResource := resource.New("https://exchange.example.com", "exchange", testScopeArray)
+	clientResource.SetClientID(testClientID)
+	retrieveClientResources := func(clientID string) []*resource.Server {
+		if clientID == testClientID {
+			return []*resource.Server{clientResource}
+		}
+		return nil
+	}
+
+	// 다른 클라이언트에게 발급되었지만 대상(aud)에 교환을 요청할 클라이언트가 사용하는 자원 서버가 포함된 토큰
+	subject := New(newClient("subject_client_id", client.TypePublic, testScopeArray), func() string { return testSubjectTokenValue })
+	subject.ApplyResourceOwnerInfo(testUsername, []string{"scope_1", "scope_2"})
+	subject.ApplyAuthentication(testStoredStart, "urn:example:acr:mfa", []string{"pwd", "otp"})
+	subject.SetAudience([]string{clientResource.URI()})
+
+	actor := New(newClient(testActorClientID, client.TypeConfidential, testScopeArray), func() string { return testActorTokenValue })
+	actor.ApplyResourceOwnerInfo("", testScopeArray)
+	actor.SetAudience([]string{clientResource.URI()})
+
+	expired := NewWithRange(confidential, func() string { return "expired" }, period.New(time.Duration(-1)))
+
+	// 다른 클라이언트에게 발급된 토큰
+	otherClient := newClient("other_client_id", client.TypeConfidential, testScopeArray)
+	other := New(otherClient, func() string { return "other" })
+	other.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+
+	// 다른 클라이언트에게 발급되었으며 대상(aud)이 자원 지시자가 아닌 클라이언트 아이디인 토큰
+	otherAudience := New(otherClient, func() string { return "other_audience" })
+	otherAudience.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+	otherAudience.SetAudience([]string{testClientID})
+
+	// DPoP 공개키에 바인딩된 토큰
+	bound := New(confidential, func() string { return "bound" })
+	bound.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+	bound.SetConfirmation(&Confirmation{JKT: "test_jkt"})
+
+	retriever := retrieveTestAccessTokens(map[string]*AccessToken{
+		testSubjectTokenValue: subject,
+		testActorTokenValue:   actor,
+		"expired":             expired,
+		"other":               other,
+		"other_audience":      otherAudience,
+		"bound":               bound,
+	})
+
+	// 다른 클라이언트에게 발급된 리플레시 토큰. 대상(aud)에 교환을 요청할 클라이언트가 사용하는 자원 서버가 포함되어 있어도 사용 할 수 없다.
+	otherAccessToken := New(otherClient, func() string { return "other_access_token" })
+	otherAccessToken.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+	otherAccessToken.SetAudience([]string{clientResource.URI()})
+	otherRefreshToken := NewRefreshToken(otherAccessToken, generateTestRefreshToken)
+
+	tests := []struct {
+		grantTestCase
+		grantExceptCase
+	}{
+		{
+			grantTestCase: grantTestCase{
+				name:    "공개 클라이언트는 ErrInvalidClient 발생",
+				client:  newClient(testClientID, client.TypePublic, testScopeArray),
+				request: &Request{SubjectToken: testSubjectTokenValue, SubjectTokenType: TokenTypeAccessToken},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrInvalidClient},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "교환할 토큰 누락시 ErrMissingParameter 발생",
+				client:  confidential,
+				request: &Request{SubjectTokenType: TokenTypeAccessToken},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrMissingParameter},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "지원하지 않는 토큰 타입은 ErrInvalidRequest 발생",
+				client:  confidential,
+				request: &Request{SubjectToken: testSubjectTokenValue, SubjectTokenType: "urn:ietf:params:oauth:token-type:saml2"},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrInvalidRequest},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "만료된 토큰은 ErrInvalidRequest 발생",
+				client:  confidential,
+				request: &Request{SubjectToken: "expired", SubjectTokenType: TokenTypeAccessToken},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrInvalidRequest},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "클라이언트 토큰 형식이 JWT가 아닌 경우 JWT 요청시 ErrInvalidRequest 발생",
+				client:  confidential,
+				request: &Request{SubjectToken: testSubjectTokenValue, SubjectTokenType: TokenTypeAccessToken, RequestedTokenType: TokenTypeJWT},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrInvalidRequest},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "교환할 토큰의 범위 외 스코프 요청시 ErrInvalidScope 발생",
+				client:  confidential,
+				request: &Request{SubjectToken: testSubjectTokenValue, SubjectTokenType: TokenTypeAccessToken, Scope: "scope_3"},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrInvalidScope},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "상대 경로 resource 요청시 ErrInvalidTarget 발생",
+				client:  confidential,
+				request: &Request{SubjectToken: testSubjectTokenValue, SubjectTokenType: TokenTypeAccessToken, Resource: []string{"/api"}},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrInvalidTarget},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "다른 클라이언트에게 발급된 토큰은 ErrUnauthorized 발생",
+				client:  confidential,
+				request: &Request{SubjectToken: "other", SubjectTokenType: TokenTypeAccessToken},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrUnauthorized},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "대상(aud)에 클라이언트가 사용하는 자원 서버가 없는 토큰은 ErrUnauthorized 발생",
+				client:  confidential,
+				request: &Request{SubjectToken: "other_audience", SubjectTokenType: TokenTypeAccessToken},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrUnauthorized},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "다른 클라이언트에게 발급된 리플레시 토큰은 ErrUnauthorized 발생",
+				client:  confidential,
+				request: &Request{SubjectToken: testRefreshTokenValue, SubjectTokenType: TokenTypeRefreshToken},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrUnauthorized},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "다른 클라이언트에게 발급된 행동 주체의 토큰은 ErrUnauthorized 발생",
+				client:  confidential,
+				request: &Request{SubjectToken: testSubjectTokenValue, SubjectTokenType: TokenTypeAccessToken, ActorToken: "other", ActorTokenType: TokenTypeAccessToken},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrUnauthorized},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "DPoP 공개키에 바인딩된 토큰을 증명 없이 교환시 ErrInvalidDPoPProof 발생",
+				client:  confidential,
+				request: &Request{SubjectToken: "bound", SubjectTokenType: TokenTypeAccessToken},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrInvalidDPoPProof},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:                 "등록되지 않은 audience 요청시 ErrInvalidTarget 발생",
+				client:               confidential,
+				request:              &Request{SubjectToken: testSubjectTokenValue, SubjectTokenType: TokenTypeAccessToken, Audience: []string{"https://unknown.example.com"}},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrInvalidTarget},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:    "행동 주체의 토큰 타입 누락시 ErrMissingParameter 발생",
+				client:  confidential,
+				request: &Request{SubjectToken: testSubjectTokenValue, SubjectTokenType: TokenTypeAccessToken, ActorToken: testActorTokenValue},
+			},
+			grantExceptCase: grantExceptCase{err: oautherr.ErrMissingParameter},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:   "가장(impersonation)시 교환할 토큰의 자원 소유자로 축소된 스코프의 토큰 발급",
+				client: confidential,
+				request: &Request{
+					SubjectToken:     testSubjectTokenValue,
+					SubjectTokenType: TokenTypeAccessToken,
+					Scope:            "scope_1",
+					Audience:         []string{"https://orders.example.com"},
+					Resource:         []string{"https://api.example.com"},
+				},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			grantExceptCase: grantExceptCase{
+				assertAccessToken: func(t *testing.T, accessToken *AccessToken) {
+					assert.Equal(t, testClientID, accessToken.Client().Id())
+					assert.Equal(t, testUsername, accessToken.Username())
+					assert.Equal(t, []string{"scope_1"}, accessToken.Scopes())
+					assert.Equal(t, []string{"https://orders.example.com", "https://api.example.com"}, accessToken.Audience())
+					assert.Nil(t, accessToken.Actor())
+					assert.Equal(t, testStoredStart, accessToken.AuthTime())
+					assert.Equal(t, "urn:example:acr:mfa", accessToken.ACR())
+					assert.Equal(t, []string{"pwd", "otp"}, accessToken.AMR())
+				},
+				assertRefreshToken: func(t *testing.T, refreshToken *RefreshToken) {
+					assert.Nil(t, refreshToken)
+				},
+			},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:                 "DPoP 공개키에 바인딩된 토큰을 같은 공개키의 증명과 함께 교환",
+				client:               confidential,
+				request:              &Request{SubjectToken: "bound", SubjectTokenType: TokenTypeAccessToken, DPoPJKT: "test_jkt"},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			grantExceptCase: grantExceptCase{
+				assertAccessToken: func(t *testing.T, accessToken *AccessToken) {
+					assert.Equal(t, testScopeArray, accessToken.Scopes())
+				},
+			},
+		},
+		{
+			grantTestCase: grantTestCase{
+				name:   "위임(delegation)시 행동 주체가 기록됨",
+				client: confidential,
+				request: &Request{
+					SubjectToken:     testSubjectTokenValue,
+					SubjectTokenType: TokenTypeAccessToken,
+					ActorToken:       testActorTokenValue,
+					ActorTokenType:   TokenTypeAccessToken,
+				},
+				accessTokenGenerator: generateTestAccessToken,
+			},
+			grantExceptCase: grantExceptCase{
+				assertAccessToken: func(t *testing.T, accessToken *AccessToken) {
+					assert.Equal(t, []string{"scope_1", "scope_2"}, accessToken.Scopes())
+					assert.Equal(t, &Actor{Subject: testActorClientID, ClientID: testActorClientID}, accessToken.Actor())
+				},
+			},
+		},
+	}
 
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			granter := TokenExchangeGranter{
+				AccessTokenGenerator:    tc.accessTokenGenerator,
+				RetrieveAccessToken:     retriever,
+				RetrieveRefreshToken:    retrieveRefreshToken(testRefreshTokenValue, otherRefreshToken),
+				RetrieveResourceServer:  retrieveTestResourceServer,
+				RetrieveClientResources: retrieveClientResources,
+			}
 			accessToken, refreshToken, err := granter.GenerateToken(tc.client, tc.request)
 			if tc.grantExceptCase.err != nil {
 				assert.ErrorIs(t, err, tc.grantExceptCase.err)
@@ -860,4 +1517,28 @@ func TestRefreshTokenGrant_GenerateToken(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("위임 받은 토큰을 다시 위임하면 이전 행동 주체가 중첩됨", func(t *testing.T) {
+		delegated := New(confidential, func() string { return "delegated" })
+		delegated.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+		delegated.SetActor(&Actor{Subject: "first_actor"})
+
+		granter := TokenExchangeGranter{
+			AccessTokenGenerator: generateTestAccessToken,
+			RetrieveAccessToken: retrieveTestAccessTokens(map[string]*AccessToken{
+				"delegated":         delegated,
+				testActorTokenValue: actor,
+			}), RetrieveClientResources: retrieveClientResources,
+		}
+		accessToken, _, err := granter.GenerateToken(confidential, &Request{
+			SubjectToken:     "delegated",
+			SubjectTokenType: TokenTypeJWT,
+			ActorToken:       testActorTokenValue,
+			ActorTokenType:   TokenTypeAccessToken,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, testActorClientID, accessToken.Actor().Subject)
+		assert.Equal(t, &Actor{Subject: "first_actor"}, accessToken.Actor().Actor)
+	})
 }
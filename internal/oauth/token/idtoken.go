@@ -0,0 +1,102 @@
+package token
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"oauth-server-go/internal/oauth/authorization"
+)
+
+// IDTokenClaims [OpenID Connect] 에 정의된 ID 토큰의 클레임
+//
+// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#IDToken
+type IDTokenClaims struct {
+	Issuer          string   `json:"iss"`
+	Subject         string   `json:"sub"`
+	Audience        []string `json:"aud"`
+	ExpiresAt       int64    `json:"exp"`
+	IssuedAt        int64    `json:"iat"`
+	AuthTime        int64    `json:"auth_time,omitempty"`
+	ACR             string   `json:"acr,omitempty"`
+	AMR             []string `json:"amr,omitempty"`
+	Nonce           string   `json:"nonce,omitempty"`
+	AccessTokenHash string   `json:"at_hash,omitempty"`
+	CodeHash        string   `json:"c_hash,omitempty"`
+}
+
+// IDToken [OpenID Connect] ID 토큰
+//
+// 자원 소유자의 인증 정보를 담고 있으며 엑세스 토큰과 함께 발급된다.
+// at_hash 클레임은 최종적으로 발급되는 엑세스 토큰값으로 계산 되어야 하므로 엑세스 토큰의 인코딩이 끝난 후 [IDToken.Sign] 으로 서명한다.
+//
+// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#IDToken
+type IDToken struct {
+	claims *IDTokenClaims
+
+	// value 서명된 ID 토큰값. 서명되기 전에는 공백("")이다.
+	value string
+}
+
+// NewIDToken 인가 코드와 인가 코드로 발급된 엑세스 토큰의 정보로 ID 토큰을 생성한다.
+func NewIDToken(issuer string, code *authorization.Code, t *AccessToken) *IDToken {
+	claims := &IDTokenClaims{
+		Issuer:    issuer,
+		Subject:   code.Username(),
+		Audience:  []string{t.Client().Id()},
+		ExpiresAt: t.End().Unix(),
+		IssuedAt:  t.Start().Unix(),
+		Nonce:     code.Nonce(),
+		ACR:       code.ACR(),
+		AMR:       code.AMR(),
+		CodeHash:  halfHash(code.Value()),
+	}
+	if !code.AuthTime().IsZero() {
+		claims.AuthTime = code.AuthTime().Unix()
+	}
+	return &IDToken{claims: claims}
+}
+
+// NewBackchannelIDToken 승인된 백채널 인증 요청과 이로 발급된 엑세스 토큰의 정보로 ID 토큰을 생성한다.
+func NewBackchannelIDToken(issuer string, b *authorization.BackchannelAuthentication, t *AccessToken) *IDToken {
+	claims := &IDTokenClaims{
+		Issuer:    issuer,
+		Subject:   b.Username(),
+		Audience:  []string{t.Client().Id()},
+		ExpiresAt: t.End().Unix(),
+		IssuedAt:  t.Start().Unix(),
+		ACR:       b.ACR(),
+		AMR:       b.AMR(),
+	}
+	if !b.AuthTime().IsZero() {
+		claims.AuthTime = b.AuthTime().Unix()
+	}
+	return &IDToken{claims: claims}
+}
+
+func (i *IDToken) Claims() *IDTokenClaims {
+	return i.claims
+}
+
+func (i *IDToken) Value() string {
+	return i.value
+}
+
+// Sign 인자로 받은 엑세스 토큰값으로 at_hash 클레임을 계산하고 클레임을 서명한다.
+func (i *IDToken) Sign(sign Sign, accessToken string) error {
+	i.claims.AccessTokenHash = halfHash(accessToken)
+	signed, err := sign("", i.claims)
+	if err != nil {
+		return fmt.Errorf("error occurred during sign id token: %w", err)
+	}
+	i.value = signed
+	return nil
+}
+
+// halfHash 인자로 받은 값을 SHA-256으로 해싱하고 왼쪽 절반을 base64url로 인코딩 하여 반환한다.
+// [OpenID Connect] 의 at_hash, c_hash 계산에 사용되며 현재 지원하는 서명 알고리즘이 모두 SHA-256을 사용하므로 SHA-256으로 고정한다.
+//
+// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#CodeIDToken
+func halfHash(v string) string {
+	sum := sha256.Sum256([]byte(v))
+	return base64.RawURLEncoding.EncodeToString(sum[:len(sum)/2])
+}
@@ -0,0 +1,81 @@
+package token
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/authorization"
+	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/scope"
+	"testing"
+	"time"
+)
+
+// testNonce 테스트용으로 사용할 nonce 값
+const testNonce = "test_nonce"
+
+// newTestOpenIDCode openid 스코프가 부여된 테스트용 인가 코드를 생성한다.
+func newTestOpenIDCode(authTime time.Time) *authorization.Code {
+	scopes := append([]string{scope.OpenID}, testScopeArray...)
+	c := authorization.NewCode(newClient(testClientID, client.TypeConfidential, scopes), generateTestAuthorizationCode)
+	r := newAuthorizationRequest(testRedirectURI, testCodeChallenge, scopes)
+	r.Nonce = testNonce
+	r.AuthTime = authTime
+	_ = c.CopyFrom(r)
+	return c
+}
+
+func TestNewIDToken(t *testing.T) {
+	authTime := time.Now().Add(-time.Minute)
+	code := newTestOpenIDCode(authTime)
+	accessToken := New(code.Client(), generateTestAccessToken)
+	accessToken.ApplyAuthorizationCode(code)
+
+	idToken := NewIDToken(testIssuer, code, accessToken)
+	claims := idToken.Claims()
+	assert.Equal(t, testIssuer, claims.Issuer)
+	assert.Equal(t, testUsername, claims.Subject)
+	assert.Equal(t, []string{testClientID}, claims.Audience)
+	assert.Equal(t, accessToken.Start().Unix(), claims.IssuedAt)
+	assert.Equal(t, accessToken.End().Unix(), claims.ExpiresAt)
+	assert.Equal(t, authTime.Unix(), claims.AuthTime)
+	assert.Equal(t, testNonce, claims.Nonce)
+	assert.Equal(t, halfHash(testAuthorizationCodeValue), claims.CodeHash)
+	assert.Len(t, claims.CodeHash, 22)
+}
+
+func TestIDToken_Sign(t *testing.T) {
+	t.Run("엑세스 토큰값으로 at_hash를 계산하여 서명함", func(t *testing.T) {
+		code := newTestOpenIDCode(time.Now())
+		accessToken := New(code.Client(), generateTestAccessToken)
+		idToken := NewIDToken(testIssuer, code, accessToken)
+
+		var typ string
+		var signed any
+		err := idToken.Sign(func(tp string, c any) (string, error) {
+			typ, signed = tp, c
+			return testSignedValue, nil
+		}, testSignedValue)
+		assert.Nil(t, err)
+		assert.Equal(t, "", typ)
+		assert.Equal(t, idToken.Claims(), signed)
+		assert.Equal(t, halfHash(testSignedValue), idToken.Claims().AccessTokenHash)
+		assert.Equal(t, testSignedValue, idToken.Value())
+	})
+
+	t.Run("서명 실패시 에러 반환", func(t *testing.T) {
+		signErr := errors.New("sign error")
+		code := newTestOpenIDCode(time.Now())
+		idToken := NewIDToken(testIssuer, code, New(code.Client(), generateTestAccessToken))
+
+		err := idToken.Sign(func(string, any) (string, error) {
+			return "", signErr
+		}, testAccessTokenValue)
+		assert.ErrorIs(t, err, signErr)
+		assert.Equal(t, "", idToken.Value())
+	})
+}
+
+func TestHalfHash(t *testing.T) {
+	// OpenID Connect Core 1.0 의 예제 값
+	assert.Equal(t, "77QmUPtjPfzWtF2AnpK9RQ", halfHash("jHkWEdUXMU1BwAsC4vtUsZwnNvTIxEl0z9K3vx5KF0Y"))
+}
@@ -0,0 +1,86 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/key"
+	"slices"
+	"time"
+)
+
+// MediaTypeTokenIntrospectionJWT 토큰 질의 요청의 Accept 헤더와 응답의 Content-Type 헤더에 사용하는 JWT 토큰 질의 응답의 미디어 타입 [RFC 9701]
+//
+// [RFC 9701]: https://datatracker.ietf.org/doc/html/rfc9701#section-4
+const MediaTypeTokenIntrospectionJWT = "application/" + JWTTypeTokenIntrospection
+
+// SignWith 인자로 받은 알고리즘으로 클레임을 서명하여 JWS Compact 형식의 문자열로 반환하는 함수
+//
+// Parameters:
+//   - alg: 서명 알고리즘
+//   - typ: JOSE 헤더의 typ 값
+//   - claims: 서명할 클레임
+type SignWith func(alg key.Algorithm, typ string, claims any) (string, error)
+
+// IntrospectionClaims [RFC 9701] 에 정의된 JWT 토큰 질의 응답의 클레임
+//
+// 토큰 질의 응답은 token_introspection 클레임에 담기며, 토큰 질의를 요청한 자원 서버(클라이언트)의 아이디가 aud 클레임으로 설정된다.
+//
+// [RFC 9701]: https://datatracker.ietf.org/doc/html/rfc9701#section-5
+type IntrospectionClaims struct {
+	Issuer             string      `json:"iss"`
+	Audience           string      `json:"aud"`
+	IssuedAt           int64       `json:"iat"`
+	TokenIntrospection *Inspection `json:"token_introspection"`
+}
+
+// IntrospectionEncoder 토큰 질의 응답을 [RFC 9701] 에 정의된 JWT 형식으로 인코딩 한다.
+//
+// 토큰 질의를 요청한 클라이언트에 등록된 서명 알고리즘(introspection_signed_response_alg)으로 서명하며,
+// 등록된 알고리즘이 없는 경우 Algorithms 의 첫번째 알고리즘을 사용한다.
+// 클라이언트에 암호화 알고리즘(introspection_encrypted_response_alg)이 등록된 경우 서명된 JWT를 클라이언트의 공개키로 암호화한다.
+//
+// [RFC 9701]: https://datatracker.ietf.org/doc/html/rfc9701
+type IntrospectionEncoder struct {
+	// Issuer 토큰 발행자(iss) 식별자
+	Issuer string
+
+	// Algorithms 인가 서버가 서명에 사용하는 알고리즘 목록
+	Algorithms []key.Algorithm
+
+	// SignWith 클레임 서명 함수
+	SignWith SignWith
+
+	// FetchJWKS 응답을 암호화 할 때 클라이언트의 jwks_uri 에서 공개키 목록을 조회하는 함수
+	FetchJWKS client.FetchJWKS
+}
+
+// Encode 토큰 질의 응답을 클레임에 담아 서명하고, 필요한 경우 암호화 하여 JWT 문자열을 반환한다.
+//
+// 클라이언트에 등록된 서명 알고리즘을 인가 서버에서 사용하지 않는 경우 [oautherr.ErrUnknown] 를 반환한다.
+func (e *IntrospectionEncoder) Encode(ctx context.Context, c *client.Client, i *Inspection) (string, error) {
+	alg := e.Algorithms[0]
+	if registered := c.IntrospectionSignedResponseAlg(); registered != "" {
+		alg = key.Algorithm(registered)
+	}
+	if !slices.Contains(e.Algorithms, alg) {
+		return "", fmt.Errorf("%w: introspection signing algorithm(%s) is not supported", oautherr.ErrUnknown, alg)
+	}
+
+	claims := &IntrospectionClaims{
+		Issuer:             e.Issuer,
+		Audience:           c.Id(),
+		IssuedAt:           time.Now().Unix(),
+		TokenIntrospection: i,
+	}
+	jws, err := e.SignWith(alg, JWTTypeTokenIntrospection, claims)
+	if err != nil {
+		return "", err
+	}
+
+	if c.IntrospectionEncryptedResponseAlg() == "" {
+		return jws, nil
+	}
+	return c.EncryptJWT(ctx, e.FetchJWKS, c.IntrospectionEncryptedResponseAlg(), c.IntrospectionEncryptedResponseEnc(), jws)
+}
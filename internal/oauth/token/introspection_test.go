@@ -0,0 +1,106 @@
+package token
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/key"
+	"testing"
+)
+
+// captureSignWith 서명 요청된 알고리즘과 클레임을 저장하고 고정된 서명값을 반환하는 테스트용 서명 함수를 생성한다.
+func captureSignWith(alg *key.Algorithm, claims **IntrospectionClaims) SignWith {
+	return func(a key.Algorithm, typ string, c any) (string, error) {
+		*alg = a
+		*claims = c.(*IntrospectionClaims)
+		return testSignedValue, nil
+	}
+}
+
+func TestIntrospectionEncoder_Encode(t *testing.T) {
+	algorithms := []key.Algorithm{key.AlgorithmRS256, key.AlgorithmES256}
+	inspection := &Inspection{Active: true, ClientID: testClientID}
+
+	t.Run("등록된 서명 알고리즘이 없는 경우 기본 서명 알고리즘으로 서명", func(t *testing.T) {
+		var alg key.Algorithm
+		var claims *IntrospectionClaims
+		encoder := IntrospectionEncoder{Issuer: testIssuer, Algorithms: algorithms, SignWith: captureSignWith(&alg, &claims)}
+
+		signed, err := encoder.Encode(context.Background(), newClient(testClientID, client.TypeConfidential, nil), inspection)
+
+		assert.NoError(t, err)
+		assert.Equal(t, testSignedValue, signed)
+		assert.Equal(t, key.AlgorithmRS256, alg)
+		assert.Equal(t, testIssuer, claims.Issuer)
+		assert.Equal(t, testClientID, claims.Audience)
+		assert.Equal(t, inspection, claims.TokenIntrospection)
+	})
+
+	t.Run("클라이언트에 등록된 서명 알고리즘으로 서명", func(t *testing.T) {
+		var alg key.Algorithm
+		var claims *IntrospectionClaims
+		encoder := IntrospectionEncoder{Issuer: testIssuer, Algorithms: algorithms, SignWith: captureSignWith(&alg, &claims)}
+		c := newClient(testClientID, client.TypeConfidential, nil)
+		c.SetIntrospectionSignedResponseAlg(jose.ES256)
+
+		_, err := encoder.Encode(context.Background(), c, inspection)
+
+		assert.NoError(t, err)
+		assert.Equal(t, key.AlgorithmES256, alg)
+	})
+
+	t.Run("인가 서버에서 사용하지 않는 서명 알고리즘", func(t *testing.T) {
+		var alg key.Algorithm
+		var claims *IntrospectionClaims
+		encoder := IntrospectionEncoder{Issuer: testIssuer, Algorithms: algorithms, SignWith: captureSignWith(&alg, &claims)}
+		c := newClient(testClientID, client.TypeConfidential, nil)
+		c.SetIntrospectionSignedResponseAlg(jose.PS256)
+
+		_, err := encoder.Encode(context.Background(), c, inspection)
+
+		assert.ErrorIs(t, err, oautherr.ErrUnknown)
+	})
+
+	t.Run("암호화 알고리즘이 등록된 경우 클라이언트의 공개키로 암호화", func(t *testing.T) {
+		var alg key.Algorithm
+		var claims *IntrospectionClaims
+		encoder := IntrospectionEncoder{Issuer: testIssuer, Algorithms: algorithms, SignWith: captureSignWith(&alg, &claims)}
+
+		private, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		c := newClient(testClientID, client.TypeConfidential, nil)
+		c.SetJWKS(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &private.PublicKey, KeyID: "enc-key", Use: "enc"}}})
+		c.SetIntrospectionEncryptedResponseAlg(jose.ECDH_ES)
+
+		encrypted, err := encoder.Encode(context.Background(), c, inspection)
+		assert.NoError(t, err)
+
+		jwe, err := jose.ParseEncrypted(encrypted, []jose.KeyAlgorithm{jose.ECDH_ES}, []jose.ContentEncryption{jose.A128CBC_HS256})
+		assert.NoError(t, err)
+		assert.Equal(t, "enc-key", jwe.Header.KeyID)
+		assert.Equal(t, "JWT", jwe.Header.ExtraHeaders[jose.HeaderContentType])
+
+		decrypted, err := jwe.Decrypt(private)
+		assert.NoError(t, err)
+		assert.Equal(t, testSignedValue, string(decrypted))
+	})
+
+	t.Run("암호화에 사용할 공개키가 없는 경우 ErrInvalidClient", func(t *testing.T) {
+		var alg key.Algorithm
+		var claims *IntrospectionClaims
+		encoder := IntrospectionEncoder{Issuer: testIssuer, Algorithms: algorithms, SignWith: captureSignWith(&alg, &claims)}
+
+		private, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		c := newClient(testClientID, client.TypeConfidential, nil)
+		c.SetJWKS(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &private.PublicKey, Use: "sig"}}})
+		c.SetIntrospectionEncryptedResponseAlg(jose.ECDH_ES)
+
+		_, err := encoder.Encode(context.Background(), c, inspection)
+
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+}
@@ -0,0 +1,105 @@
+package token
+
+import (
+	"oauth-server-go/internal/oauth/detail"
+	"oauth-server-go/internal/oauth/scope"
+)
+
+// JWTTypeAccessToken JWT 엑세스 토큰의 JOSE 헤더 typ 값 [RFC 9068]
+//
+// [RFC 9068]: https://datatracker.ietf.org/doc/html/rfc9068#section-2.1
+const JWTTypeAccessToken = "at+jwt"
+
+// JWTTypeTokenIntrospection JWT 토큰 질의 응답의 JOSE 헤더 typ 값 [RFC 9701]
+//
+// [RFC 9701]: https://datatracker.ietf.org/doc/html/rfc9701#section-5
+const JWTTypeTokenIntrospection = "token-introspection+jwt"
+
+// Sign 클레임을 서명하여 JWS Compact 형식의 문자열로 반환하는 함수
+//
+// Parameters:
+//   - typ: JOSE 헤더의 typ 값
+//   - claims: 서명할 클레임
+type Sign func(typ string, claims any) (string, error)
+
+// Claims [RFC 9068] 에 정의된 JWT 엑세스 토큰의 클레임
+//
+// [RFC 9068]: https://datatracker.ietf.org/doc/html/rfc9068#section-2.2
+type Claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  []string `json:"aud"`
+	ClientID  string   `json:"client_id"`
+	Scope     string   `json:"scope,omitempty"`
+	JTI       string   `json:"jti"`
+	IssuedAt  int64    `json:"iat"`
+	ExpiresAt int64    `json:"exp"`
+	Actor     *Actor   `json:"act,omitempty"`
+
+	// Confirmation DPoP 공개키나 클라이언트 인증서에 바인딩된 토큰의 경우 공개키나 인증서의 Thumbprint 를 가진다.
+	Confirmation *Confirmation `json:"cnf,omitempty"`
+
+	// AuthorizationDetails [RFC 9396] 토큰에 부여된 인가 상세 정보
+	//
+	// [RFC 9396]: https://datatracker.ietf.org/doc/html/rfc9396#section-9.1
+	AuthorizationDetails detail.Details `json:"authorization_details,omitempty"`
+
+	// AuthTime, ACR, AMR [RFC 9068] 자원 소유자가 인증한 시간과 인증 컨텍스트 클래스, 인증 방식
+	//
+	// [RFC 9068]: https://datatracker.ietf.org/doc/html/rfc9068#section-2.2.1
+	AuthTime int64    `json:"auth_time,omitempty"`
+	ACR      string   `json:"acr,omitempty"`
+	AMR      []string `json:"amr,omitempty"`
+}
+
+// NewClaims 엑세스 토큰의 정보로 JWT 클레임을 생성한다.
+//
+// 자원 소유자가 없는 토큰(클라이언트 자격 증명 방식 등)의 경우 sub 클레임은 클라이언트 아이디로 설정된다.
+// 토큰에 따로 지정된 대상(audience)이 없을 경우 aud 클레임은 토큰을 발급 받은 클라이언트의 아이디로 설정된다.
+// 토큰 교환으로 위임 받은 토큰의 경우 act 클레임에 위임 받은 주체가 설정된다.
+// DPoP 공개키에 바인딩된 토큰의 경우 cnf 클레임에 공개키의 Thumbprint 가 설정된다.
+// 인가 상세 정보가 부여된 토큰의 경우 authorization_details 클레임에 인가 상세 정보가 설정된다.
+// 자원 소유자의 인증으로 발급된 토큰의 경우 auth_time, acr, amr 클레임에 자원 소유자의 인증 정보가 설정된다.
+func NewClaims(issuer string, t *AccessToken) *Claims {
+	audience := t.Audience()
+	if len(audience) == 0 {
+		audience = []string{t.Client().Id()}
+	}
+	claims := &Claims{
+		Issuer:    issuer,
+		Subject:   t.Subject(),
+		Audience:  audience,
+		ClientID:  t.Client().Id(),
+		Scope:     scope.Join(t.Scopes()),
+		JTI:       t.Value(),
+		IssuedAt:  t.Start().Unix(),
+		ExpiresAt: t.End().Unix(),
+		Actor:     t.Actor(),
+
+		Confirmation:         t.Confirmation(),
+		AuthorizationDetails: t.AuthorizationDetails(),
+		ACR:                  t.ACR(),
+		AMR:                  t.AMR(),
+	}
+	if !t.AuthTime().IsZero() {
+		claims.AuthTime = t.AuthTime().Unix()
+	}
+	return claims
+}
+
+// JWTEncoder 엑세스 토큰을 [RFC 9068] 에 정의된 JWT 형식으로 인코딩 한다.
+//
+// [RFC 9068]: https://datatracker.ietf.org/doc/html/rfc9068
+type JWTEncoder struct {
+	// Issuer 토큰 발행자(iss) 식별자
+	Issuer string
+
+	// Sign 클레임 서명 함수
+	Sign Sign
+}
+
+// Encode 엑세스 토큰의 정보로 클레임을 만들고 서명하여 JWT 문자열을 반환한다.
+// [EncodeToken] 의 구현체로 사용된다.
+func (e *JWTEncoder) Encode(t *AccessToken) (string, error) {
+	return e.Sign(JWTTypeAccessToken, NewClaims(e.Issuer, t))
+}
@@ -0,0 +1,127 @@
+package token
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/client"
+	"testing"
+	"time"
+)
+
+// testIssuer 테스트용으로 사용할 토큰 발행자 식별자
+const testIssuer = "https://issuer.example.com"
+
+// testSignedValue 테스트용 서명 함수가 반환할 서명값
+const testSignedValue = "test_signed_value"
+
+// captureSign 서명 요청된 JOSE 헤더 typ 값과 클레임을 저장하고 고정된 서명값을 반환하는 테스트용 서명 함수를 생성한다.
+func captureSign(typ *string, claims **Claims) Sign {
+	return func(t string, c any) (string, error) {
+		*typ = t
+		*claims = c.(*Claims)
+		return testSignedValue, nil
+	}
+}
+
+func TestJWTEncoder_Encode(t *testing.T) {
+	t.Run("토큰의 정보가 클레임에 올바르게 설정됨", func(t *testing.T) {
+		var typ string
+		var claims *Claims
+		encoder := JWTEncoder{Issuer: testIssuer, Sign: captureSign(&typ, &claims)}
+
+		accessToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+		accessToken.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+
+		err := accessToken.Encode(encoder.Encode)
+		assert.Nil(t, err)
+		assert.Equal(t, JWTTypeAccessToken, typ)
+		assert.Equal(t, testIssuer, claims.Issuer)
+		assert.Equal(t, testUsername, claims.Subject)
+		assert.Equal(t, []string{testClientID}, claims.Audience)
+		assert.Equal(t, testClientID, claims.ClientID)
+		assert.Equal(t, "scope_1 scope_2 scope_3", claims.Scope)
+		assert.Equal(t, testAccessTokenValue, claims.JTI)
+		assert.Equal(t, accessToken.Start().Unix(), claims.IssuedAt)
+		assert.Equal(t, accessToken.End().Unix(), claims.ExpiresAt)
+	})
+
+	t.Run("자원 소유자가 없는 경우 sub 클레임에 클라이언트 아이디가 설정됨", func(t *testing.T) {
+		var typ string
+		var claims *Claims
+		encoder := JWTEncoder{Issuer: testIssuer, Sign: captureSign(&typ, &claims)}
+
+		accessToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+		accessToken.ApplyResourceOwnerInfo("", testScopeArray)
+
+		_ = accessToken.Encode(encoder.Encode)
+		assert.Equal(t, testClientID, claims.Subject)
+	})
+
+	t.Run("대상 서비스와 행동 주체가 지정된 경우 aud, act 클레임에 설정됨", func(t *testing.T) {
+		var typ string
+		var claims *Claims
+		encoder := JWTEncoder{Issuer: testIssuer, Sign: captureSign(&typ, &claims)}
+
+		accessToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+		accessToken.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+		accessToken.SetAudience([]string{"https://api.example.com"})
+		accessToken.SetActor(&Actor{Subject: "actor"})
+
+		_ = accessToken.Encode(encoder.Encode)
+		assert.Equal(t, []string{"https://api.example.com"}, claims.Audience)
+		assert.Equal(t, &Actor{Subject: "actor"}, claims.Actor)
+	})
+
+	t.Run("자원 소유자의 인증 정보가 auth_time, acr, amr 클레임에 설정됨", func(t *testing.T) {
+		var typ string
+		var claims *Claims
+		encoder := JWTEncoder{Issuer: testIssuer, Sign: captureSign(&typ, &claims)}
+
+		authTime := time.Now().Add(-time.Minute)
+		accessToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+		accessToken.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+		accessToken.ApplyAuthentication(authTime, "urn:example:acr:mfa", []string{"pwd", "otp"})
+
+		_ = accessToken.Encode(encoder.Encode)
+		assert.Equal(t, authTime.Unix(), claims.AuthTime)
+		assert.Equal(t, "urn:example:acr:mfa", claims.ACR)
+		assert.Equal(t, []string{"pwd", "otp"}, claims.AMR)
+	})
+
+	t.Run("자원 소유자의 인증 정보가 없는 경우 auth_time 클레임이 설정되지 않음", func(t *testing.T) {
+		var typ string
+		var claims *Claims
+		encoder := JWTEncoder{Issuer: testIssuer, Sign: captureSign(&typ, &claims)}
+
+		accessToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+
+		_ = accessToken.Encode(encoder.Encode)
+		assert.Zero(t, claims.AuthTime)
+	})
+
+	t.Run("인코딩 후 토큰값은 서명값으로, 기존 토큰값은 jti로 설정됨", func(t *testing.T) {
+		var typ string
+		var claims *Claims
+		encoder := JWTEncoder{Issuer: testIssuer, Sign: captureSign(&typ, &claims)}
+
+		accessToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+
+		_ = accessToken.Encode(encoder.Encode)
+		assert.Equal(t, testSignedValue, accessToken.Value())
+		assert.Equal(t, testAccessTokenValue, accessToken.JTI())
+	})
+
+	t.Run("서명 실패시 토큰값이 변경되지 않음", func(t *testing.T) {
+		signErr := errors.New("sign error")
+		encoder := JWTEncoder{Issuer: testIssuer, Sign: func(string, any) (string, error) {
+			return "", signErr
+		}}
+
+		accessToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+
+		err := accessToken.Encode(encoder.Encode)
+		assert.ErrorIs(t, err, signErr)
+		assert.Equal(t, testAccessTokenValue, accessToken.Value())
+		assert.Equal(t, "", accessToken.JTI())
+	})
+}
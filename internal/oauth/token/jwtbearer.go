@@ -0,0 +1,227 @@
+package token
+
+import (
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/resource"
+	"oauth-server-go/internal/oauth/scope"
+	"oauth-server-go/pkg/array"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// jwtBearerLeeway JWT 어설션의 시간 관련 클레임(exp, nbf, iat) 검증시 허용하는 시간 오차
+const jwtBearerLeeway = time.Second * 30
+
+// SubjectMapping JWT 어설션의 주체(sub)를 인가 서버의 자원 소유자 아이디로 변환하는 규칙
+type SubjectMapping struct {
+	// pattern 주체 전체와 일치해야 하는 정규 표현식
+	pattern *regexp.Regexp
+
+	// username 자원 소유자 아이디 템플릿. pattern 의 캡처 그룹을 $1, ${name} 형식으로 참조 할 수 있다.
+	username string
+}
+
+// NewSubjectMapping 주체 변환 규칙을 생성한다. pattern 은 주체 전체와 일치하는 경우에만 적용된다.
+func NewSubjectMapping(pattern, username string) (*SubjectMapping, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid subject pattern(%s): %w", pattern, err)
+	}
+	return &SubjectMapping{pattern: re, username: username}, nil
+}
+
+// Map 주체가 규칙과 일치하는 경우 변환된 자원 소유자 아이디를 반환한다.
+func (m *SubjectMapping) Map(subject string) (string, bool) {
+	match := m.pattern.FindStringSubmatchIndex(subject)
+	if match == nil {
+		return "", false
+	}
+	return string(m.pattern.ExpandString(nil, m.username, subject, match)), true
+}
+
+// TrustedIssuer JWT 인가 승인 방식에서 어설션을 발급 할 수 있는 신뢰하는 발급자
+type TrustedIssuer struct {
+	issuer string
+
+	// jwks, jwksURI 발급자의 공개키 목록과 공개키 목록을 조회할 수 있는 URI 둘 중 하나만 설정한다.
+	jwks    *jose.JSONWebKeySet
+	jwksURI string
+
+	// mappings 주체 변환 규칙 목록. 순서대로 적용하며 일치하는 규칙이 없는 주체는 거부한다.
+	mappings []*SubjectMapping
+
+	// scopes 발급자의 어설션으로 부여 할 수 있는 스코프 목록
+	scopes []string
+}
+
+func NewTrustedIssuer(issuer string, jwks *jose.JSONWebKeySet, jwksURI string, mappings []*SubjectMapping, scopes []string) *TrustedIssuer {
+	return &TrustedIssuer{issuer: issuer, jwks: jwks, jwksURI: jwksURI, mappings: mappings, scopes: scopes}
+}
+
+func (i *TrustedIssuer) Issuer() string {
+	return i.issuer
+}
+
+func (i *TrustedIssuer) Scopes() []string {
+	return i.scopes
+}
+
+// Username 주체 변환 규칙을 순서대로 적용해 처음 일치하는 규칙으로 변환된 자원 소유자 아이디를 반환한다.
+func (i *TrustedIssuer) Username(subject string) (string, bool) {
+	for _, m := range i.mappings {
+		if username, ok := m.Map(subject); ok && username != "" {
+			return username, true
+		}
+	}
+	return "", false
+}
+
+// KeySet 발급자의 공개키 목록을 반환한다. jwks 가 없는 경우 jwks_uri 에서 조회한다.
+func (i *TrustedIssuer) KeySet(fetch func(uri string) (*jose.JSONWebKeySet, error)) (*jose.JSONWebKeySet, error) {
+	if i.jwks != nil {
+		return i.jwks, nil
+	}
+	if i.jwksURI == "" || fetch == nil {
+		return nil, fmt.Errorf("issuer(%s) has no public key", i.issuer)
+	}
+	return fetch(i.jwksURI)
+}
+
+// TrustedIssuers 발급자 식별자(iss)로 조회할 수 있는 신뢰하는 발급자 목록
+type TrustedIssuers map[string]*TrustedIssuer
+
+func NewTrustedIssuers(issuers ...*TrustedIssuer) TrustedIssuers {
+	r := make(TrustedIssuers, len(issuers))
+	for _, i := range issuers {
+		r[i.issuer] = i
+	}
+	return r
+}
+
+// Find 발급자 식별자로 신뢰하는 발급자를 조회한다.
+func (r TrustedIssuers) Find(issuer string) (*TrustedIssuer, bool) {
+	i, ok := r[issuer]
+	return i, ok
+}
+
+// JWTBearerGranter [RFC 7523] JWT 인가 승인 방식
+//
+// 신뢰하는 발급자가 서명한 JWT 어설션을 검증하고 어설션의 주체를 자원 소유자로 하는 엑세스 토큰을 발급한다.
+//
+// [RFC 7523]: https://datatracker.ietf.org/doc/html/rfc7523#section-2.1
+type JWTBearerGranter struct {
+	// AccessTokenGenerator 텍스트 형태의 랜덤 문자열로 토큰을 생성하는 함수
+	// 엑세스 토큰의 실제 토큰값을 생성하는데 사용한다.
+	AccessTokenGenerator GenerateToken
+
+	// TrustedIssuers 어설션을 발급 할 수 있는 신뢰하는 발급자 목록
+	TrustedIssuers TrustedIssuers
+
+	// FetchJWKS 발급자의 jwks_uri 에서 공개키 목록을 조회하는 함수
+	FetchJWKS func(uri string) (*jose.JSONWebKeySet, error)
+
+	// Audience 어설션의 aud 클레임에 포함되어야 하는 값 목록. 인가 서버 식별자나 토큰 엔드포인트 URL 중 하나를 포함해야 한다.
+	Audience []string
+
+	// RetrieveResourceServer 자원 지시자로 등록된 자원 서버를 조회하는 함수. nil 인 경우 자원 지시자를 지원하지 않는다.
+	RetrieveResourceServer resource.Retrieve
+}
+
+// GenerateToken JWT 어설션을 검증하고 새 엑세스 토큰을 발급한다. 리플레시 토큰은 항상 nil을 반환한다.
+//
+// 어설션은 신뢰하는 발급자의 공개키로 서명되어야 하며 aud 클레임은 인가 서버를 포함해야 하고 sub, exp 클레임은 필수이다.
+// 새 토큰의 스코프는 발급자와 클라이언트 모두에 허용된 스코프 이내여야 하며, 생략된 경우 둘 모두에 허용된 스코프를 부여한다.
+func (srv *JWTBearerGranter) GenerateToken(c *client.Client, request *Request) (*AccessToken, *RefreshToken, error) {
+	// 비공개 클라이언트만 JWT 어설션으로 토큰 발급 가능
+	if c.T() != client.TypeConfidential {
+		return nil, nil, fmt.Errorf("%w: public client", oautherr.ErrInvalidClient)
+	}
+
+	if request.Assertion == "" {
+		return nil, nil, fmt.Errorf("%w: assertion", oautherr.ErrMissingParameter)
+	}
+
+	issuer, claims, err := srv.verify(request.Assertion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	username, ok := issuer.Username(claims.Subject)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: subject(%s) is not mapped to resource owner", oautherr.ErrUnauthorized, claims.Subject)
+	}
+
+	scopes := scope.Split(request.Scope)
+	if len(scopes) == 0 {
+		scopes = array.FilterFunc(issuer.Scopes(), func(s string) bool {
+			return slices.Contains(c.Scopes(), s)
+		})
+	}
+	if !array.ContainsAll(issuer.Scopes(), scopes) || !array.ContainsAll(c.Scopes(), scopes) {
+		return nil, nil, oautherr.ErrInvalidScope
+	}
+
+	token := New(c, srv.AccessTokenGenerator)
+	token.ApplyResourceOwnerInfo(username, scopes)
+	if err := token.RestrictResources(srv.RetrieveResourceServer, request.Resource, nil); err != nil {
+		return nil, nil, err
+	}
+
+	return token, nil, nil
+}
+
+// verify 어설션의 발급자가 신뢰하는 발급자인지 확인하고 서명과 클레임을 검증한다.
+// 검증에 실패한 경우 [oautherr.ErrUnauthorized] 를 반환한다.
+func (srv *JWTBearerGranter) verify(assertion string) (*TrustedIssuer, *jwt.Claims, error) {
+	parsed, err := jwt.ParseSigned(assertion, client.SigningAlgorithms)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: assertion is malformed: %v", oautherr.ErrUnauthorized, err)
+	}
+
+	var unverified jwt.Claims
+	if err = parsed.UnsafeClaimsWithoutVerification(&unverified); err != nil {
+		return nil, nil, fmt.Errorf("%w: assertion is malformed: %v", oautherr.ErrUnauthorized, err)
+	}
+	issuer, ok := srv.TrustedIssuers.Find(unverified.Issuer)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: issuer(%s) is not trusted", oautherr.ErrUnauthorized, unverified.Issuer)
+	}
+
+	jwks, err := issuer.KeySet(srv.FetchJWKS)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", oautherr.ErrUnauthorized, err)
+	}
+	keys := jwks.Keys
+	if kid := parsed.Headers[0].KeyID; kid != "" {
+		keys = jwks.Key(kid)
+	}
+
+	var claims *jwt.Claims
+	for _, k := range keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var verified jwt.Claims
+		if err = parsed.Claims(k.Key, &verified); err == nil {
+			claims = &verified
+			break
+		}
+	}
+	if claims == nil {
+		return nil, nil, fmt.Errorf("%w: assertion signature could not be verified with issuer(%s) keys", oautherr.ErrUnauthorized, issuer.Issuer())
+	}
+
+	expected := jwt.Expected{Issuer: issuer.Issuer(), AnyAudience: srv.Audience, Time: time.Now()}
+	if err = claims.ValidateWithLeeway(expected, jwtBearerLeeway); err != nil {
+		return nil, nil, fmt.Errorf("%w: assertion is invalid: %v", oautherr.ErrUnauthorized, err)
+	}
+	if claims.Subject == "" || claims.Expiry == nil {
+		return nil, nil, fmt.Errorf("%w: assertion must contain sub and exp", oautherr.ErrUnauthorized)
+	}
+	return issuer, claims, nil
+}
@@ -0,0 +1,146 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"testing"
+	"time"
+)
+
+const (
+	// testTrustedIssuer 테스트용 신뢰하는 발급자 식별자
+	testTrustedIssuer = "https://idp.partner.example.com"
+
+	// testJWTBearerAudience 테스트용 인가 서버 토큰 엔드포인트
+	testJWTBearerAudience = "https://auth.example.com/oauth/auth/token"
+)
+
+// signTestJWTBearerAssertion 주어진 키로 테스트용 JWT 어설션을 서명한다.
+func signTestJWTBearerAssertion(t *testing.T, private *ecdsa.PrivateKey, claims jwt.Claims) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: private}, nil)
+	assert.NoError(t, err)
+
+	raw, err := jwt.Signed(signer).Claims(claims).Serialize()
+	assert.NoError(t, err)
+	return raw
+}
+
+func TestSubjectMapping_Map(t *testing.T) {
+	mapping, err := NewSubjectMapping(`svc-(?P<name>[a-z]+)@partner`, "partner-${name}")
+	assert.NoError(t, err)
+
+	t.Run("캡처 그룹으로 자원 소유자 아이디 변환", func(t *testing.T) {
+		username, ok := mapping.Map("svc-batch@partner")
+		assert.True(t, ok)
+		assert.Equal(t, "partner-batch", username)
+	})
+
+	t.Run("주체 일부만 일치하는 경우 변환하지 않음", func(t *testing.T) {
+		_, ok := mapping.Map("admin:svc-batch@partner")
+		assert.False(t, ok)
+	})
+
+	t.Run("잘못된 정규 표현식은 에러 발생", func(t *testing.T) {
+		_, err := NewSubjectMapping("(", "$1")
+		assert.Error(t, err)
+	})
+}
+
+func TestJWTBearerGrant_GenerateToken(t *testing.T) {
+	private, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	mapping, _ := NewSubjectMapping(`svc-(.+)`, "partner-$1")
+	issuer := NewTrustedIssuer(testTrustedIssuer, &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: private.Public()}}}, "",
+		[]*SubjectMapping{mapping}, []string{"scope_1", "scope_2", "partner_only"})
+
+	granter := JWTBearerGranter{
+		AccessTokenGenerator: generateTestAccessToken,
+		TrustedIssuers:       NewTrustedIssuers(issuer),
+		Audience:             []string{testJWTBearerAudience},
+	}
+	confidential := newClient(testClientID, client.TypeConfidential, testScopeArray)
+
+	validClaims := func() jwt.Claims {
+		return jwt.Claims{
+			Issuer:   testTrustedIssuer,
+			Subject:  "svc-batch",
+			Audience: jwt.Audience{testJWTBearerAudience},
+			Expiry:   jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		}
+	}
+
+	t.Run("신뢰하는 발급자의 어설션으로 토큰 발급", func(t *testing.T) {
+		request := &Request{Assertion: signTestJWTBearerAssertion(t, private, validClaims()), Scope: "scope_1"}
+
+		accessToken, refreshToken, err := granter.GenerateToken(confidential, request)
+
+		assert.NoError(t, err)
+		assert.Nil(t, refreshToken)
+		assert.Equal(t, "partner-batch", accessToken.Username())
+		assert.Equal(t, []string{"scope_1"}, accessToken.Scopes())
+	})
+
+	t.Run("스코프 생략시 발급자와 클라이언트 모두에 허용된 스코프 부여", func(t *testing.T) {
+		request := &Request{Assertion: signTestJWTBearerAssertion(t, private, validClaims())}
+
+		accessToken, _, err := granter.GenerateToken(confidential, request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"scope_1", "scope_2"}, accessToken.Scopes())
+	})
+
+	t.Run("공개 클라이언트는 ErrInvalidClient 발생", func(t *testing.T) {
+		request := &Request{Assertion: signTestJWTBearerAssertion(t, private, validClaims())}
+
+		_, _, err := granter.GenerateToken(newClient(testClientID, client.TypePublic, testScopeArray), request)
+		assert.ErrorIs(t, err, oautherr.ErrInvalidClient)
+	})
+
+	t.Run("어설션 누락시 ErrMissingParameter 발생", func(t *testing.T) {
+		_, _, err := granter.GenerateToken(confidential, &Request{})
+		assert.ErrorIs(t, err, oautherr.ErrMissingParameter)
+	})
+
+	t.Run("발급자나 클라이언트에 허용되지 않은 스코프 요청시 ErrInvalidScope 발생", func(t *testing.T) {
+		for _, s := range []string{"scope_3", "partner_only"} {
+			request := &Request{Assertion: signTestJWTBearerAssertion(t, private, validClaims()), Scope: s}
+
+			_, _, err := granter.GenerateToken(confidential, request)
+			assert.ErrorIs(t, err, oautherr.ErrInvalidScope)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		private *ecdsa.PrivateKey
+		edit    func(c *jwt.Claims)
+	}{
+		{name: "신뢰하지 않는 발급자", edit: func(c *jwt.Claims) { c.Issuer = "https://unknown.example.com" }},
+		{name: "발급자의 공개키로 검증되지 않는 서명", private: other},
+		{name: "aud 에 인가 서버가 없음", edit: func(c *jwt.Claims) { c.Audience = jwt.Audience{"https://other.example.com"} }},
+		{name: "만료된 어설션", edit: func(c *jwt.Claims) { c.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour)) }},
+		{name: "exp 가 없음", edit: func(c *jwt.Claims) { c.Expiry = nil }},
+		{name: "변환 규칙과 일치하지 않는 주체", edit: func(c *jwt.Claims) { c.Subject = "admin" }},
+	}
+	for _, tc := range tests {
+		t.Run("검증 실패시 ErrUnauthorized 발생/"+tc.name, func(t *testing.T) {
+			claims, key := validClaims(), private
+			if tc.edit != nil {
+				tc.edit(&claims)
+			}
+			if tc.private != nil {
+				key = tc.private
+			}
+
+			_, _, err := granter.GenerateToken(confidential, &Request{Assertion: signTestJWTBearerAssertion(t, key, claims)})
+			assert.ErrorIs(t, err, oautherr.ErrUnauthorized)
+		})
+	}
+}
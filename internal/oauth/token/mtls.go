@@ -0,0 +1,27 @@
+package token
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"oauth-server-go/internal/oauth/client"
+)
+
+// CertificateThumbprint [RFC 8705] 에 정의된 클라이언트 인증서의 SHA-256 Thumbprint(x5t#S256)를 반환한다.
+//
+// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-3.1
+func CertificateThumbprint(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// BindCertificate 발급된 토큰을 클라이언트 인증서에 바인딩한다.
+// 공개 클라이언트의 리플레시 토큰은 [RFC 8705] 에 따라 함께 바인딩한다.
+//
+// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-4
+func BindCertificate(x5t string, accessToken *AccessToken, refreshToken *RefreshToken) {
+	accessToken.confirm().X5TS256 = x5t
+	if refreshToken != nil && accessToken.Client().T() == client.TypePublic {
+		refreshToken.confirm().X5TS256 = x5t
+	}
+}
@@ -2,6 +2,7 @@ package token
 
 import (
 	"oauth-server-go/internal/oauth/authorization"
+	"oauth-server-go/internal/oauth/detail"
 	"oauth-server-go/internal/oauth/scope"
 )
 
@@ -28,6 +29,58 @@ const (
 	// GrantTypeRefreshToken 리플레시 토큰을 이용하여 토큰을 갱신하는 방식
 	// 엑세스 토큰이 만료된 경우 리플레시 토큰을 사용하여 새로운 엑세스 토큰을 발급 받는다.
 	GrantTypeRefreshToken GrantType = "refresh_token"
+
+	// GrantTypeDeviceCode [RFC 8628] 디바이스 인가 승인 방식
+	// 브라우저를 사용 할 수 없는 디바이스에서 디바이스 코드로 토큰 엔드포인트를 폴링하여 토큰을 발급 받는다.
+	//
+	// [RFC 8628]: https://datatracker.ietf.org/doc/html/rfc8628
+	GrantTypeDeviceCode GrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// GrantTypeTokenExchange [RFC 8693] 토큰 교환 방식
+	// 이미 발급된 토큰을 다른 대상 서비스에서 사용할 토큰으로 교환한다.
+	//
+	// [RFC 8693]: https://datatracker.ietf.org/doc/html/rfc8693
+	GrantTypeTokenExchange GrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	// GrantTypeJWTBearer [RFC 7523] JWT 인가 승인 방식
+	// 신뢰하는 발급자가 서명한 JWT 어설션으로 사용자의 개입 없이 토큰을 발급 받는다.
+	//
+	// [RFC 7523]: https://datatracker.ietf.org/doc/html/rfc7523#section-2.1
+	GrantTypeJWTBearer GrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+	// GrantTypeCIBA [OpenID CIBA] 백채널 인증 승인 방식
+	// 백채널 인증 요청으로 발급 받은 인증 요청 식별자로 자원 소유자가 인증 기기에서 승인한 토큰을 발급 받는다.
+	//
+	// [OpenID CIBA]: https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.10.1
+	GrantTypeCIBA GrantType = "urn:openid:params:grant-type:ciba"
+
+	// GrantTypeImplicit 암묵적 승인 방식
+	// 토큰 엔드포인트에서 사용되지 않으며 인가 서버 메타데이터 등에서 승인 방식을 표현할 때만 사용한다.
+	GrantTypeImplicit GrantType = "implicit"
+)
+
+// TokenEndpointGrantTypes 토큰 엔드포인트에서 요청 할 수 있는 모든 인가 방식
+var TokenEndpointGrantTypes = []GrantType{
+	GrantTypeAuthorizationCode,
+	GrantTypePassword,
+	GrantTypeClientCredentials,
+	GrantTypeRefreshToken,
+	GrantTypeDeviceCode,
+	GrantTypeTokenExchange,
+	GrantTypeJWTBearer,
+	GrantTypeCIBA,
+}
+
+// TokenTypeIdentifier [RFC 8693] 에 정의된 토큰 타입 식별자
+// 토큰 교환 방식에서 교환할 토큰과 발급 받을 토큰의 타입을 나타낸다.
+//
+// [RFC 8693]: https://datatracker.ietf.org/doc/html/rfc8693#section-3
+type TokenTypeIdentifier string
+
+const (
+	TokenTypeAccessToken  TokenTypeIdentifier = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeRefreshToken TokenTypeIdentifier = "urn:ietf:params:oauth:token-type:refresh_token"
+	TokenTypeJWT          TokenTypeIdentifier = "urn:ietf:params:oauth:token-type:jwt"
 )
 
 // Request OAuth2 토큰 발행 요청을 나타내는 구조체
@@ -60,6 +113,51 @@ type Request struct {
 	// 공백으로 구분된 문자열로 클라이언트가 접근하고자 하는 자원의 범위를 나타낸다.
 	// 생략시 클라이언트에 설정된 기본 범위가 사용된다.
 	Scope string `form:"scope"`
+
+	// DeviceCode 디바이스 인가 승인 방식에서 사용되는 디바이스 코드
+	// 디바이스 인가 요청으로 발급 받은 device_code를 포함해야 한다.
+	DeviceCode string `form:"device_code"`
+
+	// SubjectToken 토큰 교환 방식에서 교환할 토큰 이 토큰의 자원 소유자가 새 토큰의 자원 소유자가 된다.
+	SubjectToken string `form:"subject_token"`
+
+	// SubjectTokenType subject_token 의 토큰 타입 식별자
+	SubjectTokenType TokenTypeIdentifier `form:"subject_token_type"`
+
+	// ActorToken 토큰 교환 방식에서 자원 소유자를 대신하여 행동하는 주체의 토큰
+	// 입력된 경우 위임(delegation)으로, 생략된 경우 가장(impersonation)으로 처리한다.
+	ActorToken string `form:"actor_token"`
+
+	// ActorTokenType actor_token 의 토큰 타입 식별자 actor_token 이 입력된 경우 필수이다.
+	ActorTokenType TokenTypeIdentifier `form:"actor_token_type"`
+
+	// RequestedTokenType 발급 받을 토큰의 타입 식별자 생략시 엑세스 토큰을 발급한다.
+	RequestedTokenType TokenTypeIdentifier `form:"requested_token_type"`
+
+	// Audience 발급 받을 토큰을 사용할 대상 서비스의 논리적 이름
+	Audience []string `form:"audience"`
+
+	// Resource 발급 받을 토큰을 사용할 대상 서비스의 URI 절대 경로여야 하며 프래그먼트를 포함 할 수 없다.
+	Resource []string `form:"resource"`
+
+	// AuthorizationDetails 발급 받을 토큰에 부여할 인가 상세 정보의 JSON 배열 문자열
+	// 인가 코드나 리플레시 토큰으로 이미 부여된 인가 상세 정보가 있는 경우 그 이내로만 요청 할 수 있다.
+	AuthorizationDetails string `form:"authorization_details"`
+
+	// AuthReqID 백채널 인증 승인 방식에서 사용되는 인증 요청 식별자
+	// 백채널 인증 요청으로 발급 받은 auth_req_id를 포함해야 한다.
+	AuthReqID string `form:"auth_req_id"`
+
+	// Assertion JWT 인가 승인 방식에서 사용되는 신뢰하는 발급자가 서명한 JWT 어설션
+	Assertion string `form:"assertion"`
+
+	// DPoPJKT 토큰 요청과 함께 전달된 DPoP 증명 공개키의 JWK SHA-256 Thumbprint
+	// 요청 파라미터로 받지 않으며 DPoP 증명 검증이 완료된 후 설정되어 발급되는 토큰의 바인딩에 사용된다.
+	DPoPJKT string `form:"-"`
+
+	// CertificateThumbprint 토큰 요청의 TLS 연결에서 받은 클라이언트 인증서의 SHA-256 Thumbprint
+	// 요청 파라미터로 받지 않으며 클라이언트 인증서가 있는 경우 설정되어 발급되는 토큰의 바인딩에 사용된다.
+	CertificateThumbprint string `form:"-"`
 }
 
 // Response OAuth2 토큰 발행 응답
@@ -69,6 +167,13 @@ type Response struct {
 	ExpiresIn uint   `json:"expires_in,omitempty"`
 	Refresh   string `json:"refresh_token,omitempty"`
 	Scope     string `json:"scope,omitempty"`
+	IDToken   string `json:"id_token,omitempty"`
+
+	// IssuedTokenType 토큰 교환 방식에서 발급된 토큰의 타입 식별자
+	IssuedTokenType TokenTypeIdentifier `json:"issued_token_type,omitempty"`
+
+	// AuthorizationDetails 발급된 엑세스 토큰에 부여된 인가 상세 정보
+	AuthorizationDetails detail.Details `json:"authorization_details,omitempty"`
 }
 
 // InspectionRequest 토큰 질의 API에서 사용할 요청 폼
@@ -80,6 +185,15 @@ type InspectionRequest struct {
 	TokenTypeHint TypeHint `form:"token_type_hint"`
 }
 
+// RevocationRequest 토큰 폐기 API에서 사용할 요청 폼
+// [RFC 7009] 를 참고
+//
+// [RFC 7009]: https://datatracker.ietf.org/doc/html/rfc7009#section-2.1
+type RevocationRequest struct {
+	Token         string   `form:"token"`
+	TokenTypeHint TypeHint `form:"token_type_hint"`
+}
+
 // Inspection 토큰 질의 API의 응답 폼 [RFC 7662] 를 참고
 //
 // [RFC 7662]: https://datatracker.ietf.org/doc/html/rfc7662#section-2.2
@@ -90,20 +204,47 @@ type Inspection struct {
 	Username  string `json:"username,omitempty"`
 	TokenType Type   `json:"token_type,omitempty"`
 
-	ExpiresIn uint   `json:"exp,omitempty"`
-	IssuedAt  uint   `json:"iat,omitempty"`
-	NotBefore uint   `json:"nbf,omitempty"`
-	Subject   string `json:"sub,omitempty"`
-	Audience  string `json:"aud,omitempty"`
-	Issuer    string `json:"iss,omitempty"`
-	JTI       string `json:"jti,omitempty"`
+	ExpiresIn uint     `json:"exp,omitempty"`
+	IssuedAt  uint     `json:"iat,omitempty"`
+	NotBefore uint     `json:"nbf,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
+	Audience  []string `json:"aud,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	JTI       string   `json:"jti,omitempty"`
+
+	// Actor 토큰 교환으로 위임 받은 토큰의 경우 자원 소유자를 대신하여 행동하는 주체
+	Actor *Actor `json:"act,omitempty"`
+
+	// Confirmation 토큰이 바인딩된 소유 증명 키 정보
+	Confirmation *Confirmation `json:"cnf,omitempty"`
+
+	// AuthorizationDetails 토큰에 부여된 인가 상세 정보
+	AuthorizationDetails detail.Details `json:"authorization_details,omitempty"`
+
+	// AuthTime, ACR, AMR [RFC 9470] 토큰을 발급 받을 때 자원 소유자의 인증 정보
+	// 자원 서버는 이 값이 요구하는 인증 수준에 미치지 못하는 경우 insufficient_user_authentication 에러로 다시 인증을 요구한다.
+	//
+	// [RFC 9470]: https://datatracker.ietf.org/doc/html/rfc9470#section-6.2
+	AuthTime uint     `json:"auth_time,omitempty"`
+	ACR      string   `json:"acr,omitempty"`
+	AMR      []string `json:"amr,omitempty"`
 }
 
 func (i *Inspection) CopyFromAccessToken(token *AccessToken) {
 	i.Scope = scope.Join(token.Scopes())
 	i.ClientID = token.Client().Id()
 	i.Username = token.Username()
-	i.TokenType = TypeBearer // 현재는 Bearer만 지원
+	i.TokenType = token.Type()
+	i.JTI = token.JTI()
+	i.Audience = token.Audience()
+	i.Actor = token.Actor()
+	i.Confirmation = token.Confirmation()
+	i.AuthorizationDetails = token.AuthorizationDetails()
+	if !token.AuthTime().IsZero() {
+		i.AuthTime = uint(token.AuthTime().Unix())
+	}
+	i.ACR = token.ACR()
+	i.AMR = token.AMR()
 }
 
 func InspectAccessToken(token *AccessToken) *Inspection {
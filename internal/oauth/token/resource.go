@@ -0,0 +1,43 @@
+package token
+
+import (
+	"fmt"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/resource"
+	"oauth-server-go/pkg/array"
+)
+
+// RestrictResources [RFC 8707] 요청된 자원 지시자로 토큰의 대상(aud)과 스코프를 자원 서버로 제한한다.
+//
+// granted 는 인가 코드나 기존 토큰으로 이미 부여된 자원 지시자 목록으로, 있는 경우 요청된 자원은 granted 이내여야 하며
+// 요청된 자원이 없는 경우 granted 를 그대로 사용한다. 대상 자원이 없는 경우 토큰을 제한하지 않는다.
+// 토큰의 스코프는 대상 자원 서버들이 소유한 스코프로 축소된다.
+//
+// [RFC 8707]: https://datatracker.ietf.org/doc/html/rfc8707#section-2.2
+func (t *AccessToken) RestrictResources(retrieve resource.Retrieve, requested, granted []string) error {
+	resources := requested
+	if len(resources) == 0 {
+		resources = granted
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+	if len(granted) > 0 && !array.ContainsAll(granted, resources) {
+		return fmt.Errorf("%w: requested resource is not granted", oautherr.ErrInvalidTarget)
+	}
+	if retrieve == nil {
+		return fmt.Errorf("%w: resource indicators are not supported", oautherr.ErrInvalidTarget)
+	}
+
+	servers, err := resource.Resolve(retrieve, resources)
+	if err != nil {
+		return err
+	}
+	scopes, err := resource.Restrict(servers, t.scopes)
+	if err != nil {
+		return err
+	}
+	t.scopes = scopes
+	t.audience = resources
+	return nil
+}
@@ -0,0 +1,81 @@
+package token
+
+import (
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/oauth/client"
+	oautherr "oauth-server-go/internal/oauth/errors"
+	"oauth-server-go/internal/oauth/resource"
+	"testing"
+)
+
+// 자원 지시자 테스트에서 사용할 자원 서버
+var (
+	testAPIResource    = resource.New("https://api.example.com", "api", []string{"scope_1", "scope_2"})
+	testOrdersResource = resource.New("https://orders.example.com", "orders", []string{"scope_3"})
+)
+
+// retrieveTestResourceServer 테스트용 자원 서버 조회 함수
+// [resource.Retrieve] 함수의 구현체로 사용된다.
+func retrieveTestResourceServer(uri string) (*resource.Server, bool) {
+	for _, s := range []*resource.Server{testAPIResource, testOrdersResource} {
+		if s.URI() == uri {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+func TestAccessToken_RestrictResources(t *testing.T) {
+	newToken := func() *AccessToken {
+		accessToken := New(newClient(testClientID, client.TypeConfidential, testScopeArray), generateTestAccessToken)
+		accessToken.ApplyResourceOwnerInfo(testUsername, testScopeArray)
+		return accessToken
+	}
+
+	t.Run("요청된 자원 서버로 대상과 스코프 제한", func(t *testing.T) {
+		accessToken := newToken()
+
+		err := accessToken.RestrictResources(retrieveTestResourceServer, []string{"https://orders.example.com"}, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://orders.example.com"}, accessToken.Audience())
+		assert.Equal(t, []string{"scope_3"}, accessToken.Scopes())
+	})
+
+	t.Run("요청된 자원이 없는 경우 부여된 자원 사용", func(t *testing.T) {
+		accessToken := newToken()
+
+		err := accessToken.RestrictResources(retrieveTestResourceServer, nil, []string{"https://api.example.com"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://api.example.com"}, accessToken.Audience())
+		assert.Equal(t, []string{"scope_1", "scope_2"}, accessToken.Scopes())
+	})
+
+	t.Run("대상 자원이 없는 경우 제한하지 않음", func(t *testing.T) {
+		accessToken := newToken()
+
+		err := accessToken.RestrictResources(nil, nil, nil)
+
+		assert.NoError(t, err)
+		assert.Nil(t, accessToken.Audience())
+		assert.Equal(t, testScopeArray, accessToken.Scopes())
+	})
+
+	tests := []struct {
+		name      string
+		retrieve  resource.Retrieve
+		requested []string
+		granted   []string
+	}{
+		{name: "부여되지 않은 자원 요청", retrieve: retrieveTestResourceServer, requested: []string{"https://orders.example.com"}, granted: []string{"https://api.example.com"}},
+		{name: "등록되지 않은 자원 요청", retrieve: retrieveTestResourceServer, requested: []string{"https://unknown.example.com"}},
+		{name: "자원 지시자를 지원하지 않음", requested: []string{"https://api.example.com"}},
+	}
+	for _, tc := range tests {
+		t.Run("ErrInvalidTarget 발생/"+tc.name, func(t *testing.T) {
+			err := newToken().RestrictResources(tc.retrieve, tc.requested, tc.granted)
+			assert.ErrorIs(t, err, oautherr.ErrInvalidTarget)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package token
 import (
 	"oauth-server-go/internal/oauth/authorization"
 	"oauth-server-go/internal/oauth/client"
+	"oauth-server-go/internal/oauth/detail"
 	"oauth-server-go/pkg/period"
 	"time"
 )
@@ -11,12 +12,22 @@ import (
 // 10분으로 설정
 const tokenExpiresMinute = time.Minute * 10
 
+// MaxSignedLifetime 서명되어 발급되는 토큰(JWT)의 최대 유효기간
+// 서명키가 퇴역한 후에도 최소 이 기간 동안은 공개키 목록(JWKS)에 유지 되어야 한다.
+const MaxSignedLifetime = tokenExpiresMinute
+
 // GenerateToken 토큰 텍스트 생성 함수
 //
 // 이 함수로 생성된 문자열이 실제 토큰값으로 사용된다.
 // 호출시 토큰 소유자의 정보를 유추 할 수 없도록 랜덤한 문자열을 반환해야 한다.
 type GenerateToken func() string
 
+// EncodeToken 엑세스 토큰의 정보(클레임)를 이용하여 토큰값을 생성하는 함수
+//
+// [GenerateToken] 과 달리 토큰의 소유자, 클라이언트, 스코프 등이 모두 설정된 이후에 호출되며
+// JWT 등 토큰 자체에 정보를 담는 형식의 토큰값을 생성하는데 사용된다.
+type EncodeToken func(t *AccessToken) (string, error)
+
 // Type 엑세스 토큰 타입 자세한 사항은 [RFC 6749] 를 참고
 //
 // [RFC 6749]: https://datatracker.ietf.org/doc/html/rfc6749#section-7.1
@@ -25,6 +36,11 @@ type Type string
 const (
 	TypeBearer Type = "bearer"
 	TypeMAC    Type = "mac"
+
+	// TypeDPoP [RFC 9449] DPoP 증명의 공개키에 바인딩된 토큰
+	//
+	// [RFC 9449]: https://datatracker.ietf.org/doc/html/rfc9449#section-5
+	TypeDPoP Type = "DPoP"
 )
 
 // TypeHint 토큰 정보 질의시 질의할 토큰의 타입
@@ -38,12 +54,31 @@ const (
 	TypeHintRefreshToken TypeHint = "refresh_token"
 )
 
+// Confirmation [RFC 7800] 에 정의된 토큰의 소유 증명 키 정보(cnf 클레임)
+//
+// [RFC 7800]: https://datatracker.ietf.org/doc/html/rfc7800#section-3.1
+type Confirmation struct {
+	// JKT [RFC 9449] 토큰이 바인딩된 DPoP 공개키의 JWK SHA-256 Thumbprint
+	//
+	// [RFC 9449]: https://datatracker.ietf.org/doc/html/rfc9449#section-6.1
+	JKT string `json:"jkt,omitempty"`
+
+	// X5TS256 [RFC 8705] 토큰이 바인딩된 클라이언트 인증서의 SHA-256 Thumbprint
+	//
+	// [RFC 8705]: https://datatracker.ietf.org/doc/html/rfc8705#section-3.1
+	X5TS256 string `json:"x5t#S256,omitempty"`
+}
+
 // AccessToken OAuth2 액세스 토큰
 type AccessToken struct {
 	// value 토큰의 실제 값. 토큰 소유자의 정보등을 유추할 수 없도록 아무런 의미가 없는 랜덤한 값이어야 한다.
 	// 이 값은 API 요청 시 인증 수단으로 사용된다.
 	value string
 
+	// jti 토큰의 고유 식별자
+	// 토큰값이 [EncodeToken] 으로 인코딩 된 경우 인코딩 전의 랜덤한 토큰값이 저장된다.
+	jti string
+
 	// client 토큰을 발급한 클라이언트
 	client *client.Client
 
@@ -53,6 +88,29 @@ type AccessToken struct {
 	// scopes 할당된 스코프
 	scopes []string
 
+	// idToken 엑세스 토큰과 함께 발급된 ID 토큰. openid 스코프가 부여되지 않은 경우 nil 이다.
+	idToken *IDToken
+
+	// audience 토큰을 사용할 대상 서비스 지정되지 않은 경우 토큰을 발급 받은 클라이언트가 대상이 된다.
+	audience []string
+
+	// actor 토큰 교환으로 위임 받은 경우 자원 소유자를 대신하여 행동하는 주체. 위임 받지 않은 경우 nil 이다.
+	actor *Actor
+
+	// confirmation 토큰이 바인딩된 소유 증명 키 정보. 바인딩 되지 않은 Bearer 토큰의 경우 nil 이다.
+	confirmation *Confirmation
+
+	// authorizationDetails 토큰에 부여된 인가 상세 정보. 부여되지 않은 경우 nil 이다.
+	authorizationDetails detail.Details
+
+	// authTime, acr, amr [RFC 9470] 토큰을 발급 받을 때 자원 소유자가 인증한 시간과 인증 컨텍스트 클래스, 인증 방식
+	// 자원 서버는 이 값으로 자원 소유자의 인증이 충분한지 확인한다. 자원 소유자의 인증 없이 발급된 경우 비어 있다.
+	//
+	// [RFC 9470]: https://datatracker.ietf.org/doc/html/rfc9470#section-6
+	authTime time.Time
+	acr      string
+	amr      []string
+
 	period.Range
 }
 
@@ -76,6 +134,14 @@ func (t *AccessToken) Value() string {
 	return t.value
 }
 
+func (t *AccessToken) JTI() string {
+	return t.jti
+}
+
+func (t *AccessToken) SetJTI(jti string) {
+	t.jti = jti
+}
+
 func (t *AccessToken) Client() *client.Client {
 	return t.client
 }
@@ -88,9 +154,96 @@ func (t *AccessToken) Scopes() []string {
 	return t.scopes
 }
 
+func (t *AccessToken) IDToken() *IDToken {
+	return t.idToken
+}
+
+func (t *AccessToken) SetIDToken(idToken *IDToken) {
+	t.idToken = idToken
+}
+
+func (t *AccessToken) Audience() []string {
+	return t.audience
+}
+
+func (t *AccessToken) SetAudience(audience []string) {
+	t.audience = audience
+}
+
+func (t *AccessToken) Actor() *Actor {
+	return t.actor
+}
+
+func (t *AccessToken) SetActor(actor *Actor) {
+	t.actor = actor
+}
+
+func (t *AccessToken) Confirmation() *Confirmation {
+	return t.confirmation
+}
+
+func (t *AccessToken) SetConfirmation(cnf *Confirmation) {
+	t.confirmation = cnf
+}
+
+func (t *AccessToken) AuthorizationDetails() detail.Details {
+	return t.authorizationDetails
+}
+
+func (t *AccessToken) SetAuthorizationDetails(details detail.Details) {
+	t.authorizationDetails = details
+}
+
+func (t *AccessToken) AuthTime() time.Time {
+	return t.authTime
+}
+
+func (t *AccessToken) ACR() string {
+	return t.acr
+}
+
+func (t *AccessToken) AMR() []string {
+	return t.amr
+}
+
+// ApplyAuthentication 자원 소유자가 인증한 시간과 인증 컨텍스트 클래스(acr), 인증 방식(amr)을 설정한다.
+func (t *AccessToken) ApplyAuthentication(authTime time.Time, acr string, amr []string) {
+	t.authTime = authTime
+	t.acr = acr
+	t.amr = amr
+}
+
+// confirm 토큰의 소유 증명 키 정보를 반환한다. 바인딩 되지 않은 경우 새로 생성하여 설정한다.
+func (t *AccessToken) confirm() *Confirmation {
+	if t.confirmation == nil {
+		t.confirmation = &Confirmation{}
+	}
+	return t.confirmation
+}
+
+// Type 토큰의 타입을 반환한다.
+// DPoP 공개키에 바인딩된 토큰은 [TypeDPoP] 를, 그 외에는 [TypeBearer] 를 반환한다.
+func (t *AccessToken) Type() Type {
+	if t.confirmation != nil && t.confirmation.JKT != "" {
+		return TypeDPoP
+	}
+	return TypeBearer
+}
+
+// Subject 토큰의 주체를 반환한다.
+// 자원 소유자가 없는 토큰(클라이언트 자격 증명 방식 등)의 경우 클라이언트 아이디를 반환한다.
+func (t *AccessToken) Subject() string {
+	if t.username == "" {
+		return t.client.Id()
+	}
+	return t.username
+}
+
 func (t *AccessToken) ApplyAuthorizationCode(code *authorization.Code) {
 	t.username = code.Username()
 	t.scopes = code.Scopes()
+	t.authorizationDetails = code.AuthorizationDetails()
+	t.ApplyAuthentication(code.AuthTime(), code.ACR(), code.AMR())
 }
 
 func (t *AccessToken) ApplyResourceOwnerInfo(username string, scopes []string) {
@@ -98,6 +251,29 @@ func (t *AccessToken) ApplyResourceOwnerInfo(username string, scopes []string) {
 	t.scopes = scopes
 }
 
+// Encode 인자로 받은 함수를 이용하여 토큰값을 새로 생성하고 기존 토큰값을 대체한다.
+// 기존 토큰값은 토큰의 고유 식별자(jti)로 사용된다.
+func (t *AccessToken) Encode(encoder EncodeToken) error {
+	encoded, err := encoder(t)
+	if err != nil {
+		return err
+	}
+	t.jti = t.value
+	t.value = encoded
+	return nil
+}
+
+// Actor [RFC 8693] 에 정의된 위임 받은 주체(act 클레임)
+//
+// 중첩된 Actor 는 이전에 위임 받았던 주체를 나타내며 가장 바깥의 Actor 가 현재 행동하는 주체이다.
+//
+// [RFC 8693]: https://datatracker.ietf.org/doc/html/rfc8693#section-4.1
+type Actor struct {
+	Subject  string `json:"sub"`
+	ClientID string `json:"client_id,omitempty"`
+	Actor    *Actor `json:"act,omitempty"`
+}
+
 // refreshExpiresMinute OAuth2 리프레시 토큰 만료
 // 7일로 설정
 const refreshExpiresMinute = time.Hour * 24 * 7
@@ -110,22 +286,38 @@ type RefreshToken struct {
 	// token 리플래시 토큰 사용시 재생성할 액세스 토큰
 	token *AccessToken
 
+	// confirmation 리플레시 토큰이 바인딩된 소유 증명 키 정보. 바인딩 되지 않은 경우 nil 이다.
+	confirmation *Confirmation
+
+	// scopes, resources 리플레시 토큰으로 부여 할 수 있는 스코프와 자원 지시자 목록
+	// 엑세스 토큰은 요청한 자원 지시자로 축소 될 수 있으므로 처음 부여된 범위를 따로 저장하여 재발급시 이 범위 이내로 제한한다.
+	scopes    []string
+	resources []string
+
+	// authorizationDetails [RFC 9396] 리플레시 토큰으로 부여 할 수 있는 인가 상세 정보 목록
+	// 엑세스 토큰은 요청한 인가 상세 정보로 축소 될 수 있으므로 처음 부여된 인가 상세 정보를 따로 저장하여 재발급시 이 범위 이내로 제한한다.
+	//
+	// [RFC 9396]: https://datatracker.ietf.org/doc/html/rfc9396#section-6.2
+	authorizationDetails detail.Details
+
 	period.Range
 }
 
+// NewRefreshToken 새 리플레시 토큰을 생성한다.
+// 부여 할 수 있는 스코프와 자원 지시자, 인가 상세 정보는 엑세스 토큰의 스코프와 대상(aud), 인가 상세 정보로 초기화 된다.
 func NewRefreshToken(token *AccessToken, g GenerateToken) *RefreshToken {
-	return &RefreshToken{
-		value: g(),
-		token: token,
-		Range: period.New(refreshExpiresMinute),
-	}
+	return NewRefreshTokenWithRange(token, g, period.New(refreshExpiresMinute))
 }
 
 func NewRefreshTokenWithRange(token *AccessToken, g GenerateToken, r period.Range) *RefreshToken {
 	return &RefreshToken{
-		value: g(),
-		token: token,
-		Range: r,
+		value:     g(),
+		token:     token,
+		scopes:    token.Scopes(),
+		resources: token.Audience(),
+		Range:     r,
+
+		authorizationDetails: token.AuthorizationDetails(),
 	}
 }
 
@@ -136,3 +328,48 @@ func (t *RefreshToken) Value() string {
 func (t *RefreshToken) Token() *AccessToken {
 	return t.token
 }
+
+func (t *RefreshToken) Confirmation() *Confirmation {
+	return t.confirmation
+}
+
+// Scopes 리플레시 토큰으로 부여 할 수 있는 스코프 목록을 반환한다.
+func (t *RefreshToken) Scopes() []string {
+	return t.scopes
+}
+
+func (t *RefreshToken) SetScopes(scopes []string) {
+	t.scopes = scopes
+}
+
+// Resources [RFC 8707] 리플레시 토큰으로 부여 할 수 있는 자원 지시자 목록을 반환한다.
+//
+// [RFC 8707]: https://datatracker.ietf.org/doc/html/rfc8707#section-2.2
+func (t *RefreshToken) Resources() []string {
+	return t.resources
+}
+
+func (t *RefreshToken) SetResources(resources []string) {
+	t.resources = resources
+}
+
+// AuthorizationDetails 리플레시 토큰으로 부여 할 수 있는 인가 상세 정보 목록을 반환한다.
+func (t *RefreshToken) AuthorizationDetails() detail.Details {
+	return t.authorizationDetails
+}
+
+func (t *RefreshToken) SetAuthorizationDetails(details detail.Details) {
+	t.authorizationDetails = details
+}
+
+func (t *RefreshToken) SetConfirmation(cnf *Confirmation) {
+	t.confirmation = cnf
+}
+
+// confirm 리플레시 토큰의 소유 증명 키 정보를 반환한다. 바인딩 되지 않은 경우 새로 생성하여 설정한다.
+func (t *RefreshToken) confirm() *Confirmation {
+	if t.confirmation == nil {
+		t.confirmation = &Confirmation{}
+	}
+	return t.confirmation
+}
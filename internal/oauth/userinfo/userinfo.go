@@ -0,0 +1,48 @@
+// Package userinfo 는 [OpenID Connect UserInfo] 응답에 포함될 클레임을 정의한다.
+//
+// [OpenID Connect UserInfo]: https://openid.net/specs/openid-connect-core-1_0.html#UserInfo
+package userinfo
+
+import (
+	"oauth-server-go/internal/pkg/auth"
+	"slices"
+)
+
+// [OpenID Connect] 에서 정의하는 클레임 요청 스코프
+//
+// [OpenID Connect]: https://openid.net/specs/openid-connect-core-1_0.html#ScopeClaims
+const (
+	// ScopeProfile name, preferred_username 클레임에 대한 접근을 요청한다.
+	ScopeProfile = "profile"
+
+	// ScopeEmail email, email_verified 클레임에 대한 접근을 요청한다.
+	ScopeEmail = "email"
+)
+
+// ClaimNames [Claims] 에 포함될 수 있는 모든 클레임 이름
+var ClaimNames = []string{"sub", "name", "preferred_username", "email", "email_verified"}
+
+// Claims UserInfo 엔드포인트에서 반환할 사용자 클레임
+type Claims struct {
+	Subject           string `json:"sub"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"`
+}
+
+// New 사용자 프로필에서 엑세스 토큰에 부여된 스코프로 접근 가능한 클레임만 선택하여 반환한다.
+// sub 클레임은 스코프와 관계 없이 항상 포함된다.
+func New(p *auth.Profile, scopes []string) *Claims {
+	claims := &Claims{Subject: p.Username}
+	if slices.Contains(scopes, ScopeProfile) {
+		claims.Name = p.Name
+		claims.PreferredUsername = p.Username
+	}
+	if slices.Contains(scopes, ScopeEmail) && p.Email != "" {
+		verified := p.EmailVerified
+		claims.Email = p.Email
+		claims.EmailVerified = &verified
+	}
+	return claims
+}
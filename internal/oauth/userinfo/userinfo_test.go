@@ -0,0 +1,51 @@
+package userinfo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"oauth-server-go/internal/pkg/auth"
+	"testing"
+)
+
+var testProfile = &auth.Profile{
+	Username:      "test_username",
+	Name:          "test_name",
+	Email:         "test@example.com",
+	EmailVerified: true,
+}
+
+func TestNew(t *testing.T) {
+	verified := true
+	tests := []struct {
+		name   string
+		scopes []string
+		except *Claims
+	}{
+		{
+			name:   "openid 스코프만 있을 경우 sub 클레임만 반환",
+			scopes: []string{"openid"},
+			except: &Claims{Subject: "test_username"},
+		},
+		{
+			name:   "profile 스코프가 있을 경우 이름 클레임 반환",
+			scopes: []string{"openid", ScopeProfile},
+			except: &Claims{Subject: "test_username", Name: "test_name", PreferredUsername: "test_username"},
+		},
+		{
+			name:   "email 스코프가 있을 경우 이메일 클레임 반환",
+			scopes: []string{"openid", ScopeEmail},
+			except: &Claims{Subject: "test_username", Email: "test@example.com", EmailVerified: &verified},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.except, New(testProfile, tc.scopes))
+		})
+	}
+
+	t.Run("이메일이 없을 경우 이메일 클레임을 반환하지 않음", func(t *testing.T) {
+		claims := New(&auth.Profile{Username: "test_username"}, []string{"openid", ScopeEmail})
+		assert.Equal(t, "", claims.Email)
+		assert.Nil(t, claims.EmailVerified)
+	})
+}
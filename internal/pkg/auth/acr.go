@@ -0,0 +1,57 @@
+package auth
+
+import "slices"
+
+// [RFC 8176] 에 정의된 인증 방식 참조값(amr)
+//
+// [RFC 8176]: https://datatracker.ietf.org/doc/html/rfc8176#section-2
+const (
+	// MethodPassword 패스워드 인증
+	MethodPassword = "pwd"
+
+	// MethodOTP 일회용 비밀번호(TOTP) 인증
+	MethodOTP = "otp"
+
+	// MethodMultiFactor 두 가지 이상의 인증 요소를 사용한 인증
+	MethodMultiFactor = "mfa"
+)
+
+// 인증 서버에서 사용하는 인증 컨텍스트 클래스 참조값(acr)
+const (
+	// ACRPassword 패스워드만 사용하여 인증함
+	ACRPassword = "urn:oauth-server-go:acr:password"
+
+	// ACRMultiFactor 패스워드와 일회용 비밀번호로 다중 요소 인증을 함
+	ACRMultiFactor = "urn:oauth-server-go:acr:mfa"
+)
+
+// ACRValues 지원하는 모든 인증 컨텍스트 클래스 참조값. 뒤에 위치할수록 보증 수준이 높다.
+var ACRValues = []string{ACRPassword, ACRMultiFactor}
+
+// ContextClass 사용한 인증 방식(amr) 목록으로 인증 컨텍스트 클래스(acr)를 결정한다.
+func ContextClass(amr []string) string {
+	if slices.Contains(amr, MethodMultiFactor) {
+		return ACRMultiFactor
+	}
+	return ACRPassword
+}
+
+// SatisfyACR 인증 컨텍스트 클래스(acr)가 요청된 인증 컨텍스트 클래스 중 하나 이상의 보증 수준을 만족하는지 확인한다.
+//
+// 보증 수준이 더 높은 인증 컨텍스트 클래스는 낮은 인증 컨텍스트 클래스를 만족한다.
+// 지원하지 않는 값은 무시하며 요청된 값 중 지원하는 값이 없는 경우 만족하는 것으로 본다.
+func SatisfyACR(acr string, requested []string) bool {
+	level := slices.Index(ACRValues, acr)
+	supported := false
+	for _, r := range requested {
+		required := slices.Index(ACRValues, r)
+		if required < 0 {
+			continue
+		}
+		if level >= required {
+			return true
+		}
+		supported = true
+	}
+	return !supported
+}
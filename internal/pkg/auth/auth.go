@@ -3,3 +3,14 @@ package auth
 // SimpleAuthenticate 사용자의 아이디와 패스워드를 받아 로그인을 실행한다.
 // 로그인이 성공하였을 경우 true를 반환한다.
 type SimpleAuthenticate func(id, pw string) (bool, error)
+
+// Profile 사용자의 프로필 정보
+type Profile struct {
+	Username      string
+	Name          string
+	Email         string
+	EmailVerified bool
+}
+
+// RetrieveProfile 사용자의 아이디를 받아 프로필 정보를 조회한다.
+type RetrieveProfile func(username string) (*Profile, error)
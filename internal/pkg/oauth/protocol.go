@@ -209,12 +209,24 @@ type (
 		Username  string    `json:"username,omitempty"`
 		TokenType TokenType `json:"token_type,omitempty"`
 
-		ExpiresIn uint   `json:"exp,omitempty"`
-		IssuedAt  uint   `json:"iat,omitempty"`
-		NotBefore uint   `json:"nbf,omitempty"`
-		Subject   string `json:"sub,omitempty"`
-		Audience  string `json:"aud,omitempty"`
-		Issuer    string `json:"iss,omitempty"`
-		JTI       string `json:"jti,omitempty"`
+		ExpiresIn uint     `json:"exp,omitempty"`
+		IssuedAt  uint     `json:"iat,omitempty"`
+		NotBefore uint     `json:"nbf,omitempty"`
+		Subject   string   `json:"sub,omitempty"`
+		Audience  []string `json:"aud,omitempty"`
+		Issuer    string   `json:"iss,omitempty"`
+		JTI       string   `json:"jti,omitempty"`
+
+		// Actor 토큰 교환으로 위임 받은 토큰의 경우 자원 소유자를 대신하여 행동하는 주체
+		Actor *Actor `json:"act,omitempty"`
+	}
+
+	// Actor [RFC 8693] 에 정의된 위임 받은 주체(act 클레임)
+	//
+	// [RFC 8693]: https://datatracker.ietf.org/doc/html/rfc8693#section-4.1
+	Actor struct {
+		Subject  string `json:"sub"`
+		ClientID string `json:"client_id,omitempty"`
+		Actor    *Actor `json:"act,omitempty"`
 	}
 )
@@ -5,6 +5,7 @@ import (
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"time"
 )
 
 // gin 컨텍스트에 등록할 키 상수
@@ -18,6 +19,15 @@ const (
 // 요청자의 인증 정보를 저장한다.
 type Authentication struct {
 	Username string
+
+	// AuthTime 요청자가 로그인 한 시간
+	AuthTime time.Time
+
+	// ACR 로그인의 인증 컨텍스트 클래스 참조값
+	ACR string
+
+	// AMR 로그인에 사용한 인증 방식 참조값 목록
+	AMR []string
 }
 
 // Authorization 인자로 받은 컨텍스트의 세션에 인증 정보를 저장한다.
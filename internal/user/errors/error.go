@@ -14,4 +14,10 @@ var (
 
 	// ErrAccountLocked 계정이 잠김 상태임
 	ErrAccountLocked = errors.New("account is locked")
+
+	// ErrOTPNotMatched 일회용 비밀번호가 일치하지 않거나 일회용 비밀번호를 등록하지 않은 계정임
+	ErrOTPNotMatched = errors.New("otp does not match")
+
+	// ErrOTPAttemptsExceeded 일회용 비밀번호 인증 실패 횟수를 초과하여 일시적으로 일회용 비밀번호로 인증 할 수 없음
+	ErrOTPAttemptsExceeded = errors.New("otp attempts exceeded")
 )
@@ -4,10 +4,14 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"net/url"
 	"oauth-server-go/internal/config/log"
+	pkgauth "oauth-server-go/internal/pkg/auth"
 	"oauth-server-go/internal/pkg/web"
 	usererr "oauth-server-go/internal/user/errors"
 	"oauth-server-go/internal/user/service"
+	"strings"
+	"time"
 )
 
 // AuthenticationManager 인증 프로세스 제공 인터페이스
@@ -40,7 +44,12 @@ func (h *API) Auth(c *gin.Context) error {
 		return wrap(err)
 	}
 
-	auth := web.Authentication{Username: principal.Username}
+	auth := web.Authentication{
+		Username: principal.Username,
+		AuthTime: time.Now(),
+		ACR:      pkgauth.ContextClass(principal.Methods),
+		AMR:      principal.Methods,
+	}
 	if err = web.Authorization(c, &auth); err != nil {
 		return wrap(err)
 	}
@@ -49,6 +58,9 @@ func (h *API) Auth(c *gin.Context) error {
 	return nil
 }
 
+// defaultReturnTo 로그인 완료 후 이동할 경로가 입력되지 않았을 때 이동할 경로
+const defaultReturnTo = "/oauth/manage/tokens"
+
 // Static 회원에 관련된 HTTP 정적 요청을 처리하는 함수를 모아둔 핸들러 인스턴스
 type Static struct {
 }
@@ -59,11 +71,35 @@ func NewStatic() *Static {
 }
 
 // LoginPage `gin.Context`를 이용해 사용자에게 보여줄 로그인 페이지를 지정한다.
+//
+// Parameters(query):
+//   - return_to: 로그인 완료 후 이동할 경로. 다른 사이트로 이동하지 않도록 서버 내부 경로만 사용하며 생략시 토큰 관리 페이지로 이동한다.
+//   - login_hint: 아이디 입력란에 미리 입력할 아이디
 func (h *Static) LoginPage(c *gin.Context) error {
-	c.HTML(http.StatusOK, "login.html", nil)
+	c.HTML(http.StatusOK, "login.html", gin.H{
+		"returnTo":  localReturnTo(c.Query("return_to")),
+		"loginHint": c.Query("login_hint"),
+	})
 	return nil
 }
 
+// localReturnTo 로그인 완료 후 이동할 경로가 서버 내부 경로인 경우 그대로 반환하고, 아닌 경우 [defaultReturnTo] 를 반환한다.
+//
+// 브라우저는 URL의 탭, 개행 등 제어 문자를 제거하고 역슬래시(\)를 슬래시로 취급하므로("/\t/evil.com" -> "//evil.com")
+// 제어 문자나 역슬래시를 포함한 경로는 허용하지 않는다.
+func localReturnTo(returnTo string) string {
+	if !strings.HasPrefix(returnTo, "/") || strings.ContainsFunc(returnTo, func(r rune) bool {
+		return r < 0x20 || r == 0x7f || r == '\\'
+	}) {
+		return defaultReturnTo
+	}
+	u, err := url.Parse(returnTo)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return defaultReturnTo
+	}
+	return returnTo
+}
+
 // wrap 인자로 받은 err을 사전에 정의된 에러로 랩핑한다.
 func wrap(err error) error {
 	if errors.Is(err, usererr.ErrRequireParamsMissing) {
@@ -72,6 +108,10 @@ func wrap(err error) error {
 		return web.Wrap(err, web.ErrCodeBadRequest, "id/password is not matched")
 	} else if errors.Is(err, usererr.ErrAccountLocked) {
 		return web.Wrap(err, web.ErrCodeBadRequest, "account is locked")
+	} else if errors.Is(err, usererr.ErrOTPNotMatched) {
+		return web.Wrap(err, web.ErrCodeBadRequest, "otp is not matched")
+	} else if errors.Is(err, usererr.ErrOTPAttemptsExceeded) {
+		return web.Wrap(err, web.ErrCodeBadRequest, "too many otp attempts")
 	} else {
 		log.Sugared().Error(err)
 		return web.Wrap(err, web.ErrCodeUnknown, "internal server codes")
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLocalReturnTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		returnTo string
+		expected string
+	}{
+		{name: "서버 내부 경로", returnTo: "/oauth/authorize?client_id=client&state=a%2Fb", expected: "/oauth/authorize?client_id=client&state=a%2Fb"},
+		{name: "입력 되지 않음", returnTo: "", expected: defaultReturnTo},
+		{name: "상대 경로", returnTo: "oauth/authorize", expected: defaultReturnTo},
+		{name: "절대 URL", returnTo: "https://evil.com", expected: defaultReturnTo},
+		{name: "프로토콜 상대 URL", returnTo: "//evil.com", expected: defaultReturnTo},
+		{name: "역슬래시 포함", returnTo: "/\\evil.com", expected: defaultReturnTo},
+		{name: "탭 문자 포함", returnTo: "/\t/evil.com", expected: defaultReturnTo},
+		{name: "개행 문자 포함", returnTo: "/\n/evil.com", expected: defaultReturnTo},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, localReturnTo(tc.returnTo))
+		})
+	}
+}
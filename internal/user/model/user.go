@@ -14,9 +14,24 @@ type Account struct {
 	ID            uint
 	Username      string
 	Password      string
+	Name          string
+	Email         string
+	EmailVerified bool
 	Active        bool
 	ActiveToken   *VerificationToken `gorm:"embedded;embeddedPrefix:active"`
 	PasswordToken *VerificationToken `gorm:"embedded;embeddedPrefix:password"`
+
+	// OTPSecret 인증 앱에 등록한 TOTP 비밀키(Base32). 등록하지 않은 경우 nil 이며 일회용 비밀번호로 인증 할 수 없다.
+	OTPSecret *string `gorm:"column:otp_secret"`
+
+	// OTPCounter 마지막으로 인증에 성공한 일회용 비밀번호의 주기 카운터. 인증에 성공한 적이 없는 경우 nil 이다.
+	OTPCounter *int64 `gorm:"column:otp_counter"`
+
+	// OTPFailedCount 일회용 비밀번호 인증에 연속으로 실패한 횟수. 인증에 성공하면 초기화 된다.
+	OTPFailedCount int `gorm:"column:otp_failed_count"`
+
+	// OTPFailedAt 마지막으로 일회용 비밀번호 인증에 실패한 시각
+	OTPFailedAt sql.NullTime `gorm:"column:otp_failed_at"`
 }
 
 func (a Account) TableName() string {
@@ -6,6 +6,7 @@ import (
 	"gorm.io/gorm"
 	usererr "oauth-server-go/internal/user/errors"
 	"oauth-server-go/internal/user/model"
+	"time"
 )
 
 // Gorm gorm을 이용한 저장소
@@ -27,3 +28,23 @@ func (g *Gorm) FindByUsername(u string) (*model.Account, error) {
 	}
 	return &account, nil
 }
+
+// AcceptOTP 인자로 받은 주기 카운터가 마지막으로 인증에 성공한 주기 카운터 보다 큰 경우에만 카운터를 저장하고 실패 횟수를 초기화한다.
+// 동시에 같은 일회용 비밀번호로 인증 하는 경우 하나의 요청만 성공 할 수 있도록 조건부로 갱신하며, 갱신 여부를 반환한다.
+func (g *Gorm) AcceptOTP(id uint, counter int64) (bool, error) {
+	result := g.db.Model(&model.Account{}).
+		Where("id = ? and (otp_counter is null or otp_counter < ?)", id, counter).
+		Updates(map[string]any{"otp_counter": counter, "otp_failed_count": 0, "otp_failed_at": nil})
+	return result.RowsAffected > 0, result.Error
+}
+
+// AttemptOTP 일회용 비밀번호 인증을 시도 할 수 있는 경우 실패 횟수를 미리 증가시키고 시도 시각을 저장한다.
+// 연속으로 실패한 횟수가 max 이상이고 마지막 실패 시각으로 부터 lock 이 지나지 않은 경우 갱신하지 않는다.
+// 동시에 여러 요청이 제한 여부를 확인하더라도 max 회 까지만 시도 할 수 있도록 확인과 증가를 하나의 조건부 갱신으로 처리하며, 갱신 여부를 반환한다.
+// 인증에 성공한 경우 AcceptOTP 에서 실패 횟수를 초기화한다.
+func (g *Gorm) AttemptOTP(id uint, at time.Time, max int, lock time.Duration) (bool, error) {
+	result := g.db.Model(&model.Account{}).
+		Where("id = ? and (otp_failed_count < ? or otp_failed_at is null or otp_failed_at <= ?)", id, max, at.Add(-lock)).
+		Updates(map[string]any{"otp_failed_count": gorm.Expr("otp_failed_count + 1"), "otp_failed_at": at})
+	return result.RowsAffected > 0, result.Error
+}
@@ -16,12 +16,14 @@ type Environment interface {
 }
 
 type Extract struct {
-	Authenticate auth.SimpleAuthenticate
+	Authenticate    auth.SimpleAuthenticate
+	RetrieveProfile auth.RetrieveProfile
 }
 
 func APIRouting(route *gin.Engine, env Environment) Extract {
 	repo := repository.NewGorm(env.GetDB())
 	authSrv := service.NewAuthenticationService(repo)
+	accountSrv := service.NewAccountService(repo)
 
 	h := handler.NewAPI(authSrv)
 
@@ -38,7 +40,8 @@ func APIRouting(route *gin.Engine, env Environment) Extract {
 	}
 
 	return Extract{
-		Authenticate: simpleAuth,
+		Authenticate:    simpleAuth,
+		RetrieveProfile: accountSrv.Profile,
 	}
 
 }
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"oauth-server-go/internal/pkg/auth"
+)
+
+// AccountService 회원 계정 정보를 제공하는 서비스 객체
+type AccountService struct {
+	repo Repository
+}
+
+// NewAccountService 새 계정 서비스 인스턴스를 생성한다.
+func NewAccountService(repo Repository) *AccountService {
+	return &AccountService{repo: repo}
+}
+
+// Profile 인자로 받은 유저 아이디의 프로필 정보를 조회한다.
+func (s *AccountService) Profile(username string) (*auth.Profile, error) {
+	account, err := s.repo.FindByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Profile{
+		Username:      account.Username,
+		Name:          account.Name,
+		Email:         account.Email,
+		EmailVerified: account.EmailVerified,
+	}, nil
+}
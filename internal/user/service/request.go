@@ -4,14 +4,20 @@ package service
 type AuthenticationRequest struct {
 	Username string `json:"username" form:"username"`
 	Password string `json:"password" form:"password"`
+
+	// OTP 다중 요소 인증을 위한 일회용 비밀번호. 생략시 패스워드로만 인증한다.
+	OTP string `json:"otp" form:"otp"`
 }
 
 // Principal 인증된 회원의 정보를 저장하는 구조체
 type Principal struct {
 	Username string
+
+	// Methods 인증에 사용한 인증 방식 참조값(amr) 목록
+	Methods []string
 }
 
 // NewPrincipal 새 인증 인스턴스를 생성한다.
-func NewPrincipal(u string) *Principal {
-	return &Principal{Username: u}
+func NewPrincipal(u string, methods ...string) *Principal {
+	return &Principal{Username: u, Methods: methods}
 }
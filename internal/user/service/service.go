@@ -2,9 +2,12 @@ package service
 
 import (
 	"fmt"
+	"oauth-server-go/internal/pkg/auth"
 	usererr "oauth-server-go/internal/user/errors"
 	"oauth-server-go/internal/user/model"
 	"oauth-server-go/pkg/hash"
+	"oauth-server-go/pkg/totp"
+	"time"
 )
 
 // Repository 계정 저장소 인터페이스
@@ -12,8 +15,24 @@ type Repository interface {
 
 	// FindByUsername 아이디를 인자로 받아 저장소에서 회원을 검색한다.
 	FindByUsername(u string) (*model.Account, error)
+
+	// AcceptOTP 인증에 성공한 일회용 비밀번호의 주기 카운터를 저장하고 실패 횟수를 초기화한다.
+	// 저장된 카운터 보다 크지 않아 저장하지 않은 경우 false 를 반환한다.
+	AcceptOTP(id uint, counter int64) (bool, error)
+
+	// AttemptOTP 일회용 비밀번호 인증을 시도 할 수 있는 경우 실패 횟수를 증가시키고 시도 시각을 저장한다.
+	// 연속으로 실패한 횟수가 max 이상이고 마지막 실패 시각으로 부터 lock 이 지나지 않아 갱신하지 않은 경우 false 를 반환한다.
+	AttemptOTP(id uint, at time.Time, max int, lock time.Duration) (bool, error)
 }
 
+const (
+	// maxOTPFailures 연속으로 허용하는 일회용 비밀번호 인증 실패 횟수
+	maxOTPFailures = 5
+
+	// otpLockDuration 실패 횟수를 초과한 경우 마지막 실패 시각으로 부터 일회용 비밀번호 인증을 제한하는 시간
+	otpLockDuration = 5 * time.Minute
+)
+
 // AuthenticationService 회원의 인증을 제공하는 서비스 객체
 type AuthenticationService struct {
 	repo Repository
@@ -25,6 +44,9 @@ func NewAuthenticationService(repo Repository) *AuthenticationService {
 }
 
 // Auth 인증 요청을 받아 인증 프로세스를 실행하고 인증된 사용자 인스턴스를 생성한다.
+// 일회용 비밀번호가 입력된 경우 계정에 등록된 TOTP 비밀키로 검증하여 다중 요소 인증을 한다.
+// 이미 사용한 일회용 비밀번호는 다시 사용 할 수 없으며, 연속으로 실패한 횟수가 maxOTPFailures 이상인 경우
+// 마지막 실패 시각으로 부터 otpLockDuration 동안 일회용 비밀번호 인증을 제한한다.
 func (s *AuthenticationService) Auth(request *AuthenticationRequest) (*Principal, error) {
 	if request.Username == "" || request.Password == "" {
 		return nil, fmt.Errorf("%w: username or password is missing", usererr.ErrRequireParamsMissing)
@@ -45,5 +67,33 @@ func (s *AuthenticationService) Auth(request *AuthenticationRequest) (*Principal
 		return nil, usererr.ErrAccountLocked
 	}
 
-	return NewPrincipal(account.Username), nil
+	if request.OTP == "" {
+		return NewPrincipal(account.Username, auth.MethodPassword), nil
+	}
+	if account.OTPSecret == nil {
+		return nil, usererr.ErrOTPNotMatched
+	}
+
+	// 동시에 여러 요청으로 실패 횟수 제한을 우회 할 수 없도록 검증 전에 실패 횟수를 먼저 증가시키고 성공한 경우 초기화한다.
+	now := time.Now()
+	if attempted, err := s.repo.AttemptOTP(account.ID, now, maxOTPFailures, otpLockDuration); err != nil {
+		return nil, fmt.Errorf("error occurred while saving otp attempt: %w", err)
+	} else if !attempted {
+		return nil, usererr.ErrOTPAttemptsExceeded
+	}
+
+	last := int64(-1)
+	if account.OTPCounter != nil {
+		last = *account.OTPCounter
+	}
+	counter, ok := totp.Validate(*account.OTPSecret, request.OTP, now, last)
+	if !ok {
+		return nil, usererr.ErrOTPNotMatched
+	}
+	if accepted, err := s.repo.AcceptOTP(account.ID, counter); err != nil {
+		return nil, fmt.Errorf("error occurred while saving otp counter: %w", err)
+	} else if !accepted {
+		return nil, usererr.ErrOTPNotMatched
+	}
+	return NewPrincipal(account.Username, auth.MethodPassword, auth.MethodOTP, auth.MethodMultiFactor), nil
 }
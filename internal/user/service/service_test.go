@@ -0,0 +1,135 @@
+package service
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	usererr "oauth-server-go/internal/user/errors"
+	"oauth-server-go/internal/user/model"
+	"oauth-server-go/pkg/hash"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testUsername = "username"
+	testPassword = "password"
+
+	// testOTPSecret 테스트용 TOTP 비밀키
+	testOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	// testWrongOTP 어떤 주기의 일회용 비밀번호와도 일치하지 않는 비밀번호
+	testWrongOTP = "abcdef"
+)
+
+// memoryRepository 조건부 갱신을 잠금으로 흉내 내는 메모리 계정 저장소
+type memoryRepository struct {
+	mu      sync.Mutex
+	account model.Account
+}
+
+func (r *memoryRepository) FindByUsername(u string) (*model.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u != r.account.Username {
+		return nil, usererr.ErrPasswordNotMatched
+	}
+	account := r.account
+	return &account, nil
+}
+
+func (r *memoryRepository) AcceptOTP(_ uint, counter int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.account.OTPCounter != nil && *r.account.OTPCounter >= counter {
+		return false, nil
+	}
+	r.account.OTPCounter = &counter
+	r.account.OTPFailedCount = 0
+	r.account.OTPFailedAt = sql.NullTime{}
+	return true, nil
+}
+
+func (r *memoryRepository) AttemptOTP(_ uint, at time.Time, max int, lock time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.account.OTPFailedCount >= max && r.account.OTPFailedAt.Valid && r.account.OTPFailedAt.Time.After(at.Add(-lock)) {
+		return false, nil
+	}
+	r.account.OTPFailedCount++
+	r.account.OTPFailedAt = sql.NullTime{Time: at, Valid: true}
+	return true, nil
+}
+
+func newMemoryRepository(t *testing.T) *memoryRepository {
+	password, err := hash.HashingCost(testPassword, 4)
+	assert.Nil(t, err)
+
+	secret := testOTPSecret
+	return &memoryRepository{account: model.Account{
+		ID:        1,
+		Username:  testUsername,
+		Password:  password,
+		Active:    true,
+		OTPSecret: &secret,
+	}}
+}
+
+func TestAuthenticationService_Auth_OTPLock(t *testing.T) {
+	request := &AuthenticationRequest{Username: testUsername, Password: testPassword, OTP: testWrongOTP}
+
+	t.Run("동시에 요청해도 허용 횟수 까지만 일회용 비밀번호를 검증", func(t *testing.T) {
+		repo := newMemoryRepository(t)
+		service := NewAuthenticationService(repo)
+
+		var (
+			wg                  sync.WaitGroup
+			mu                  sync.Mutex
+			notMatched, blocked int
+		)
+		for range maxOTPFailures * 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := service.Auth(request)
+
+				mu.Lock()
+				defer mu.Unlock()
+				switch err {
+				case usererr.ErrOTPNotMatched:
+					notMatched++
+				case usererr.ErrOTPAttemptsExceeded:
+					blocked++
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, maxOTPFailures, notMatched)
+		assert.Equal(t, maxOTPFailures*3, blocked)
+		assert.Equal(t, maxOTPFailures, repo.account.OTPFailedCount)
+	})
+
+	t.Run("제한 시간이 지난 경우 다시 검증", func(t *testing.T) {
+		repo := newMemoryRepository(t)
+		repo.account.OTPFailedCount = maxOTPFailures
+		repo.account.OTPFailedAt = sql.NullTime{Time: time.Now().Add(-otpLockDuration - time.Second), Valid: true}
+		service := NewAuthenticationService(repo)
+
+		_, err := service.Auth(request)
+		assert.ErrorIs(t, err, usererr.ErrOTPNotMatched)
+		assert.Equal(t, maxOTPFailures+1, repo.account.OTPFailedCount)
+
+		_, err = service.Auth(request)
+		assert.ErrorIs(t, err, usererr.ErrOTPAttemptsExceeded)
+	})
+
+	t.Run("일회용 비밀번호를 입력하지 않은 경우 실패 횟수를 증가시키지 않음", func(t *testing.T) {
+		repo := newMemoryRepository(t)
+		service := NewAuthenticationService(repo)
+
+		_, err := service.Auth(&AuthenticationRequest{Username: testUsername, Password: testPassword})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, repo.account.OTPFailedCount)
+	})
+}
@@ -4,9 +4,16 @@ import (
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"net/http"
 	"oauth-server-go/internal/config"
+	"oauth-server-go/internal/config/clientstore"
 	"oauth-server-go/internal/config/db"
+	"oauth-server-go/internal/config/dpop"
+	"oauth-server-go/internal/config/jwtbearer"
+	"oauth-server-go/internal/config/keystore"
 	"oauth-server-go/internal/config/log"
+	"oauth-server-go/internal/config/mtls"
+	"oauth-server-go/internal/config/registration"
 	"oauth-server-go/internal/config/session"
 	oauthserver "oauth-server-go/internal/oauth/server"
 	"oauth-server-go/internal/pkg/web"
@@ -17,13 +24,49 @@ const applicationSessionID = "g_session_id"
 
 // SystemEnvironment 시스템 환경
 type SystemEnvironment struct {
-	db *gorm.DB
+	db       *gorm.DB
+	issuer   string
+	keyStore *keystore.Config
+	clients  *clientstore.Config
+	dpop     *dpop.Config
+	tls      *mtls.Config
+
+	jwtBearer    *jwtbearer.Config
+	registration *registration.Config
 }
 
 func (s *SystemEnvironment) GetDB() *gorm.DB {
 	return s.db
 }
 
+func (s *SystemEnvironment) GetIssuer() string {
+	return s.issuer
+}
+
+func (s *SystemEnvironment) GetKeyStore() *keystore.Config {
+	return s.keyStore
+}
+
+func (s *SystemEnvironment) GetClientStore() *clientstore.Config {
+	return s.clients
+}
+
+func (s *SystemEnvironment) GetDPoP() *dpop.Config {
+	return s.dpop
+}
+
+func (s *SystemEnvironment) GetTLS() *mtls.Config {
+	return s.tls
+}
+
+func (s *SystemEnvironment) GetJWTBearer() *jwtbearer.Config {
+	return s.jwtBearer
+}
+
+func (s *SystemEnvironment) GetRegistration() *registration.Config {
+	return s.registration
+}
+
 func main() {
 	c := config.Read()
 
@@ -51,14 +94,32 @@ func main() {
 	route.Use(web.SessionAuthenticationHandler)
 
 	env := SystemEnvironment{
-		db: gormDB,
+		db:       gormDB,
+		issuer:   c.Issuer,
+		keyStore: &c.KeyStore,
+		clients:  &c.ClientStore,
+		dpop:     &c.DPoP,
+		tls:      &c.TLS,
+
+		jwtBearer:    &c.JWTBearer,
+		registration: &c.Registration,
 	}
 
 	userExt := user.APIRouting(route, &env)
 	user.StaticRouting(route)
 
 	oauthserver.SetResourceOwnerAuthenticate(userExt.Authenticate)
+	oauthserver.SetResourceOwnerProfile(userExt.RetrieveProfile)
 	oauthserver.OAuth2RFCRouting(route, &env)
 
-	_ = route.Run(c.Port)
+	if !c.TLS.Enabled() {
+		_ = route.Run(c.Port)
+		return
+	}
+	server := &http.Server{
+		Addr:      c.Port,
+		Handler:   route,
+		TLSConfig: c.TLS.ServerConfig(),
+	}
+	_ = server.ListenAndServeTLS(c.TLS.CertFile, c.TLS.KeyFile)
 }
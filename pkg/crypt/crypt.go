@@ -0,0 +1,117 @@
+package crypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+// saltSize 암호화 키 유도시 사용할 솔트의 크기
+const saltSize = 16
+
+// keySize AES-256 암호화 키의 크기
+const keySize = 32
+
+var (
+	// ErrMalformed 암호문의 형식이 올바르지 않음
+	ErrMalformed = errors.New("malformed ciphertext")
+
+	// ErrEmptySecret 암호화 키를 유도할 비밀번호가 입력되지 않음
+	ErrEmptySecret = errors.New("secret is empty")
+)
+
+// Encrypt 입력 받은 비밀번호로 암호화 키를 유도(scrypt)하고 AES-256-GCM 알고리즘을 이용하여 평문을 암호화 한다.
+// 반환되는 암호문은 솔트, 논스, 암호문 순서로 이어 붙여진 형태를 가진다.
+// 비밀번호가 빈 문자열인 경우 [ErrEmptySecret] 을 반환한다.
+func Encrypt(secret string, plaintext []byte) ([]byte, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(secret, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	ciphertext := append(salt, nonce...)
+	return gcm.Seal(ciphertext, nonce, plaintext, nil), nil
+}
+
+// Decrypt [Encrypt] 로 암호화된 암호문을 입력 받은 비밀번호로 복호화 한다.
+func Decrypt(secret string, ciphertext []byte) ([]byte, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+	if len(ciphertext) < saltSize {
+		return nil, ErrMalformed
+	}
+	gcm, err := newGCM(secret, ciphertext[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	ciphertext = ciphertext[saltSize:]
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+// Cipher 비밀번호로부터 한번 유도한 암호화 키를 재사용하여 AES-256-GCM 암복호화를 수행한다.
+//
+// [Encrypt], [Decrypt] 는 호출 할 때마다 scrypt 로 암호화 키를 유도하므로 요청마다 반복되는 암복호화에는 적합하지 않다.
+// Cipher 는 생성시 한번만 키를 유도하며 반환되는 암호문은 논스, 암호문 순서로 이어 붙여진 형태를 가진다.
+type Cipher struct {
+	gcm cipher.AEAD
+}
+
+// NewCipher 입력 받은 비밀번호와 솔트로 암호화 키를 유도하여 [Cipher] 를 생성한다.
+// 비밀번호가 빈 문자열인 경우 [ErrEmptySecret] 을 반환한다.
+func NewCipher(secret string, salt []byte) (*Cipher, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+	gcm, err := newGCM(secret, salt)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{gcm: gcm}, nil
+}
+
+// Encrypt 평문을 매번 새로운 논스로 암호화 한다.
+func (c *Cipher) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return c.gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt [Cipher.Encrypt] 로 암호화된 암호문을 복호화 한다.
+func (c *Cipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < c.gcm.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := ciphertext[:c.gcm.NonceSize()], ciphertext[c.gcm.NonceSize():]
+	return c.gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(secret string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(secret), salt, 1<<15, 8, 1, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,129 @@
+package crypt
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// testSecret 테스트용으로 사용할 비밀번호
+const testSecret = "test_secret"
+
+func TestEncrypt(t *testing.T) {
+	tests := []struct {
+		name      string
+		plaintext []byte
+	}{
+		{name: "빈 평문", plaintext: []byte{}},
+		{name: "짧은 평문", plaintext: []byte("plaintext")},
+		{name: "긴 평문", plaintext: make([]byte, 4096)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ciphertext, err := Encrypt(testSecret, tc.plaintext)
+			assert.Nil(t, err)
+			assert.NotContains(t, string(ciphertext), "plaintext")
+
+			plaintext, err := Decrypt(testSecret, ciphertext)
+			assert.Nil(t, err)
+			assert.Equal(t, string(tc.plaintext), string(plaintext))
+		})
+	}
+
+	t.Run("같은 평문도 매번 다른 암호문으로 암호화", func(t *testing.T) {
+		first, err := Encrypt(testSecret, []byte("plaintext"))
+		assert.Nil(t, err)
+		second, err := Encrypt(testSecret, []byte("plaintext"))
+		assert.Nil(t, err)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("비밀번호가 입력되지 않은 경우 에러 발생", func(t *testing.T) {
+		_, err := Encrypt("", []byte("plaintext"))
+		assert.ErrorIs(t, err, ErrEmptySecret)
+	})
+}
+
+func TestDecrypt(t *testing.T) {
+	ciphertext, err := Encrypt(testSecret, []byte("plaintext"))
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name       string
+		secret     string
+		ciphertext []byte
+		err        error
+	}{
+		{name: "다른 비밀번호로 복호화", secret: "wrong_secret", ciphertext: ciphertext},
+		{name: "비밀번호가 입력되지 않음", secret: "", ciphertext: ciphertext, err: ErrEmptySecret},
+		{name: "솔트보다 짧은 암호문", secret: testSecret, ciphertext: ciphertext[:saltSize-1], err: ErrMalformed},
+		{name: "논스가 없는 암호문", secret: testSecret, ciphertext: ciphertext[:saltSize+1], err: ErrMalformed},
+		{name: "변조된 암호문", secret: testSecret, ciphertext: append(append([]byte{}, ciphertext[:len(ciphertext)-1]...), ciphertext[len(ciphertext)-1]^0xff)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plaintext, err := Decrypt(tc.secret, tc.ciphertext)
+			assert.NotNil(t, err)
+			assert.Nil(t, plaintext)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestCipher(t *testing.T) {
+	salt := []byte("test_salt")
+	c, err := NewCipher(testSecret, salt)
+	assert.Nil(t, err)
+
+	t.Run("암호화한 평문을 복호화", func(t *testing.T) {
+		ciphertext, err := c.Encrypt([]byte("plaintext"))
+		assert.Nil(t, err)
+		assert.NotContains(t, string(ciphertext), "plaintext")
+
+		plaintext, err := c.Decrypt(ciphertext)
+		assert.Nil(t, err)
+		assert.Equal(t, "plaintext", string(plaintext))
+	})
+
+	t.Run("같은 평문도 매번 다른 암호문으로 암호화", func(t *testing.T) {
+		first, err := c.Encrypt([]byte("plaintext"))
+		assert.Nil(t, err)
+		second, err := c.Encrypt([]byte("plaintext"))
+		assert.Nil(t, err)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("같은 비밀번호와 솔트로 생성한 Cipher 는 서로의 암호문을 복호화", func(t *testing.T) {
+		other, err := NewCipher(testSecret, salt)
+		assert.Nil(t, err)
+		ciphertext, err := c.Encrypt([]byte("plaintext"))
+		assert.Nil(t, err)
+
+		plaintext, err := other.Decrypt(ciphertext)
+		assert.Nil(t, err)
+		assert.Equal(t, "plaintext", string(plaintext))
+	})
+
+	t.Run("다른 비밀번호로 생성한 Cipher 는 복호화 실패", func(t *testing.T) {
+		other, err := NewCipher("wrong_secret", salt)
+		assert.Nil(t, err)
+		ciphertext, err := c.Encrypt([]byte("plaintext"))
+		assert.Nil(t, err)
+
+		_, err = other.Decrypt(ciphertext)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("논스보다 짧은 암호문", func(t *testing.T) {
+		_, err := c.Decrypt([]byte{0x01})
+		assert.ErrorIs(t, err, ErrMalformed)
+	})
+
+	t.Run("비밀번호가 입력되지 않은 경우 에러 발생", func(t *testing.T) {
+		_, err := NewCipher("", salt)
+		assert.ErrorIs(t, err, ErrEmptySecret)
+	})
+}
@@ -17,6 +17,9 @@ func (s *Strings) Scan(src any) error {
 		val = string(src.([]byte))
 	case string:
 		val = src.(string)
+	case nil:
+		*s = nil
+		return nil
 	default:
 		return errors.New("val cannot casting")
 	}
@@ -0,0 +1,78 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	// step 일회용 비밀번호가 바뀌는 주기 (30초)
+	step = 30 * time.Second
+
+	// digits 일회용 비밀번호 자릿수
+	digits = 6
+
+	// skew 기기간 시간 차이를 고려하여 현재 주기 전후로 허용할 주기 수
+	skew = 1
+)
+
+// Validate [RFC 6238] TOTP 일회용 비밀번호가 유효한지 확인하고 일치한 비밀번호의 주기 카운터를 반환한다.
+//
+// secret 은 인증 앱에 등록한 Base32 인코딩된 비밀키로 패딩(=)과 대소문자, 공백은 구분하지 않는다.
+// 기기간 시간 차이를 고려하여 현재 주기와 전후 1 주기의 비밀번호를 허용하며, 비밀키를 디코딩 할 수 없는 경우 false 를 반환한다.
+//
+// 한번 사용한 비밀번호를 다시 사용 할 수 없도록 last 는 마지막으로 인증에 성공한 주기 카운터를 받으며
+// 카운터가 last 이하인 비밀번호는 유효하지 않은 것으로 처리한다. ([RFC 6238 Section 5.2])
+// 인증에 성공한 적이 없는 경우 음수를 입력한다.
+//
+// [RFC 6238]: https://datatracker.ietf.org/doc/html/rfc6238
+// [RFC 6238 Section 5.2]: https://datatracker.ietf.org/doc/html/rfc6238#section-5.2
+func Validate(secret, code string, now time.Time, last int64) (int64, bool) {
+	key, err := decodeSecret(secret)
+	if err != nil || len(code) != digits {
+		return 0, false
+	}
+
+	counter := now.Unix() / int64(step/time.Second)
+	for i := -skew; i <= skew; i++ {
+		c := counter + int64(i)
+		if c <= last {
+			continue
+		}
+		expected := generate(key, uint64(c))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// decodeSecret Base32 인코딩된 비밀키를 디코딩한다.
+func decodeSecret(secret string) ([]byte, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	normalized = strings.TrimRight(normalized, "=")
+	return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(normalized)
+}
+
+// generate [RFC 4226] HOTP 알고리즘으로 카운터의 일회용 비밀번호를 생성한다.
+//
+// [RFC 4226]: https://datatracker.ietf.org/doc/html/rfc4226#section-5.3
+func generate(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", digits, value%uint32(math.Pow10(digits)))
+}
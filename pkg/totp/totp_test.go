@@ -0,0 +1,86 @@
+package totp
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// testSecret RFC 6238 부록 B 의 SHA1 비밀키("12345678901234567890")를 Base32 로 인코딩한 값
+const testSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// testNow 테스트 기준 시각 (RFC 6238 부록 B 의 1111111111 초)
+var testNow = time.Unix(1111111111, 0)
+
+// codeAt 기준 시각으로 부터 offset 주기 만큼 떨어진 주기의 일회용 비밀번호를 생성한다.
+func codeAt(t *testing.T, now time.Time, offset int64) string {
+	key, err := decodeSecret(testSecret)
+	assert.Nil(t, err)
+	return generate(key, uint64(now.Unix()/int64(step/time.Second)+offset))
+}
+
+func TestValidate_RFC6238(t *testing.T) {
+	// RFC 6238 부록 B 의 SHA1 테스트 벡터 중 하위 6자리
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+		{unix: 20000000000, code: "353130"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.code, func(t *testing.T) {
+			now := time.Unix(tc.unix, 0)
+			counter, ok := Validate(testSecret, tc.code, now, -1)
+			assert.True(t, ok)
+			assert.Equal(t, tc.unix/30, counter)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	current := testNow.Unix() / 30
+
+	tests := []struct {
+		name    string
+		secret  string
+		code    string
+		last    int64
+		ok      bool
+		counter int64
+	}{
+		{name: "현재 주기의 비밀번호", secret: testSecret, code: codeAt(t, testNow, 0), last: -1, ok: true, counter: current},
+		{name: "이전 주기의 비밀번호 허용", secret: testSecret, code: codeAt(t, testNow, -1), last: -1, ok: true, counter: current - 1},
+		{name: "다음 주기의 비밀번호 허용", secret: testSecret, code: codeAt(t, testNow, 1), last: -1, ok: true, counter: current + 1},
+		{name: "2 주기 전의 비밀번호", secret: testSecret, code: codeAt(t, testNow, -2), last: -1},
+		{name: "2 주기 후의 비밀번호", secret: testSecret, code: codeAt(t, testNow, 2), last: -1},
+		{name: "소문자와 공백, 패딩이 포함된 비밀키", secret: "gezd gnbv gy3t qojq gezd gnbv gy3t qojq====", code: codeAt(t, testNow, 0), last: -1, ok: true, counter: current},
+		{name: "디코딩 할 수 없는 비밀키", secret: "!!!!", code: codeAt(t, testNow, 0), last: -1},
+		{name: "자릿수가 다른 비밀번호", secret: testSecret, code: "12345", last: -1},
+		{name: "이미 사용한 주기의 비밀번호", secret: testSecret, code: codeAt(t, testNow, 0), last: current},
+		{name: "마지막으로 사용한 주기 이전의 비밀번호", secret: testSecret, code: codeAt(t, testNow, -1), last: current},
+		{name: "마지막으로 사용한 주기 이후의 비밀번호", secret: testSecret, code: codeAt(t, testNow, 1), last: current, ok: true, counter: current + 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			counter, ok := Validate(tc.secret, tc.code, testNow, tc.last)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.counter, counter)
+		})
+	}
+
+	t.Run("인증에 성공한 비밀번호를 다시 사용", func(t *testing.T) {
+		code := codeAt(t, testNow, 0)
+		counter, ok := Validate(testSecret, code, testNow, -1)
+		assert.True(t, ok)
+
+		_, ok = Validate(testSecret, code, testNow.Add(step), counter)
+		assert.False(t, ok)
+	})
+}
@@ -3,11 +3,18 @@ create table account (
     id bigint primary key default nextval('account_id_seq'),
     username varchar(128) not null unique ,
     password varchar(128) not null,
+    name varchar(128),
+    email varchar(320),
+    email_verified bool not null default false,
     active bool not null default false,
     active_token varchar(128),
     active_token_expires timestamp,
     password_token varchar(128),
     password_token_expires timestamp,
+    otp_secret varchar(128),
+    otp_counter bigint,
+    otp_failed_count int not null default 0,
+    otp_failed_at timestamp,
     last_mod_password_at timestamp,
     reg_at timestamp default now(),
     mod_at timestamp
@@ -33,7 +40,28 @@ create table oauth2_client (
     secret varchar(128) not null ,
     owner_id varchar(128) not null ,
     redirect_uris text,
-    reg_at timestamp default now()
+    token_format varchar(32) not null default 'opaque',
+    reg_at timestamp default now(),
+    grant_types text,
+    token_endpoint_auth_method varchar(32),
+    logo_uri text,
+    contacts text,
+    registration_token varchar(128),
+    jwt_secret bytea,
+    require_pushed_authorization_requests boolean not null default false,
+    jwks_uri text,
+    jwks text,
+    require_signed_request_object boolean not null default false,
+    request_uris text,
+    tls_client_auth_subject_dn varchar(512),
+    tls_client_auth_san_dns varchar(256),
+    tls_client_certificate_bound_access_tokens boolean not null default false,
+    backchannel_token_delivery_mode varchar(16),
+    backchannel_client_notification_endpoint text,
+    introspection_signed_response_alg varchar(16),
+    introspection_encrypted_response_alg varchar(32),
+    introspection_encrypted_response_enc varchar(32),
+    consent_ttl_sec integer not null default 2592000
 );
 alter sequence oauth2_client_id_seq owned by oauth2_client.id;
 
@@ -44,6 +72,33 @@ create table oauth2_client_scope (
     primary key (client_id, scope_id)
 );
 
+create sequence oauth2_resource_server_id_seq;
+create table oauth2_resource_server (
+    id bigint primary key default nextval('oauth2_resource_server_id_seq'),
+    resource_uri varchar(256) not null unique ,
+    resource_name varchar(128) not null ,
+    client_id varchar(128),
+    reg_at timestamp default now()
+);
+alter sequence oauth2_resource_server_id_seq owned by oauth2_resource_server.id;
+
+create table oauth2_resource_server_scope (
+    resource_server_id bigint,
+    scope_id bigint,
+
+    primary key (resource_server_id, scope_id)
+);
+
+create sequence oauth2_authorization_detail_type_id_seq;
+create table oauth2_authorization_detail_type (
+    id bigint primary key default nextval('oauth2_authorization_detail_type_id_seq'),
+    detail_type varchar(128) not null unique ,
+    description varchar(256),
+    detail_schema text,
+    reg_at timestamp default now()
+);
+alter sequence oauth2_authorization_detail_type_id_seq owned by oauth2_authorization_detail_type.id;
+
 create sequence oauth2_authorization_code_seq;
 create table oauth2_authorization_code (
     id bigint primary key default nextval('oauth2_authorization_code_seq'),
@@ -54,6 +109,12 @@ create table oauth2_authorization_code (
     code_challenge varchar(128),
     code_challenge_method varchar(32),
     state text,
+    nonce text,
+    auth_time timestamp,
+    acr varchar(128),
+    amr text,
+    resources text,
+    authorization_details text,
     issued_at timestamp default now(),
     expired_at timestamp not null
 );
@@ -66,12 +127,103 @@ create table oauth2_code_scope(
     primary key (code_id, scope_id)
 );
 
+create sequence oauth2_device_code_seq;
+create table oauth2_device_code (
+    id bigint primary key default nextval('oauth2_device_code_seq'),
+    device_code varchar(128) not null unique,
+    user_code varchar(16) not null unique,
+    client_id bigint not null,
+    username varchar(128),
+    device_status varchar(16) not null,
+    polling_interval int not null,
+    last_polled_at timestamp,
+    auth_time timestamp,
+    acr varchar(128),
+    amr text,
+    issued_at timestamp default now(),
+    expired_at timestamp not null
+);
+alter sequence oauth2_device_code_seq owned by oauth2_device_code.id;
+
+create table oauth2_device_code_scope(
+    device_code_id bigint,
+    scope_id bigint,
+
+    primary key (device_code_id, scope_id)
+);
+
+create sequence oauth2_backchannel_authentication_seq;
+create table oauth2_backchannel_authentication (
+    id bigint primary key default nextval('oauth2_backchannel_authentication_seq'),
+    auth_req_id varchar(128) not null unique,
+    client_id bigint not null,
+    username varchar(128) not null,
+    binding_message varchar(256),
+    client_notification_token varchar(1024),
+    backchannel_status varchar(16) not null,
+    polling_interval int not null,
+    last_polled_at timestamp,
+    auth_time timestamp,
+    acr varchar(128),
+    amr text,
+    issued_at timestamp default now(),
+    expired_at timestamp not null
+);
+alter sequence oauth2_backchannel_authentication_seq owned by oauth2_backchannel_authentication.id;
+
+create table oauth2_backchannel_authentication_scope(
+    backchannel_authentication_id bigint,
+    scope_id bigint,
+
+    primary key (backchannel_authentication_id, scope_id)
+);
+
+create sequence oauth2_consent_seq;
+create table oauth2_consent (
+    id bigint primary key default nextval('oauth2_consent_seq'),
+    username varchar(128) not null,
+    client_id bigint not null,
+    granted_at timestamp default now(),
+    expires_at timestamp not null,
+
+    unique (username, client_id)
+);
+alter sequence oauth2_consent_seq owned by oauth2_consent.id;
+
+create table oauth2_consent_scope(
+    consent_id bigint,
+    scope_id bigint,
+
+    primary key (consent_id, scope_id)
+);
+
+create sequence oauth2_pushed_request_seq;
+create table oauth2_pushed_request (
+    id bigint primary key default nextval('oauth2_pushed_request_seq'),
+    request_uri varchar(256) not null unique,
+    client_id bigint not null,
+    request text not null,
+    issued_at timestamp default now(),
+    expired_at timestamp not null
+);
+alter sequence oauth2_pushed_request_seq owned by oauth2_pushed_request.id;
+
 create sequence oauth2_access_token_id_seq;
 create table oauth2_access_token (
     id bigint primary key default nextval('oauth2_access_token_id_seq'),
-    token varchar(128) not null unique,
+    token text not null,
+    token_hash varchar(64) not null unique,
+    jti varchar(128),
     client_id bigint not null ,
     username varchar(128),
+    audience text,
+    act text,
+    jkt varchar(128),
+    x5t_s256 varchar(128),
+    authorization_details text,
+    auth_time timestamp,
+    acr varchar(128),
+    amr text,
     issued_at timestamp default now(),
     expired_at timestamp not null
 );
@@ -89,7 +241,47 @@ create table oauth2_refresh_token (
     id bigint primary key default nextval('oauth2_refresh_token_id_seq'),
     token varchar(128) not null unique ,
     access_token_id bigint not null ,
+    jkt varchar(128),
+    x5t_s256 varchar(128),
+    scopes text,
+    resources text,
+    authorization_details text,
     issued_at timestamp default now(),
     expired_at timestamp not null
 );
-alter sequence oauth2_refresh_token_id_seq owned by oauth2_refresh_token.id;
\ No newline at end of file
+alter sequence oauth2_refresh_token_id_seq owned by oauth2_refresh_token.id;
+
+create sequence oauth2_dpop_proof_id_seq;
+create table oauth2_dpop_proof (
+    id bigint primary key default nextval('oauth2_dpop_proof_id_seq'),
+    jkt varchar(128) not null,
+    jti varchar(256) not null,
+    expired_at timestamp not null,
+
+    unique (jkt, jti)
+);
+alter sequence oauth2_dpop_proof_id_seq owned by oauth2_dpop_proof.id;
+
+create sequence oauth2_client_assertion_id_seq;
+create table oauth2_client_assertion (
+    id bigint primary key default nextval('oauth2_client_assertion_id_seq'),
+    client_id varchar(128) not null,
+    jti varchar(256) not null,
+    expired_at timestamp not null,
+
+    unique (client_id, jti)
+);
+alter sequence oauth2_client_assertion_id_seq owned by oauth2_client_assertion.id;
+
+create sequence oauth2_signing_key_id_seq;
+create table oauth2_signing_key (
+    id bigint primary key default nextval('oauth2_signing_key_id_seq'),
+    kid varchar(128) not null unique ,
+    algorithm varchar(32) not null ,
+    key_state varchar(32) not null ,
+    private_key bytea not null ,
+    created_at timestamp default now(),
+    activated_at timestamp,
+    retired_at timestamp
+);
+alter sequence oauth2_signing_key_id_seq owned by oauth2_signing_key.id;